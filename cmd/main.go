@@ -12,7 +12,10 @@ import (
 	"github.com/mikhailshtv/stockLkBack/internal/grpc"
 	"github.com/mikhailshtv/stockLkBack/internal/handler"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/scheduler"
 	"github.com/mikhailshtv/stockLkBack/internal/service"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/mailer"
 	"github.com/mikhailshtv/stockLkBack/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -57,6 +60,11 @@ func main() {
 		zap.String("log_level", cfg.Logging.Level),
 	)
 
+	if err := jwtgen.Configure(cfg.Auth); err != nil {
+		logger.GetLogger().Error("failed to configure jwt keys", zap.Error(err))
+		return
+	}
+
 	sqlConfig := repository.SQLConfig{
 		Host:           cfg.DB.Host,
 		Port:           cfg.DB.Port,
@@ -88,18 +96,50 @@ func main() {
 		return
 	}
 
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		logger.GetLogger().Error("failed to configure mailer", zap.Error(err))
+		return
+	}
+
 	repo := repository.NewRepository(db, clientRedis)
-	services := service.NewService(ctx, repo)
+	services := service.NewService(ctx, repo, cfg, mail)
 	handlers := handler.NewHandler(services)
 
 	go grpc.StartServer(handlers)
 
+	jobs := scheduler.NewScheduler(
+		scheduler.Job{
+			Name:     "apply_scheduled_price_changes",
+			Interval: cfg.Scheduler.PriceChangesInterval,
+			Run: func() error {
+				_, err := services.Product.ApplyScheduledPriceChanges()
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "materialize_recurring_orders",
+			Interval: cfg.Scheduler.RecurringOrdersInterval,
+			Run: func() error {
+				_, err := services.RecurringOrder.ProcessDue()
+				return err
+			},
+		},
+	)
+	go jobs.Start(ctx)
+
 	newApp, err := app.NewApp(ctx, cfg, handlers)
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
 	r := gin.Default()
+	// Без явного списка прокси заголовок X-Forwarded-For не учитывается: иначе клиент
+	// мог бы подменять свой IP и обходить ограничение попыток входа.
+	if err := r.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		log.Println(err.Error())
+		return
+	}
 	url := ginSwagger.URL("/api/v1/swagger/doc.json")
 	r.GET("api/v1/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 	err = newApp.Start(r)
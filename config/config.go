@@ -17,6 +17,7 @@ type (
 		IdleTimeout       time.Duration `yaml:"idle_timeout"`
 		ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
 		AllowedOrigins    []string      `yaml:"allowed_origins"`
+		TrustedProxies    []string      `yaml:"trusted_proxies"`
 	}
 
 	DB struct {
@@ -40,11 +41,91 @@ type (
 		Level string `yaml:"level"`
 	}
 
+	Scheduler struct {
+		PriceChangesInterval    time.Duration `yaml:"price_changes_interval"`
+		RecurringOrdersInterval time.Duration `yaml:"recurring_orders_interval"`
+	}
+
+	// Auth настройки выпуска токенов. Access-токен живет недолго, сессию продлевает refresh-токен.
+	// Токены подписываются ключом SigningKeyID, а проверяются любым ключом из Keys:
+	// при ротации новый ключ добавляется и назначается подписывающим, а прежний
+	// остается в списке, пока не истекут выпущенные им токены.
+	Auth struct {
+		AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+		RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+		Issuer          string        `yaml:"issuer"`
+		SigningKeyID    string        `yaml:"signing_key_id"`
+		Keys            []JWTKey      `yaml:"keys"`
+		// PasswordResetURL адрес страницы сброса пароля, к нему добавляется параметр token.
+		PasswordResetURL string        `yaml:"password_reset_url"`
+		PasswordResetTTL time.Duration `yaml:"password_reset_ttl"`
+		// EmailVerificationURL адрес страницы подтверждения email, к нему добавляется параметр token.
+		// Повторно письмо можно запросить не чаще раза в VerificationResendInterval
+		// и не более VerificationHourlyLimit раз в час.
+		EmailVerificationURL       string        `yaml:"email_verification_url"`
+		EmailVerificationTTL       time.Duration `yaml:"email_verification_ttl"`
+		VerificationResendInterval time.Duration `yaml:"verification_resend_interval"`
+		VerificationHourlyLimit    int           `yaml:"verification_hourly_limit"`
+		// После LoginMaxAttempts неудачных попыток входа с одним логином (LoginMaxAttemptsPerIP —
+		// с одного IP-адреса) вход блокируется на LoginLockout, и каждая следующая неудачная
+		// попытка удваивает блокировку вплоть до LoginLockoutMax. Счетчик попыток сбрасывается,
+		// если в течение LoginAttemptsWindow неудачных попыток не было.
+		LoginMaxAttempts      int           `yaml:"login_max_attempts"`
+		LoginMaxAttemptsPerIP int           `yaml:"login_max_attempts_per_ip"`
+		LoginAttemptsWindow   time.Duration `yaml:"login_attempts_window"`
+		LoginLockout          time.Duration `yaml:"login_lockout"`
+		LoginLockoutMax       time.Duration `yaml:"login_lockout_max"`
+		// TwoFactorRequiredRoles роли, которым вход без второго фактора запрещен.
+		TwoFactorRequiredRoles []string `yaml:"two_factor_required_roles"`
+	}
+
+	// JWTKey ключ подписи JWT. Для HS256 секрет берется из переменной окружения SecretEnvKey,
+	// для RS256 и EdDSA ключи читаются из PEM-файлов. Ключу, которым только проверяют
+	// подпись, достаточно открытого ключа.
+	JWTKey struct {
+		ID             string `yaml:"id"`
+		Algorithm      string `yaml:"algorithm"`
+		SecretEnvKey   string `yaml:"secret_env_key"`
+		PrivateKeyFile string `yaml:"private_key_file"`
+		PublicKeyFile  string `yaml:"public_key_file"`
+	}
+
+	// Mail настройки отправки писем. Kind: smtp — через SMTP-сервер Host:Port,
+	// file — письма сохраняются в каталог Dir, console — выводятся в stdout.
+	// Последние два варианта предназначены для разработки и тестов.
+	Mail struct {
+		Kind       string `yaml:"kind"`
+		Host       string `yaml:"host"`
+		Port       string `yaml:"port"`
+		UserEnvKey string `yaml:"user_env_key"`
+		PassEnvKey string `yaml:"pass_env_key"`
+		From       string `yaml:"from"`
+		Dir        string `yaml:"dir"`
+	}
+
+	// Seller реквизиты продавца для счетов и накладных.
+	Seller struct {
+		Name        string `yaml:"name"`
+		INN         string `yaml:"inn"`
+		KPP         string `yaml:"kpp"`
+		Address     string `yaml:"address"`
+		BankName    string `yaml:"bank_name"`
+		BIC         string `yaml:"bic"`
+		Account     string `yaml:"account"`
+		CorrAccount string `yaml:"corr_account"`
+		Director    string `yaml:"director"`
+		Accountant  string `yaml:"accountant"`
+	}
+
 	Config struct {
-		HTTP    HTTP    `yaml:"http"`
-		DB      DB      `yaml:"db"`
-		Redis   Redis   `yaml:"redis"`
-		Logging Logging `yaml:"logging"`
+		HTTP      HTTP      `yaml:"http"`
+		DB        DB        `yaml:"db"`
+		Redis     Redis     `yaml:"redis"`
+		Logging   Logging   `yaml:"logging"`
+		Scheduler Scheduler `yaml:"scheduler"`
+		Auth      Auth      `yaml:"auth"`
+		Mail      Mail      `yaml:"mail"`
+		Seller    Seller    `yaml:"seller"`
 	}
 )
 
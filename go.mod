@@ -3,8 +3,11 @@ module github.com/mikhailshtv/stockLkBack
 go 1.24.0
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/golang/mock v1.6.0
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/mikhailshtv/proto_api v0.1.9
+	github.com/pquerna/otp v1.5.0
 	github.com/swaggo/swag v1.16.4
 	golang.org/x/crypto v0.39.0
 	google.golang.org/grpc v1.73.0
@@ -13,6 +16,7 @@ require (
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
+	github.com/boombuler/barcode v1.0.1-0.20190219062509-6c824513bacc // indirect
 	github.com/bytedance/sonic v1.11.6 // indirect
 	github.com/bytedance/sonic/loader v0.1.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -12,6 +12,7 @@ import (
 	"github.com/mikhailshtv/stockLkBack/config"
 	"github.com/mikhailshtv/stockLkBack/internal/handler"
 	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -40,35 +41,170 @@ func (a *App) Start(r *gin.Engine) error {
 	r.Use(middleware.LoggingMiddleware())
 	r.Use(middleware.ErrorHandlerMiddleware())
 
+	auth := middleware.TokenAuthMiddleware(
+		a.handler.Services.User,
+		a.handler.Services.Role,
+		a.handler.Services.APIKey,
+	)
+	can := middleware.RequirePermission
+	session := middleware.RequireUserSession()
+
+	r.GET("/.well-known/jwks.json", a.handler.JWKS)
+
 	api := r.Group(a.cfg.HTTP.BasePath)
 	api.POST("/login", a.handler.Login)
+	api.POST("/login/2fa", a.handler.LoginTwoFactor)
+	api.POST("/login/2fa/setup", a.handler.SetupTwoFactorLogin)
+	api.POST("/token/refresh", a.handler.RefreshToken)
+	api.POST("/password/forgot", a.handler.ForgotPassword)
+	api.POST("/password/reset", a.handler.ResetPassword)
+	api.POST("/email/verify", a.handler.VerifyEmail)
+	api.POST("/logout", auth, session, a.handler.Logout)
+	api.POST("/logout/all", auth, session, a.handler.LogoutAll)
 	{
 		orders := api.Group("/orders")
 		{
-			orders.POST("", middleware.TokenAuthMiddleware(), a.handler.CreateOrder)
-			orders.PUT("/:id", middleware.TokenAuthMiddleware(), a.handler.EditOrder)
-			orders.GET("", middleware.TokenAuthMiddleware(), a.handler.ListOrders)
-			orders.GET("/:id", middleware.TokenAuthMiddleware(), a.handler.GetOrderByID)
-			orders.DELETE("/:id", middleware.TokenAuthMiddleware(), a.handler.DeleteOrder)
-			orders.PATCH("/:id", middleware.TokenAuthMiddleware(), a.handler.ChangeOrderStatus)
+			orders.POST("", auth, can(model.PermOrdersWrite), a.handler.CreateOrder)
+			orders.PUT("/:id", auth, can(model.PermOrdersWrite), a.handler.EditOrder)
+			orders.GET("", auth, can(model.PermOrdersRead), a.handler.ListOrders)
+			orders.GET("/:id", auth, can(model.PermOrdersRead), a.handler.GetOrderByID)
+			orders.DELETE("/:id", auth, can(model.PermOrdersWrite), a.handler.DeleteOrder)
+			orders.PATCH("/:id", auth, can(model.PermOrdersExecute), a.handler.ChangeOrderStatus)
+			orders.PATCH("/:id/shipment", auth, can(model.PermOrdersExecute), a.handler.ShipOrder)
+			orders.POST("/:id/reorder", auth, can(model.PermOrdersWrite), a.handler.ReorderOrder)
+			orders.GET("/:id/comments", auth, can(model.PermOrdersRead), a.handler.ListOrderComments)
+			orders.POST("/:id/comments", auth, can(model.PermOrdersRead), a.handler.CreateOrderComment)
+			orders.DELETE("/:id/comments/:commentId", auth, can(model.PermOrdersRead), a.handler.DeleteOrderComment)
+			orders.GET("/:id/invoice.pdf", auth, can(model.PermOrdersRead), a.handler.GetOrderInvoice)
+			orders.GET("/:id/delivery-note.pdf", auth, can(model.PermOrdersRead), a.handler.GetOrderDeliveryNote)
+		}
+		cart := api.Group("/cart")
+		{
+			cart.GET("", auth, session, a.handler.GetCart)
+			cart.DELETE("", auth, session, a.handler.ClearCart)
+			cart.POST("/items", auth, session, a.handler.AddCartItem)
+			cart.PUT("/items/:productId", auth, session, a.handler.EditCartItem)
+			cart.DELETE("/items/:productId", auth, session, a.handler.DeleteCartItem)
+			cart.POST("/checkout", auth, session, a.handler.CheckoutCart)
+		}
+		addresses := api.Group("/addresses")
+		{
+			addresses.POST("", auth, session, a.handler.CreateAddress)
+			addresses.PUT("/:id", auth, session, a.handler.EditAddress)
+			addresses.GET("", auth, session, a.handler.ListAddresses)
+			addresses.GET("/:id", auth, session, a.handler.GetAddressByID)
+			addresses.DELETE("/:id", auth, session, a.handler.DeleteAddress)
+		}
+		returns := api.Group("/returns")
+		{
+			returns.POST("", auth, session, a.handler.CreateReturn)
+			returns.GET("", auth, session, a.handler.ListReturns)
+			returns.GET("/:id", auth, session, a.handler.GetReturnByID)
+			returns.PATCH("/:id", auth, can(model.PermReturnsDecide), a.handler.DecideReturn)
 		}
 		products := api.Group("/products")
 		{
-			products.POST("", middleware.TokenAuthMiddleware(), a.handler.CreateProduct)
-			products.PUT("/:id", middleware.TokenAuthMiddleware(), a.handler.EditProduct)
-			products.GET("", middleware.TokenAuthMiddleware(), a.handler.ListProduct)
-			products.GET("/:id", middleware.TokenAuthMiddleware(), a.handler.GetProductByID)
-			products.DELETE("/:id", middleware.TokenAuthMiddleware(), a.handler.DeleteProduct)
+			products.POST("", auth, can(model.PermProductsWrite), a.handler.CreateProduct)
+			products.PUT("/:id", auth, can(model.PermProductsWrite), a.handler.EditProduct)
+			products.GET("", auth, a.handler.ListProduct)
+			products.GET("/:id", auth, a.handler.GetProductByID)
+			products.DELETE("/:id", auth, can(model.PermProductsWrite), a.handler.DeleteProduct)
+			products.GET("/:id/prices", auth, can(model.PermProductsWrite), a.handler.GetProductPrices)
+			products.POST("/:id/prices", auth, can(model.PermProductsWrite), a.handler.CreateProductPrice)
+		}
+		api.GET("/units", auth, a.handler.ListUnits)
+		me := api.Group("/me")
+		{
+			me.GET("", auth, session, a.handler.GetMe)
+			me.PATCH("", auth, session, a.handler.EditMe)
+			me.PATCH("/password", auth, session, a.handler.ChangeMyPassword)
+			me.POST("/email/verification", auth, session, a.handler.ResendEmailVerification)
+			me.POST("/2fa", auth, session, a.handler.EnrollTwoFactor)
+			me.POST("/2fa/confirm", auth, session, a.handler.ConfirmTwoFactor)
+			me.DELETE("/2fa", auth, session, a.handler.DisableTwoFactor)
+			me.GET("/orders", auth, can(model.PermOrdersRead), a.handler.ListMyOrders)
 		}
 		users := api.Group("/users")
 		{
 			users.POST("", a.handler.CreateUser) // фактически регистрация пользователя
-			users.PUT("/:id", middleware.TokenAuthMiddleware(), a.handler.EditUser)
-			users.GET("", middleware.TokenAuthMiddleware(), a.handler.ListUsers)
-			users.GET("/:id", middleware.TokenAuthMiddleware(), a.handler.GetUserByID)
-			users.DELETE("/:id", middleware.TokenAuthMiddleware(), a.handler.DeleteUser)
-			users.PATCH("/:id/role", middleware.TokenAuthMiddleware(), a.handler.ChangeUserRole)
-			users.PATCH("/:id/password", middleware.TokenAuthMiddleware(), a.handler.ChangeUserPassword)
+			users.PUT("/:id", auth, session, a.handler.EditUser)
+			users.GET("", auth, can(model.PermUsersManage), a.handler.ListUsers)
+			users.GET("/:id", auth, session, a.handler.GetUserByID)
+			users.DELETE("/:id", auth, can(model.PermUsersManage), a.handler.DeleteUser)
+			users.PATCH("/:id/role", auth, can(model.PermUsersManage), a.handler.ChangeUserRole)
+			users.PATCH("/:id/password", auth, session, a.handler.ChangeUserPassword)
+			users.POST("/:id/logout", auth, can(model.PermUsersManage), a.handler.LogoutUser)
+			users.POST("/:id/unlock", auth, can(model.PermUsersManage), a.handler.UnlockUser)
+			users.DELETE("/:id/2fa", auth, can(model.PermUsersManage), a.handler.ResetUserTwoFactor)
+			users.PATCH("/:id/customer-group", auth, can(model.PermUsersManage), a.handler.ChangeUserCustomerGroup)
+		}
+		priceLists := api.Group("/price-lists")
+		{
+			priceLists.POST("", auth, can(model.PermPricingManage), a.handler.CreatePriceList)
+			priceLists.PUT("/:id", auth, can(model.PermPricingManage), a.handler.EditPriceList)
+			priceLists.GET("", auth, can(model.PermPricingManage), a.handler.ListPriceLists)
+			priceLists.GET("/:id", auth, can(model.PermPricingManage), a.handler.GetPriceListByID)
+			priceLists.DELETE("/:id", auth, can(model.PermPricingManage), a.handler.DeletePriceList)
+			priceLists.POST("/:id/assignments", auth, can(model.PermPricingManage), a.handler.AssignPriceList)
+			priceLists.DELETE(
+				"/:id/assignments/:assignmentId",
+				auth,
+				can(model.PermPricingManage),
+				a.handler.UnassignPriceList,
+			)
+		}
+		customerGroups := api.Group("/customer-groups")
+		{
+			customerGroups.POST("", auth, can(model.PermPricingManage), a.handler.CreateCustomerGroup)
+			customerGroups.GET("", auth, can(model.PermPricingManage), a.handler.ListCustomerGroups)
+			customerGroups.DELETE("/:id", auth, can(model.PermPricingManage), a.handler.DeleteCustomerGroup)
+		}
+		promoCodes := api.Group("/promo-codes")
+		{
+			promoCodes.POST("", auth, can(model.PermPricingManage), a.handler.CreatePromoCode)
+			promoCodes.PUT("/:id", auth, can(model.PermPricingManage), a.handler.EditPromoCode)
+			promoCodes.GET("", auth, can(model.PermPricingManage), a.handler.ListPromoCodes)
+			promoCodes.GET("/:id", auth, can(model.PermPricingManage), a.handler.GetPromoCodeByID)
+			promoCodes.DELETE("/:id", auth, can(model.PermPricingManage), a.handler.DeletePromoCode)
+		}
+		shippingMethods := api.Group("/shipping-methods")
+		{
+			shippingMethods.POST("", auth, can(model.PermShippingManage), a.handler.CreateShippingMethod)
+			shippingMethods.PUT("/:id", auth, can(model.PermShippingManage), a.handler.EditShippingMethod)
+			shippingMethods.GET("", auth, a.handler.ListShippingMethods)
+			shippingMethods.GET("/:id", auth, a.handler.GetShippingMethodByID)
+			shippingMethods.DELETE("/:id", auth, can(model.PermShippingManage), a.handler.DeleteShippingMethod)
+		}
+		recurringOrders := api.Group("/recurring-orders")
+		{
+			recurringOrders.POST("", auth, can(model.PermOrdersWrite), a.handler.CreateRecurringOrder)
+			recurringOrders.GET("", auth, session, a.handler.ListRecurringOrders)
+			recurringOrders.GET("/:id", auth, session, a.handler.GetRecurringOrderByID)
+			recurringOrders.DELETE("/:id", auth, session, a.handler.DeleteRecurringOrder)
+			recurringOrders.POST("/:id/pause", auth, session, a.handler.PauseRecurringOrder)
+			recurringOrders.POST("/:id/resume", auth, session, a.handler.ResumeRecurringOrder)
+			recurringOrders.POST("/:id/skip", auth, session, a.handler.SkipRecurringOrder)
+		}
+		roles := api.Group("/roles")
+		{
+			roles.POST("", auth, can(model.PermRolesManage), a.handler.CreateRole)
+			roles.PUT("/:key", auth, can(model.PermRolesManage), a.handler.EditRole)
+			roles.GET("", auth, can(model.PermRolesManage), a.handler.ListRoles)
+			roles.GET("/:key", auth, can(model.PermRolesManage), a.handler.GetRoleByKey)
+			roles.DELETE("/:key", auth, can(model.PermRolesManage), a.handler.DeleteRole)
+		}
+		api.GET("/permissions", auth, can(model.PermRolesManage), a.handler.ListPermissions)
+		apiKeys := api.Group("/api-keys")
+		{
+			apiKeys.POST("", auth, can(model.PermAPIKeysManage), a.handler.CreateAPIKey)
+			apiKeys.GET("", auth, can(model.PermAPIKeysManage), a.handler.ListAPIKeys)
+			apiKeys.GET("/:id", auth, can(model.PermAPIKeysManage), a.handler.GetAPIKeyByID)
+			apiKeys.DELETE("/:id", auth, can(model.PermAPIKeysManage), a.handler.DeleteAPIKey)
+		}
+		notifications := api.Group("/notifications")
+		{
+			notifications.GET("", auth, session, a.handler.ListNotifications)
+			notifications.PATCH("/:id/read", auth, session, a.handler.MarkNotificationRead)
 		}
 	}
 
@@ -8,15 +8,20 @@ import (
 	"log"
 	"math"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/mikhailshtv/stockLkBack/internal/handler"
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
 
 	"github.com/mikhailshtv/proto_api/pkg/grpc/v1/orders_api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -30,29 +35,24 @@ type server struct {
 }
 
 func (s *server) GetOrders(
-	_ context.Context,
-	req *orders_api.OrderGetAllRequest,
+	ctx context.Context,
+	_ *orders_api.OrderGetAllRequest,
 ) (*orders_api.GetOrdersResponse, error) {
-	ordersAll, err := s.handler.Services.Order.GetAll(int(req.UserId), model.UserRole(req.Role))
+	caller := callerFromContext(ctx)
+	ordersAll, err := s.handler.Services.Order.GetAll(caller.userID, caller.role)
 	if err != nil {
 		log.Println(err.Error())
-		err = status.Errorf(codes.Internal, "Ошибка получения списка заказов")
-		if err != nil {
-			log.Println(err.Error())
-		}
+		return nil, status.Errorf(codes.Internal, "Ошибка получения списка заказов")
 	}
-	ordersJSON, err := json.Marshal(ordersAll)
-	if err != nil {
-		log.Println(err.Error())
-		err = status.Errorf(codes.Internal, "Ошибка при конвертации в JSON")
+	orders := make([]*orders_api.Order, 0, len(ordersAll))
+	for i := range ordersAll {
+		order, err := convertOrderToProto(&ordersAll[i])
 		if err != nil {
 			log.Println(err.Error())
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"Заказ %d не может быть передан через gRPC: %v", ordersAll[i].ID, err)
 		}
-	}
-	var orders []*orders_api.Order
-	err = json.Unmarshal(ordersJSON, &orders)
-	if err != nil {
-		log.Println(err.Error())
+		orders = append(orders, order)
 	}
 	return &orders_api.GetOrdersResponse{
 		Orders: orders,
@@ -60,7 +60,7 @@ func (s *server) GetOrders(
 }
 
 func (s *server) GetOrder(
-	_ context.Context,
+	ctx context.Context,
 	req *orders_api.OrderGetByIdRequest,
 ) (*orders_api.GetOrderResponse, error) {
 	orderID := req.GetId()
@@ -72,7 +72,8 @@ func (s *server) GetOrder(
 		return nil, err
 	}
 
-	receivedOrder, err := s.handler.Services.Order.GetByID(int(orderID), int(req.UserId), model.UserRole(req.Role))
+	caller := callerFromContext(ctx)
+	receivedOrder, err := s.handler.Services.Order.GetByID(int(orderID), caller.userID, caller.role)
 	if err != nil {
 		if err.Error() == repository.NotFoundErrorMessage {
 			err = status.Errorf(codes.NotFound, "Объект не найден")
@@ -87,18 +88,10 @@ func (s *server) GetOrder(
 		}
 		return nil, err
 	}
-	orderJSON, err := json.Marshal(receivedOrder)
-	if err != nil {
-		log.Println(err.Error())
-		err = status.Errorf(codes.Internal, "Ошибка при конвертации в JSON")
-		if err != nil {
-			log.Println(err.Error())
-		}
-	}
-	var order *orders_api.Order
-	err = json.Unmarshal(orderJSON, &order)
+	order, err := convertOrderToProto(receivedOrder)
 	if err != nil {
 		log.Println(err.Error())
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
 	}
 	return &orders_api.GetOrderResponse{
 		Order: order,
@@ -106,43 +99,46 @@ func (s *server) GetOrder(
 }
 
 func (s *server) CreateOrder(
-	_ context.Context,
+	ctx context.Context,
 	req *orders_api.OrderCreateRequest,
 ) (*orders_api.Order, error) {
 	products := req.Products
-	userID := req.UserId
+	caller := callerFromContext(ctx)
 	productsJSON, err := json.Marshal(products)
 	if err != nil {
 		log.Println(err.Error())
-		err = status.Errorf(codes.Internal, "Ошибка при конвертации в JSON")
-		if err != nil {
-			log.Println(err.Error())
-		}
+		return nil, status.Errorf(codes.Internal, "Ошибка при конвертации в JSON")
 	}
 	var orderReq model.OrderRequestBody
 
 	err = json.Unmarshal(productsJSON, &orderReq.Products)
 	if err != nil {
 		log.Println(err.Error())
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный список товаров")
 	}
-	order, err := s.handler.Services.Order.Create(orderReq, int(userID))
+	order, err := s.handler.Services.Order.Create(orderReq, caller.userID, caller.role)
 	if err != nil {
 		log.Println(err.Error())
-		err = status.Errorf(codes.Internal, "Ошибка при создании заказа")
-		if err != nil {
-			log.Println(err.Error())
+		if appErr, ok := apperrors.IsAppError(err); ok {
+			switch appErr.Type {
+			case apperrors.ErrorTypeForbidden:
+				return nil, status.Errorf(codes.PermissionDenied, "%s", appErr.Message)
+			case apperrors.ErrorTypeValidation:
+				return nil, status.Errorf(codes.InvalidArgument, "%s", appErr.Message)
+			}
 		}
+		return nil, status.Errorf(codes.Internal, "Ошибка при создании заказа")
 	}
 
 	return convertOrderToProto(order)
 }
 
 func (s *server) EditOrder(
-	_ context.Context,
+	ctx context.Context,
 	req *orders_api.OrderEditRequest,
 ) (*orders_api.Order, error) {
 	orderID := req.GetId()
-	userID := req.UserId
+	caller := callerFromContext(ctx)
 	if orderID <= 0 {
 		err := status.Errorf(codes.InvalidArgument, "id должен быть больше чем 0")
 		if err != nil {
@@ -168,7 +164,9 @@ func (s *server) EditOrder(
 		}
 	}
 
-	order, err := s.handler.Services.Order.Update(int(orderID), orderReq, int(userID))
+	// Владелец заказа через gRPC не меняется.
+	orderReq.UserID = nil
+	order, err := s.handler.Services.Order.Update(int(orderID), orderReq, caller.userID, caller.role)
 	if err != nil {
 		log.Println(err.Error())
 		if err.Error() == repository.NotFoundErrorMessage {
@@ -189,11 +187,11 @@ func (s *server) EditOrder(
 }
 
 func (s *server) DeleteOrder(
-	_ context.Context,
+	ctx context.Context,
 	req *orders_api.OrderDeleteRequest,
 ) (*orders_api.Success, error) {
 	orderID := req.GetId()
-	userID := req.UserId
+	caller := callerFromContext(ctx)
 	if orderID <= 0 {
 		err := status.Errorf(codes.InvalidArgument, "id должен быть больше чем 0")
 		if err != nil {
@@ -202,7 +200,7 @@ func (s *server) DeleteOrder(
 		return nil, err
 	}
 
-	err := s.handler.Services.Order.Delete(int(orderID), int(userID))
+	err := s.handler.Services.Order.Delete(int(orderID), caller.userID, caller.role)
 	if err != nil {
 		if err.Error() == repository.NotFoundErrorMessage {
 			err = status.Errorf(codes.NotFound, "Объект не найден")
@@ -231,8 +229,10 @@ func StartServer(handler *handler.Handler) {
 	}
 
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(
+		grpc.ChainUnaryInterceptor(
 			loggingInterceptor,
+			authInterceptor(handler.Services.User, handler.Services.APIKey),
+			permissionInterceptor(handler.Services.Role),
 		),
 	)
 
@@ -286,6 +286,137 @@ func loggingInterceptor(
 	return resp, err
 }
 
+// authInterceptor проверяет токен из метаданных authorization так же, как TokenAuthMiddleware,
+// а интеграции вместо токена передают ключ API в метаданных x-api-key.
+// Вызовы без токена и ключа, а также с отозванным или недействительным токеном или ключом отклоняются.
+// Публичные методы из publicMethods пропускаются без проверки.
+func authInterceptor(
+	revocations middleware.TokenRevocationChecker,
+	apiKeys middleware.APIKeyAuthenticator,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if _, ok := publicMethods[methodName(info.FullMethod)]; ok {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if keys := md.Get(apiKeyMetadata); len(keys) > 0 {
+			apiKey, err := apiKeys.Authenticate(keys[0])
+			if err != nil {
+				if appErr, ok := apperrors.IsAppError(err); ok && appErr.Type == apperrors.ErrorTypeUnauthorized {
+					return nil, status.Errorf(codes.Unauthenticated, "%s", appErr.Message)
+				}
+				log.Println(err.Error())
+				return nil, status.Errorf(codes.Unavailable, "Ошибка проверки ключа API")
+			}
+			return handler(context.WithValue(ctx, apiKeyContextKey{}, apiKey), req)
+		}
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Errorf(codes.Unauthenticated, "Требуется токен или ключ API")
+		}
+		claims, err := jwtgen.ParseToken(strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", err.Error())
+		}
+		revoked, err := revocations.IsTokenRevoked(claims)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, status.Errorf(codes.Unavailable, "Ошибка проверки токена")
+		}
+		if revoked {
+			return nil, status.Errorf(codes.Unauthenticated, "%s", jwtgen.ErrRevokedToken.Error())
+		}
+		return handler(context.WithValue(ctx, claimsContextKey{}, claims), req)
+	}
+}
+
+// apiKeyMetadata ключ метаданных с ключом API. Имена метаданных gRPC передаются в нижнем регистре.
+const apiKeyMetadata = "x-api-key"
+
+type apiKeyContextKey struct{}
+
+type claimsContextKey struct{}
+
+// publicMethods методы, доступные без токена и разрешений. Сейчас таких нет: новый метод
+// нужно добавить либо сюда, либо в methodPermissions, иначе вызов будет отклонен.
+var publicMethods = map[string]struct{}{}
+
+// methodPermissions разрешения, необходимые для вызова методов сервиса заказов.
+var methodPermissions = map[string]model.Permission{
+	"GetOrders":   model.PermOrdersRead,
+	"GetOrder":    model.PermOrdersRead,
+	"CreateOrder": model.PermOrdersWrite,
+	"EditOrder":   model.PermOrdersWrite,
+	"DeleteOrder": model.PermOrdersWrite,
+}
+
+// caller пользователь, от имени которого выполняется вызов. Без разрешения orders:read_all
+// в role записывается клиентская роль: вызов видит только заказы самого пользователя.
+type caller struct {
+	userID int
+	role   model.UserRole
+}
+
+type callerContextKey struct{}
+
+// callerFromContext возвращает пользователя, сохраненного permissionInterceptor.
+func callerFromContext(ctx context.Context) caller {
+	c, _ := ctx.Value(callerContextKey{}).(caller)
+	return c
+}
+
+// permissionInterceptor определяет пользователя по токену или ключу API и проверяет
+// разрешения его роли так же, как RequirePermission. Ключу API доступны только выданные
+// ему разрешения. Пользователь и роль из тела запроса не учитываются. Методы, которых нет
+// ни в publicMethods, ни в methodPermissions, отклоняются.
+func permissionInterceptor(roles middleware.RolePermissions) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		method := methodName(info.FullMethod)
+		if _, ok := publicMethods[method]; ok {
+			return handler(ctx, req)
+		}
+		var c caller
+		var permissions model.PermissionSet
+		if apiKey, ok := ctx.Value(apiKeyContextKey{}).(*model.APIKey); ok {
+			c.userID, c.role, permissions = apiKey.UserID, apiKey.Role, apiKey.Permissions
+		} else if claims, ok := ctx.Value(claimsContextKey{}).(*model.Claims); ok {
+			c.userID, c.role = claims.UserID, claims.Role
+			var err error
+			permissions, err = roles.Permissions(c.role)
+			if err != nil {
+				log.Println(err.Error())
+				return nil, status.Errorf(codes.Unavailable, "Ошибка проверки прав доступа")
+			}
+		} else {
+			return nil, status.Errorf(codes.Unauthenticated, "Требуется токен или ключ API")
+		}
+		if !permissions.Has(model.PermOrdersReadAll) {
+			c.role = model.RoleClient
+		}
+
+		permission, ok := methodPermissions[method]
+		if !ok || !permissions.Has(permission) {
+			return nil, status.Errorf(codes.PermissionDenied, "Недостаточно прав для выполнения операции")
+		}
+		return handler(context.WithValue(ctx, callerContextKey{}, c), req)
+	}
+}
+
+// methodName возвращает имя метода без пакета и сервиса: /<пакет>.OrderService/GetOrder -> GetOrder.
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
 func convertOrderToProto(order *model.Order) (*orders_api.Order, error) {
 	id, err := safeIntToInt32(order.ID, "ID заказа")
 	if err != nil {
@@ -297,7 +428,9 @@ func convertOrderToProto(order *model.Order) (*orders_api.Order, error) {
 		return nil, fmt.Errorf("ошибка валидации номера: %w", err)
 	}
 
-	totalCost, err := safeIntToInt32(order.TotalCost, "общая стоимость")
+	// Proto-контракт не передает валюту и разбивку НДС, пока в него не добавлены эти поля:
+	// итог с учетом НДС отдается в минимальных единицах валюты заказа.
+	totalCost, err := order.Total().Int32()
 	if err != nil {
 		return nil, fmt.Errorf("ошибка валидации стоимости: %w", err)
 	}
@@ -333,15 +466,32 @@ func safeIntToInt32(value int, fieldName string) (int32, error) {
 	return int32(value), nil
 }
 
+// convertProductsToProto конвертирует товары заказа в proto.
+// Proto-контракт передает количество целым числом, поэтому дробные количества
+// (весовые и метражные товары) через gRPC не передаются и возвращают ошибку.
 func convertProductsToProto(products []model.Product) ([]*orders_api.Product, error) {
-	protoProductsJSON, err := json.Marshal(products)
-	if err != nil {
-		return []*orders_api.Product{}, err
-	}
-	var protoProducts []*orders_api.Product
-	err = json.Unmarshal(protoProductsJSON, &protoProducts)
-	if err != nil {
-		return []*orders_api.Product{}, err
+	protoProducts := make([]*orders_api.Product, 0, len(products))
+	for _, product := range products {
+		quantity, err := product.Quantity.Int32()
+		if err != nil {
+			return []*orders_api.Product{}, fmt.Errorf("товар %d: %w", product.ID, err)
+		}
+		purchasePrice, err := model.NewMoney(product.PurchasePrice, product.Currency).Int32()
+		if err != nil {
+			return []*orders_api.Product{}, fmt.Errorf("товар %d: %w", product.ID, err)
+		}
+		sellPrice, err := product.SellMoney().Int32()
+		if err != nil {
+			return []*orders_api.Product{}, fmt.Errorf("товар %d: %w", product.ID, err)
+		}
+		protoProducts = append(protoProducts, &orders_api.Product{
+			Id:            product.ID,
+			Code:          product.Code,
+			Quantity:      quantity,
+			Name:          product.Name,
+			PurchasePrice: purchasePrice,
+			SalePrice:     sellPrice,
+		})
 	}
 	return protoProducts, nil
 }
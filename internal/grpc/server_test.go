@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	mocks "github.com/mikhailshtv/stockLkBack/internal/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPermissionInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	rolesMock := mocks.NewMockRole(ctrl)
+
+	employee := model.NewPermissionSet([]model.Permission{
+		model.PermOrdersRead,
+		model.PermOrdersReadAll,
+		model.PermOrdersWrite,
+		model.PermOrdersExecute,
+	})
+	client := model.NewPermissionSet([]model.Permission{model.PermOrdersRead, model.PermOrdersWrite})
+
+	withClaims := func(role model.UserRole) context.Context {
+		return context.WithValue(context.Background(), claimsContextKey{}, &model.Claims{UserID: 7, Role: role})
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		mock       func()
+		wantCode   codes.Code
+		wantCaller caller
+	}{
+		{
+			name:   "employee reads any order",
+			ctx:    withClaims(model.RoleEmployee),
+			method: "/orders.OrderService/GetOrder",
+			mock: func() {
+				rolesMock.EXPECT().Permissions(model.RoleEmployee).Return(employee, nil)
+			},
+			wantCode:   codes.OK,
+			wantCaller: caller{userID: 7, role: model.RoleEmployee},
+		},
+		{
+			name:   "client without orders:read_all sees only own orders",
+			ctx:    withClaims(model.RoleClient),
+			method: "/orders.OrderService/GetOrders",
+			mock: func() {
+				rolesMock.EXPECT().Permissions(model.RoleClient).Return(client, nil)
+			},
+			wantCode:   codes.OK,
+			wantCaller: caller{userID: 7, role: model.RoleClient},
+		},
+		{
+			name: "api key limited to its scopes",
+			ctx: context.WithValue(context.Background(), apiKeyContextKey{}, &model.APIKey{
+				UserID:      3,
+				Role:        model.RoleWarehouse,
+				Permissions: model.NewPermissionSet([]model.Permission{model.PermOrdersRead}),
+			}),
+			method:   "/orders.OrderService/DeleteOrder",
+			mock:     func() {},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "method missing from the permission table is rejected",
+			ctx:      withClaims(model.RoleAdmin),
+			method:   "/orders.OrderService/ReorderOrder",
+			mock:     func() { rolesMock.EXPECT().Permissions(model.RoleAdmin).Return(employee, nil) },
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "no token or api key",
+			ctx:      context.Background(),
+			method:   "/orders.OrderService/GetOrder",
+			mock:     func() {},
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			var got caller
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = callerFromContext(ctx)
+				return nil, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			_, err := permissionInterceptor(rolesMock)(tt.ctx, nil, info, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Код ответа = %v, want %v", code, tt.wantCode)
+			}
+			if got != tt.wantCaller {
+				t.Errorf("Пользователь вызова = %+v, want %+v", got, tt.wantCaller)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateAddress
+// @Summary Добавление адреса в адресную книгу
+// @Description Первый адрес пользователя становится адресом по умолчанию.
+// @Tags Addresses
+// @Accept			json
+// @Produce		json
+// @Param address body model.AddressRequestBody true "Объект адреса"
+// @Success 201 {object} model.Address "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/addresses [post]
+// @Security BearerAuth.
+func (h *Handler) CreateAddress(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var addressReq model.AddressRequestBody
+	if err := ctx.ShouldBindJSON(&addressReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	address, err := h.Services.Address.Create(userID, addressReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create address",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, address)
+}
+
+// EditAddress
+// @Summary Редактирование адреса
+// @Description Заказы, уже оформленные на этот адрес, сохраняют прежнее значение.
+// @Tags Addresses
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id адреса"
+// @Param address body model.AddressRequestBody true "Объект адреса"
+// @Success 200 {object} model.Address
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/addresses/{id} [put]
+// @Security BearerAuth.
+func (h *Handler) EditAddress(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID адреса", err))
+		return
+	}
+	var addressReq model.AddressRequestBody
+	if err := ctx.ShouldBindJSON(&addressReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	address, err := h.Services.Address.Update(id, userID, addressReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to update address",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "адрес не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("адрес", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, address)
+}
+
+// ListAddresses
+// @Summary Адресная книга текущего пользователя
+// @Tags Addresses
+// @Produce		json
+// @Success 200 {object} []model.Address
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/addresses [get]
+// @Security BearerAuth.
+func (h *Handler) ListAddresses(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	addresses, err := h.Services.Address.GetAll(userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get addresses",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, addresses)
+}
+
+// GetAddressByID
+// @Summary Получение адреса по id
+// @Tags Addresses
+// @Produce		json
+// @Param id path string true "id адреса"
+// @Success 200 {object} model.Address
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/addresses/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetAddressByID(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID адреса", err))
+		return
+	}
+	address, err := h.Services.Address.GetByID(id, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get address",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, address)
+}
+
+// DeleteAddress
+// @Summary Удаление адреса
+// @Description Если удален адрес по умолчанию, им становится самый ранний из оставшихся.
+// @Tags Addresses
+// @Produce		json
+// @Param id path string true "id адреса"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/addresses/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteAddress(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID адреса", err))
+		return
+	}
+	if err := h.Services.Address.Delete(id, userID); err != nil {
+		logger.GetLogger().Error("failed to delete address",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "адрес не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("адрес", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateAPIKey
+// @Summary Выпуск ключа API
+// @Description Ключ действует от имени пользователя userId в пределах разрешений scopes и передается
+// @Description в заголовке X-API-Key (в gRPC — в метаданных x-api-key). Ключ возвращается только в этом ответе.
+// @Tags APIKeys
+// @Accept			json
+// @Produce		json
+// @Param key body model.APIKeyRequestBody true "Параметры ключа"
+// @Success 201 {object} model.APIKeyCreated "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/api-keys [post]
+// @Security BearerAuth.
+func (h *Handler) CreateAPIKey(ctx *gin.Context) {
+	var keyReq model.APIKeyRequestBody
+	if err := ctx.ShouldBindJSON(&keyReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	for _, scope := range keyReq.Scopes {
+		if !middleware.HasPermission(ctx, scope) {
+			middleware.HandleError(ctx, errors.NewForbiddenError("Нельзя выдать ключу права шире собственных", nil))
+			return
+		}
+	}
+	key, err := h.Services.APIKey.Create(ctx.GetInt(userIDKey), keyReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create api key",
+			zap.Error(err),
+			zap.Int("user_id", keyReq.UserID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeys
+// @Summary Список ключей API
+// @Tags APIKeys
+// @Produce		json
+// @Success 200 {object} []model.APIKey
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/api-keys [get]
+// @Security BearerAuth.
+func (h *Handler) ListAPIKeys(ctx *gin.Context) {
+	keys, err := h.Services.APIKey.GetAll()
+	if err != nil {
+		logger.GetLogger().Error("failed to get api keys",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// GetAPIKeyByID
+// @Summary Получение ключа API
+// @Tags APIKeys
+// @Produce		json
+// @Param id path string true "id ключа"
+// @Success 200 {object} model.APIKey
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/api-keys/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetAPIKeyByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID ключа", err))
+		return
+	}
+	key, err := h.Services.APIKey.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get api key",
+			zap.Error(err),
+			zap.Int("api_key_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, key)
+}
+
+// DeleteAPIKey
+// @Summary Отзыв ключа API
+// @Tags APIKeys
+// @Produce		json
+// @Param id path string true "id ключа"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/api-keys/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteAPIKey(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID ключа", err))
+		return
+	}
+	if err := h.Services.APIKey.Delete(id); err != nil {
+		logger.GetLogger().Error("failed to delete api key",
+			zap.Error(err),
+			zap.Int("api_key_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetCart
+// @Summary Корзина текущего пользователя
+// @Description Цены и остатки актуальны на момент запроса, issues перечисляет строки, которые нельзя оформить.
+// @Tags Cart
+// @Produce		json
+// @Success 200 {object} model.Cart
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart [get]
+// @Security BearerAuth.
+func (h *Handler) GetCart(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	cart, err := h.Services.Cart.Get(userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cart)
+}
+
+// AddCartItem
+// @Summary Добавление товара в корзину
+// @Description Если товар уже есть в корзине, количество суммируется.
+// @Tags Cart
+// @Accept			json
+// @Produce		json
+// @Param item body model.CartItemRequest true "Товар и количество"
+// @Success 200 {object} model.Cart
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart/items [post]
+// @Security BearerAuth.
+func (h *Handler) AddCartItem(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var item model.CartItemRequest
+	if err := ctx.ShouldBindJSON(&item); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	cart, err := h.Services.Cart.AddItem(userID, item)
+	if err != nil {
+		logger.GetLogger().Error("failed to add cart item",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", item.ProductID),
+		)
+		handleCartError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cart)
+}
+
+// EditCartItem
+// @Summary Изменение количества товара в корзине
+// @Tags Cart
+// @Accept			json
+// @Produce		json
+// @Param productId path string true "id товара"
+// @Param item body model.CartItemRequest true "Новое количество"
+// @Success 200 {object} model.Cart
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart/items/{productId} [put]
+// @Security BearerAuth.
+func (h *Handler) EditCartItem(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	productID, err := strconv.Atoi(ctx.Params.ByName("productId"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID товара", err))
+		return
+	}
+	var item model.CartItemRequest
+	if err := ctx.ShouldBindJSON(&item); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	item.ProductID = productID
+	cart, err := h.Services.Cart.UpdateItem(userID, item)
+	if err != nil {
+		logger.GetLogger().Error("failed to update cart item",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", productID),
+		)
+		handleCartError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cart)
+}
+
+// DeleteCartItem
+// @Summary Удаление товара из корзины
+// @Tags Cart
+// @Produce		json
+// @Param productId path string true "id товара"
+// @Success 200 {object} model.Cart
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart/items/{productId} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteCartItem(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	productID, err := strconv.Atoi(ctx.Params.ByName("productId"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID товара", err))
+		return
+	}
+	cart, err := h.Services.Cart.RemoveItem(userID, productID)
+	if err != nil {
+		logger.GetLogger().Error("failed to remove cart item",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", productID),
+		)
+		handleCartError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cart)
+}
+
+// ClearCart
+// @Summary Очистка корзины
+// @Tags Cart
+// @Produce		json
+// @Success 200 {object} model.Success "Корзина очищена"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart [delete]
+// @Security BearerAuth.
+func (h *Handler) ClearCart(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	if err := h.Services.Cart.Clear(userID); err != nil {
+		logger.GetLogger().Error("failed to clear cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Корзина очищена",
+	})
+}
+
+// CheckoutCart
+// @Summary Оформление заказа из корзины
+// @Description Заказ создается по текущим ценам, после оформления корзина очищается.
+// @Description Если часть товаров стала недоступна, заказ не создается, а ошибка перечисляет проблемные строки.
+// @Tags Cart
+// @Accept			json
+// @Produce		json
+// @Param checkout body model.CartCheckoutBody false "Промокод, режим НДС, адрес и способ доставки"
+// @Success 201 {object} model.Order "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/cart/checkout [post]
+// @Security BearerAuth.
+func (h *Handler) CheckoutCart(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var checkout model.CartCheckoutBody
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&checkout); err != nil {
+			middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+			return
+		}
+	}
+	order, err := h.Services.Cart.Checkout(userID, checkout)
+	if err != nil {
+		logger.GetLogger().Error("failed to checkout cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		handleCartError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, order)
+}
+
+// handleCartError сопоставляет ошибки репозиториев корзины и заказов с ответами API.
+// Ошибки, уже сформированные сервисом, передаются как есть.
+func handleCartError(ctx *gin.Context, err error) {
+	if _, ok := errors.IsAppError(err); ok {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "доставк"):
+		middleware.HandleError(ctx, errors.NewValidationError(msg, err))
+	case strings.Contains(msg, "отсутствует в корзине"),
+		strings.Contains(msg, "не найден"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("товар", err))
+	case strings.Contains(msg, "не применима"),
+		strings.Contains(msg, "должно быть целым"),
+		strings.Contains(msg, "знаков после запятой"),
+		strings.Contains(msg, "недостаточно"),
+		strings.Contains(msg, "недействителен или исчерпан"),
+		strings.Contains(msg, "валют"):
+		middleware.HandleError(ctx, errors.NewValidationError(msg, err))
+	default:
+		middleware.HandleError(ctx, err)
+	}
+}
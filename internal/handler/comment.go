@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateOrderComment
+// @Summary Добавление комментария к заказу
+// @Description Внутренние заметки (internal) может оставлять только сотрудник, клиент их не видит.
+// @Description Ответ на внутреннюю заметку тоже становится внутренним.
+// @Tags Orders
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id заказа"
+// @Param comment body model.OrderCommentRequestBody true "Объект комментария"
+// @Success 201 {object} model.OrderComment "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/orders/{id}/comments [post]
+// @Security BearerAuth.
+func (h *Handler) CreateOrderComment(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	orderID, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	var commentReq model.OrderCommentRequestBody
+	if err := ctx.ShouldBindJSON(&commentReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	comment, err := h.Services.Comment.Create(orderID, commentReq, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to create order comment",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		handleCommentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// ListOrderComments
+// @Summary Комментарии заказа
+// @Description Комментарии возвращаются деревом ответов. Клиент видит только публичные комментарии своих заказов.
+// @Tags Orders
+// @Produce		json
+// @Param id path string true "id заказа"
+// @Success 200 {object} []model.OrderComment
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/orders/{id}/comments [get]
+// @Security BearerAuth.
+func (h *Handler) ListOrderComments(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	orderID, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	comments, err := h.Services.Comment.GetAll(orderID, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order comments",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, comments)
+}
+
+// DeleteOrderComment
+// @Summary Удаление комментария заказа
+// @Description Комментарий удаляется вместе с ответами. Клиент может удалить только свой комментарий.
+// @Tags Orders
+// @Produce		json
+// @Param id path string true "id заказа"
+// @Param commentId path string true "id комментария"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/orders/{id}/comments/{commentId} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteOrderComment(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	orderID, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	commentID, err := strconv.Atoi(ctx.Params.ByName("commentId"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID комментария", err))
+		return
+	}
+	if err := h.Services.Comment.Delete(commentID, orderID, userID, role); err != nil {
+		logger.GetLogger().Error("failed to delete order comment",
+			zap.Error(err),
+			zap.Int("comment_id", commentID),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		handleCommentError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
+
+// handleCommentError сопоставляет ошибки репозитория комментариев с ответами API.
+func handleCommentError(ctx *gin.Context, err error) {
+	if _, ok := errors.IsAppError(err); ok {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "заказ не найден"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
+	case strings.Contains(msg, "комментарий"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("комментарий", err))
+	default:
+		middleware.HandleError(ctx, err)
+	}
+}
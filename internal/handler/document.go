@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetOrderInvoice
+// @Summary Счет на оплату заказа в PDF
+// @Tags Orders
+// @Produce		application/pdf
+// @Success 200 {file} file
+// @Failure 400 {string} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Param id path string true "id заказа"
+// @Router /api/v1/orders/{id}/invoice.pdf [get]
+// @Security BearerAuth.
+func (h *Handler) GetOrderInvoice(ctx *gin.Context) {
+	h.orderDocument(ctx, "invoice", h.Services.Document.Invoice)
+}
+
+// GetOrderDeliveryNote
+// @Summary Товарная накладная (ТОРГ-12) по выполненному заказу в PDF
+// @Tags Orders
+// @Produce		application/pdf
+// @Success 200 {file} file
+// @Failure 400 {string} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Param id path string true "id заказа"
+// @Router /api/v1/orders/{id}/delivery-note.pdf [get]
+// @Security BearerAuth.
+func (h *Handler) GetOrderDeliveryNote(ctx *gin.Context) {
+	h.orderDocument(ctx, "delivery-note", h.Services.Document.DeliveryNote)
+}
+
+func (h *Handler) orderDocument(
+	ctx *gin.Context,
+	name string,
+	render func(orderID, userID int, role model.UserRole) ([]byte, error),
+) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	pdf, err := render(id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to render order document",
+			zap.Error(err),
+			zap.String("document", name),
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "заказ не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d.pdf"`, name, id))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
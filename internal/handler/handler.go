@@ -1,10 +1,17 @@
 package handler
 
-import "github.com/mikhailshtv/stockLkBack/internal/service"
+import (
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
 
 const (
 	userRoleKey = "role"
 	userIDKey   = "userId"
+	claimsKey   = "claims"
 )
 
 type Handler struct {
@@ -14,3 +21,20 @@ type Handler struct {
 func NewHandler(services *service.Service) *Handler {
 	return &Handler{Services: services}
 }
+
+// scopedRole возвращает роль, с которой запрос обращается к данным. Без разрешения permission
+// пользователь работает только со своими записями, как клиент, какой бы ни была его роль.
+func scopedRole(ctx *gin.Context, permission model.Permission) (model.UserRole, bool) {
+	value, exists := ctx.Get(userRoleKey)
+	if !exists {
+		return "", false
+	}
+	role, ok := value.(model.UserRole)
+	if !ok {
+		return "", false
+	}
+	if !middleware.HasPermission(ctx, permission) {
+		return model.RoleClient, true
+	}
+	return role, true
+}
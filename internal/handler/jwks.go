@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS
+// @Summary Открытые ключи для проверки токенов
+// @Description Набор ключей в формате JWK. Токен подписан ключом, id которого указан в заголовке kid.
+// @Description Симметричные ключи HS256 не публикуются.
+// @Tags Login
+// @Produce		json
+// @Success 200 {object} jwtgen.JWKSet
+// @Router /.well-known/jwks.json [get].
+func (h *Handler) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jwtgen.PublicKeys())
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetMe
+// @Summary Профиль текущего пользователя
+// @Tags Me
+// @Produce		json
+// @Success 200 {object} model.User
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me [get]
+// @Security BearerAuth.
+func (h *Handler) GetMe(ctx *gin.Context) {
+	h.getUser(ctx, ctx.GetInt(userIDKey))
+}
+
+// EditMe
+// @Summary Редактирование профиля текущего пользователя
+// @Description Изменяются только переданные поля.
+// @Tags Me
+// @Accept			json
+// @Produce		json
+// @Param user body model.UserEditBody true "Объект пользователя"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me [patch]
+// @Security BearerAuth.
+func (h *Handler) EditMe(ctx *gin.Context) {
+	h.editUser(ctx, ctx.GetInt(userIDKey))
+}
+
+// ChangeMyPassword
+// @Summary Смена пароля текущего пользователя
+// @Description После смены пароля все сессии пользователя завершаются.
+// @Tags Me
+// @Accept			json
+// @Produce		json
+// @Param user body model.UserChangePasswordBody true "Объект с паролями"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/password [patch]
+// @Security BearerAuth.
+func (h *Handler) ChangeMyPassword(ctx *gin.Context) {
+	h.changeUserPassword(ctx, ctx.GetInt(userIDKey))
+}
+
+// ResendEmailVerification
+// @Summary Повторная отправка письма для подтверждения email
+// @Description Письмо можно запросить не чаще раза в минуту и не более нескольких раз в час.
+// @Tags Me
+// @Produce		json
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 429 {object} model.Error "Too many requests"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/email/verification [post]
+// @Security BearerAuth.
+func (h *Handler) ResendEmailVerification(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	if err := h.Services.User.ResendEmailVerification(userID); err != nil {
+		logger.GetLogger().Error("failed to resend email verification",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Письмо для подтверждения email отправлено",
+	})
+}
+
+// ListMyOrders
+// @Summary Заказы текущего пользователя
+// @Description Только заказы, оформленные на текущего пользователя, независимо от его роли.
+// @Tags Me
+// @Produce		json
+// @Success 200 {object} []model.Order
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/orders [get]
+// @Security BearerAuth.
+func (h *Handler) ListMyOrders(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	// С ролью клиента сервис возвращает только заказы самого пользователя.
+	orders, err := h.Services.Order.GetAll(userID, model.RoleClient)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user orders",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, orders)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ListNotifications
+// @Summary Список уведомлений пользователя
+// @Description Сначала новые. С параметром unread=true возвращаются только непрочитанные.
+// @Tags Notifications
+// @Produce		json
+// @Param unread query bool false "Только непрочитанные"
+// @Success 200 {object} []model.Notification
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/notifications [get]
+// @Security BearerAuth.
+func (h *Handler) ListNotifications(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	unreadOnly := false
+	if unread := ctx.Query("unread"); unread != "" {
+		var err error
+		unreadOnly, err = strconv.ParseBool(unread)
+		if err != nil {
+			middleware.HandleError(ctx, errors.NewValidationError("Некорректное значение параметра unread", err))
+			return
+		}
+	}
+	notifications, err := h.Services.Notification.GetAll(userID, unreadOnly)
+	if err != nil {
+		logger.GetLogger().Error("failed to get notifications",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead
+// @Summary Отметка уведомления прочитанным
+// @Tags Notifications
+// @Produce		json
+// @Param id path string true "id уведомления"
+// @Success 200 {object} model.Notification
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/notifications/{id}/read [patch]
+// @Security BearerAuth.
+func (h *Handler) MarkNotificationRead(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID уведомления", err))
+		return
+	}
+	notification, err := h.Services.Notification.MarkRead(id, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to mark notification as read",
+			zap.Error(err),
+			zap.Int("notification_id", id),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notification)
+}
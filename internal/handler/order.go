@@ -16,6 +16,9 @@ import (
 
 // CreateOrder
 // @Summary Создание заказа
+// @Description Скидки на заказ и строки может назначать только сотрудник, клиент может указать промокод.
+// @Description Сотрудник может оформить заказ на клиента, указав его в userId.
+// @Description Без addressId используется адрес клиента по умолчанию, стоимость доставки входит в totalCost.
 // @Tags Orders
 // @Accept			json
 // @Produce		json
@@ -23,22 +26,38 @@ import (
 // @Success 201 {object} model.Order "Created"
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 500 {object} model.Error "Internal"
 // @Router /api/v1/orders [post]
 // @Security BearerAuth.
 func (h *Handler) CreateOrder(ctx *gin.Context) {
 	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
 	var orderReq model.OrderRequestBody
 	if err := ctx.ShouldBindJSON(&orderReq); err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
 		return
 	}
-	order, err := h.Services.Order.Create(orderReq, userID)
+	if orderReq.HasManualDiscount() && !middleware.HasPermission(ctx, model.PermOrdersDiscount) {
+		middleware.HandleError(ctx, errors.NewForbiddenError("Скидки может назначать только сотрудник", nil))
+		return
+	}
+	order, err := h.Services.Order.Create(orderReq, userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to create order",
 			zap.Error(err),
 			zap.Int("user_id", userID),
 		)
+		if strings.Contains(err.Error(), "недействителен или исчерпан") ||
+			strings.Contains(err.Error(), "валют") ||
+			strings.Contains(err.Error(), "доставк") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -55,12 +74,18 @@ func (h *Handler) CreateOrder(ctx *gin.Context) {
 // @Success 200 {object} model.Order
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Router /api/v1/orders{id} [put]
 // @Security BearerAuth.
 func (h *Handler) EditOrder(ctx *gin.Context) {
 	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -72,7 +97,11 @@ func (h *Handler) EditOrder(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
 		return
 	}
-	orderResult, err := h.Services.Order.Update(id, order, userID)
+	if order.HasManualDiscount() && !middleware.HasPermission(ctx, model.PermOrdersDiscount) {
+		middleware.HandleError(ctx, errors.NewForbiddenError("Скидки может назначать только сотрудник", nil))
+		return
+	}
+	orderResult, err := h.Services.Order.Update(id, order, userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to update order",
 			zap.Error(err),
@@ -83,6 +112,10 @@ func (h *Handler) EditOrder(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
 			return
 		}
+		if strings.Contains(err.Error(), "валют") || strings.Contains(err.Error(), "доставк") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -101,12 +134,12 @@ func (h *Handler) EditOrder(ctx *gin.Context) {
 // @Security BearerAuth.
 func (h *Handler) ListOrders(ctx *gin.Context) {
 	userID := ctx.GetInt(userIDKey)
-	role, exists := ctx.Get(userRoleKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
 	if !exists {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
 		return
 	}
-	orders, err := h.Services.Order.GetAll(userID, role.(model.UserRole))
+	orders, err := h.Services.Order.GetAll(userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to get orders",
 			zap.Error(err),
@@ -132,7 +165,7 @@ func (h *Handler) ListOrders(ctx *gin.Context) {
 // @Security BearerAuth.
 func (h *Handler) GetOrderByID(ctx *gin.Context) {
 	userID := ctx.GetInt(userIDKey)
-	role, exists := ctx.Get(userRoleKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
 	if !exists {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
 		return
@@ -143,7 +176,7 @@ func (h *Handler) GetOrderByID(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
 		return
 	}
-	order, err := h.Services.Order.GetByID(id, userID, role.(model.UserRole))
+	order, err := h.Services.Order.GetByID(id, userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to get order by ID",
 			zap.Error(err),
@@ -175,12 +208,17 @@ func (h *Handler) GetOrderByID(ctx *gin.Context) {
 func (h *Handler) DeleteOrder(ctx *gin.Context) {
 	idStr := ctx.Params.ByName("id")
 	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
 		return
 	}
-	err = h.Services.Order.Delete(id, userID)
+	err = h.Services.Order.Delete(id, userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to delete order",
 			zap.Error(err),
@@ -203,6 +241,7 @@ func (h *Handler) DeleteOrder(ctx *gin.Context) {
 
 // ChangeOrderStatus
 // @Summary Изменение статуса заказа
+// @Description Требует разрешения orders:execute.
 // @Tags Orders
 // @Produce		json
 // @Param id path string true "id заказа"
@@ -210,6 +249,7 @@ func (h *Handler) DeleteOrder(ctx *gin.Context) {
 // @Success 200 {object} model.Order "Ok"
 // @Failure 400 {string} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {string} string "Internal"
 // @Router /api/v1/orders/{id} [patch]
@@ -217,6 +257,11 @@ func (h *Handler) DeleteOrder(ctx *gin.Context) {
 func (h *Handler) ChangeOrderStatus(ctx *gin.Context) {
 	idStr := ctx.Params.ByName("id")
 	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
@@ -227,7 +272,7 @@ func (h *Handler) ChangeOrderStatus(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
 		return
 	}
-	orderResult, err := h.Services.Order.UpdateStatus(id, order, userID)
+	orderResult, err := h.Services.Order.UpdateStatus(id, order, userID, role)
 	if err != nil {
 		logger.GetLogger().Error("failed to update order status",
 			zap.Error(err),
@@ -238,8 +283,107 @@ func (h *Handler) ChangeOrderStatus(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
 			return
 		}
+		if strings.Contains(err.Error(), "валют") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, orderResult)
 }
+
+// ShipOrder
+// @Summary Отметка об отправке заказа
+// @Description Сохраняет перевозчика и трек-номер. Доступно только сотруднику.
+// @Tags Orders
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id заказа"
+// @Param shipment body model.ShipmentRequestBody true "Перевозчик и трек-номер"
+// @Success 200 {object} model.Order
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/orders/{id}/shipment [patch]
+// @Security BearerAuth.
+func (h *Handler) ShipOrder(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	var shipment model.ShipmentRequestBody
+	if err := ctx.ShouldBindJSON(&shipment); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	order, err := h.Services.Order.Ship(id, shipment, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to ship order",
+			zap.Error(err),
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "заказ не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
+
+// ReorderOrder
+// @Summary Повтор заказа
+// @Description Создает новый заказ по строкам существующего с текущими ценами и остатками.
+// @Description lines перечисляет перенесенные строки: adjusted — количество уменьшено до остатка, unavailable — товар пропущен.
+// @Description Скидки и промокод не переносятся. Сотрудник оформляет повторный заказ на владельца исходного.
+// @Tags Orders
+// @Produce		json
+// @Param id path string true "id исходного заказа"
+// @Success 201 {object} model.ReorderResult "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/orders/{id}/reorder [post]
+// @Security BearerAuth.
+func (h *Handler) ReorderOrder(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("некорректный ID заказа", err))
+		return
+	}
+	result, err := h.Services.Order.Reorder(id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to reorder",
+			zap.Error(err),
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+		)
+		if _, ok := errors.IsAppError(err); ok {
+			middleware.HandleError(ctx, err)
+			return
+		}
+		if strings.Contains(err.Error(), "недостаточно") ||
+			strings.Contains(err.Error(), "валют") ||
+			strings.Contains(err.Error(), "доставк") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, result)
+}
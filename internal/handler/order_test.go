@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/service"
 	mock_service "github.com/mikhailshtv/stockLkBack/internal/service/mocks"
@@ -42,17 +43,20 @@ func TestHandler_CreateOrder(t *testing.T) {
 				Products: []model.OrderProduct{
 					{
 						ProductID: 1,
-						Quantity:  1,
+						Quantity:  model.NewQuantity(1),
 						SellPrice: 74000,
 					},
 				},
 			},
 			mockBehavior: func(s *mock_service.MockOrder, orderReq model.OrderRequestBody) {
-				s.EXPECT().Create(orderReq, 1).Return(
+				s.EXPECT().Create(orderReq, 1, model.RoleClient).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
+						TaxMode:          model.TaxInclusive,
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						Status:           model.StatusActive,
@@ -60,7 +64,7 @@ func TestHandler_CreateOrder(t *testing.T) {
 							{
 								ID:        1,
 								Code:      137207,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								Name:      "Bacon",
 								SellPrice: 74000,
 							},
@@ -73,7 +77,13 @@ func TestHandler_CreateOrder(t *testing.T) {
 			expectedResponseBody: `{
 				"id":1,
 				"number":1,
+				"subtotal":74000,
+				"discountTotal":0,
 				"totalCost":74000,
+				"currency":"RUB",
+				"taxMode":"inclusive",
+				"taxTotal":0,
+				"shippingCost":0,
 				"createdDate":"2025-05-25T12:17:16.550631Z",
 				"lastModifiedDate":"2025-05-25T12:17:16.550631Z",
 				"status":{"key":"active","displayName":"Активный"},
@@ -104,6 +114,22 @@ func TestHandler_CreateOrder(t *testing.T) {
 			expectedStatusCode:   400,
 			expectedResponseBody: `{"code":400, "message":"Некорректное тело запроса", "type":"VALIDATION_ERROR"}`,
 		},
+		{
+			name: "Скидка от клиента",
+			inputBody: `
+				{
+					"products":[{"productId":1,"quantity":1}],
+					"discountType":"percent",
+					"discountValue":10
+				}`,
+			mockBehavior:       func(_ *mock_service.MockOrder, _ model.OrderRequestBody) {},
+			expectedStatusCode: 403,
+			expectedResponseBody: `{
+				"code":403,
+				"message":"Скидки может назначать только сотрудник",
+				"type":"FORBIDDEN"
+			}`,
+		},
 		{
 			name: "Ошибка 500",
 			inputBody: `
@@ -114,7 +140,7 @@ func TestHandler_CreateOrder(t *testing.T) {
 				Products: []model.OrderProduct{},
 			},
 			mockBehavior: func(s *mock_service.MockOrder, orderReq model.OrderRequestBody) {
-				s.EXPECT().Create(orderReq, 1).Return(nil, errors.NewInternalError("Внутренняя ошибка сервера", nil))
+				s.EXPECT().Create(orderReq, 1, model.RoleClient).Return(nil, errors.NewInternalError("Внутренняя ошибка сервера", nil))
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"code":500, "message":"Внутренняя ошибка сервера", "type":"INTERNAL_ERROR"}`,
@@ -130,6 +156,7 @@ func TestHandler_CreateOrder(t *testing.T) {
 			r := gin.New()
 			r.POST("/orders", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
+				ctx.Set("role", model.RoleClient)
 				handler.CreateOrder(ctx)
 			})
 			w := httptest.NewRecorder()
@@ -158,7 +185,10 @@ func TestHandler_ListOrders(t *testing.T) {
 						{
 							ID:               1,
 							Number:           1,
+							Subtotal:         74000,
 							TotalCost:        74000,
+							Currency:         "RUB",
+							TaxMode:          model.TaxInclusive,
 							CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 							LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 							Status:           model.StatusActive,
@@ -166,7 +196,7 @@ func TestHandler_ListOrders(t *testing.T) {
 								{
 									ID:        1,
 									Code:      14823,
-									Quantity:  1,
+									Quantity:  model.NewQuantity(1),
 									Name:      "Cheese",
 									SellPrice: 74000,
 								},
@@ -181,7 +211,13 @@ func TestHandler_ListOrders(t *testing.T) {
 				{
 					"id":1,
 					"number":1,
+					"subtotal":74000,
+					"discountTotal":0,
 					"totalCost":74000,
+					"currency":"RUB",
+					"taxMode":"inclusive",
+					"taxTotal":0,
+					"shippingCost":0,
 					"createdDate":"2025-05-25T12:17:16.550631Z",
 					"lastModifiedDate":"2025-05-25T12:17:16.550631Z",
 					"status":{"key":"active","displayName":"Активный"},
@@ -223,6 +259,7 @@ func TestHandler_ListOrders(t *testing.T) {
 			r.GET("/orders", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
 				ctx.Set("role", model.RoleEmployee)
+				ctx.Set("permissions", model.NewPermissionSet([]model.Permission{model.PermOrdersReadAll}))
 				handler.ListOrders(ctx)
 			})
 
@@ -264,17 +301,20 @@ func TestHandler_EditOrders(t *testing.T) {
 				Products: []model.OrderProduct{
 					{
 						ProductID: 1,
-						Quantity:  1,
+						Quantity:  model.NewQuantity(1),
 						SellPrice: 74000,
 					},
 				},
 			},
 			mockBehavior: func(s *mock_service.MockOrder, requestBody model.OrderRequestBody) {
-				s.EXPECT().Update(1, requestBody, 1).Return(
+				s.EXPECT().Update(1, requestBody, 1, model.RoleClient).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
+						TaxMode:          model.TaxInclusive,
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						Status:           model.StatusActive,
@@ -282,7 +322,7 @@ func TestHandler_EditOrders(t *testing.T) {
 							{
 								ID:        1,
 								Code:      14823,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								Name:      "Cheese",
 								SellPrice: 74000,
 							},
@@ -296,7 +336,13 @@ func TestHandler_EditOrders(t *testing.T) {
 				{
 					"id":1,
 					"number":1,
+					"subtotal":74000,
+					"discountTotal":0,
 					"totalCost":74000,
+					"currency":"RUB",
+					"taxMode":"inclusive",
+					"taxTotal":0,
+					"shippingCost":0,
 					"createdDate":"2025-05-25T12:17:16.550631Z",
 					"lastModifiedDate":"2025-05-25T12:17:16.550631Z",
 					"status":{"key":"active","displayName":"Активный"},
@@ -323,6 +369,7 @@ func TestHandler_EditOrders(t *testing.T) {
 			r := gin.New()
 			r.PUT("/orders/:id", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
+				ctx.Set("role", model.RoleClient)
 				handler.EditOrder(ctx)
 			})
 
@@ -353,7 +400,10 @@ func TestHandler_GetOrderById(t *testing.T) {
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
+						TaxMode:          model.TaxInclusive,
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						Status:           model.StatusActive,
@@ -361,7 +411,7 @@ func TestHandler_GetOrderById(t *testing.T) {
 							{
 								ID:        1,
 								Code:      14823,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								Name:      "Cheese",
 								SellPrice: 74000,
 							},
@@ -375,7 +425,13 @@ func TestHandler_GetOrderById(t *testing.T) {
 				{
 					"id":1,
 					"number":1,
+					"subtotal":74000,
+					"discountTotal":0,
 					"totalCost":74000,
+					"currency":"RUB",
+					"taxMode":"inclusive",
+					"taxTotal":0,
+					"shippingCost":0,
 					"createdDate":"2025-05-25T12:17:16.550631Z",
 					"lastModifiedDate":"2025-05-25T12:17:16.550631Z",
 					"status":{"key":"active","displayName":"Активный"},
@@ -403,6 +459,7 @@ func TestHandler_GetOrderById(t *testing.T) {
 			r.GET("/orders/:id", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
 				ctx.Set("role", model.RoleEmployee)
+				ctx.Set("permissions", model.NewPermissionSet([]model.Permission{model.PermOrdersReadAll}))
 				handler.GetOrderByID(ctx)
 			})
 
@@ -429,7 +486,7 @@ func TestHandler_DeleteOrder(t *testing.T) {
 		{
 			name: "Ok",
 			mockBehavior: func(s *mock_service.MockOrder) {
-				s.EXPECT().Delete(1, 1).Return(nil)
+				s.EXPECT().Delete(1, 1, model.RoleClient).Return(nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"Success","message":"Объект успешно удален"}`,
@@ -437,7 +494,7 @@ func TestHandler_DeleteOrder(t *testing.T) {
 		{
 			name: "Ошибка удаления",
 			mockBehavior: func(s *mock_service.MockOrder) {
-				s.EXPECT().Delete(1, 1).Return(errors.NewInternalError("Неизвестная ошибка сервера", nil))
+				s.EXPECT().Delete(1, 1, model.RoleClient).Return(errors.NewInternalError("Неизвестная ошибка сервера", nil))
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"type":"INTERNAL_ERROR","message":"Неизвестная ошибка сервера","code":500}`,
@@ -455,6 +512,7 @@ func TestHandler_DeleteOrder(t *testing.T) {
 			r := gin.New()
 			r.DELETE("/orders/:id", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
+				ctx.Set("role", model.RoleClient)
 				handler.DeleteOrder(ctx)
 			})
 
@@ -476,6 +534,8 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 		name                 string
 		inputBody            string
 		inputOrder           model.OrderStatusRequest
+		role                 model.UserRole
+		permissions          []model.Permission
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
@@ -495,12 +555,17 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 					DisplayName: "Выполнен",
 				},
 			},
+			role:        model.RoleWarehouse,
+			permissions: []model.Permission{model.PermOrdersExecute, model.PermOrdersReadAll},
 			mockBehavior: func(s *mock_service.MockOrder, requestBody model.OrderStatusRequest) {
-				s.EXPECT().UpdateStatus(1, requestBody, 1).Return(
+				s.EXPECT().UpdateStatus(1, requestBody, 1, model.RoleWarehouse).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
+						TaxMode:          model.TaxInclusive,
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						Status:           model.StatusActive,
@@ -508,7 +573,7 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 							{
 								ID:        1,
 								Code:      14823,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								Name:      "Cheese",
 								SellPrice: 74000,
 							},
@@ -522,7 +587,13 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 				{
 					"id":1,
 					"number":1,
+					"subtotal":74000,
+					"discountTotal":0,
 					"totalCost":74000,
+					"currency":"RUB",
+					"taxMode":"inclusive",
+					"taxTotal":0,
+					"shippingCost":0,
 					"createdDate":"2025-05-25T12:17:16.550631Z",
 					"lastModifiedDate":"2025-05-25T12:17:16.550631Z",
 					"status":{"key":"active","displayName":"Активный"},
@@ -538,6 +609,25 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 					"userId":1
 				}`,
 		},
+		{
+			name: "Клиент без разрешения orders:execute",
+			inputBody: `
+				{
+					"status":{
+						"key":"executed",
+						"displayName":"Выполнен"
+					}
+				}`,
+			role:               model.RoleClient,
+			permissions:        []model.Permission{model.PermOrdersRead, model.PermOrdersWrite},
+			mockBehavior:       func(_ *mock_service.MockOrder, _ model.OrderStatusRequest) {},
+			expectedStatusCode: 403,
+			expectedResponseBody: `{
+				"code":403,
+				"message":"Недостаточно прав для выполнения операции",
+				"type":"FORBIDDEN"
+			}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -549,8 +639,9 @@ func TestHandler_ChangeOrderStatus(t *testing.T) {
 			r := gin.New()
 			r.PATCH("/orders/:id", func(ctx *gin.Context) {
 				ctx.Set("userId", 1)
-				handler.ChangeOrderStatus(ctx)
-			})
+				ctx.Set("role", test.role)
+				ctx.Set("permissions", model.NewPermissionSet(test.permissions))
+			}, middleware.RequirePermission(model.PermOrdersExecute), handler.ChangeOrderStatus)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("PATCH", "/orders/1", bytes.NewBufferString(test.inputBody))
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreatePriceList
+// @Summary Создание прайс-листа
+// @Tags PriceLists
+// @Accept			json
+// @Produce		json
+// @Param priceList body model.PriceListRequestBody true "Объект прайс-листа"
+// @Success 201 {object} model.PriceList "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists [post]
+// @Security BearerAuth.
+func (h *Handler) CreatePriceList(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var priceListReq model.PriceListRequestBody
+	if err := ctx.ShouldBindJSON(&priceListReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	priceList, err := h.Services.PriceList.Create(priceListReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create price list",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, priceList)
+}
+
+// EditPriceList
+// @Summary Редактирование прайс-листа
+// @Description Список цен прайс-листа заменяется целиком
+// @Tags PriceLists
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id прайс-листа"
+// @Param priceList body model.PriceListRequestBody true "Объект прайс-листа"
+// @Success 200 {object} model.PriceList
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists/{id} [put]
+// @Security BearerAuth.
+func (h *Handler) EditPriceList(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID прайс-листа", err))
+		return
+	}
+	var priceListReq model.PriceListRequestBody
+	if err := ctx.ShouldBindJSON(&priceListReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	priceList, err := h.Services.PriceList.Update(id, priceListReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to update price list",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "прайс-лист не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("прайс-лист", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, priceList)
+}
+
+// ListPriceLists
+// @Summary Список прайс-листов
+// @Tags PriceLists
+// @Produce		json
+// @Success 200 {object} []model.PriceList
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists [get]
+// @Security BearerAuth.
+func (h *Handler) ListPriceLists(ctx *gin.Context) {
+	priceLists, err := h.Services.PriceList.GetAll()
+	if err != nil {
+		logger.GetLogger().Error("failed to get price lists",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, priceLists)
+}
+
+// GetPriceListByID
+// @Summary Получение прайс-листа по id
+// @Tags PriceLists
+// @Produce		json
+// @Param id path string true "id прайс-листа"
+// @Success 200 {object} model.PriceList
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetPriceListByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID прайс-листа", err))
+		return
+	}
+	priceList, err := h.Services.PriceList.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get price list",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, priceList)
+}
+
+// DeletePriceList
+// @Summary Удаление прайс-листа
+// @Tags PriceLists
+// @Produce		json
+// @Param id path string true "id прайс-листа"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeletePriceList(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID прайс-листа", err))
+		return
+	}
+	if err := h.Services.PriceList.Delete(id); err != nil {
+		logger.GetLogger().Error("failed to delete price list",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		if strings.Contains(err.Error(), "прайс-лист не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("прайс-лист", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
+
+// AssignPriceList
+// @Summary Назначение прайс-листа пользователю или группе клиентов
+// @Tags PriceLists
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id прайс-листа"
+// @Param assignment body model.PriceListAssignmentBody true "Пользователь или группа клиентов"
+// @Success 201 {object} model.PriceListAssignment "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists/{id}/assignments [post]
+// @Security BearerAuth.
+func (h *Handler) AssignPriceList(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID прайс-листа", err))
+		return
+	}
+	var assignmentReq model.PriceListAssignmentBody
+	if err := ctx.ShouldBindJSON(&assignmentReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	assignment, err := h.Services.PriceList.AddAssignment(id, assignmentReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to assign price list",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		if strings.Contains(err.Error(), "не найдены") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("прайс-лист, пользователь или группа клиентов", err))
+			return
+		}
+		if strings.Contains(err.Error(), "прайс-лист уже назначен") {
+			middleware.HandleError(ctx, errors.NewValidationError("Прайс-лист уже назначен", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, assignment)
+}
+
+// UnassignPriceList
+// @Summary Отмена назначения прайс-листа
+// @Tags PriceLists
+// @Produce		json
+// @Param id path string true "id прайс-листа"
+// @Param assignmentId path string true "id назначения"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/price-lists/{id}/assignments/{assignmentId} [delete]
+// @Security BearerAuth.
+func (h *Handler) UnassignPriceList(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID прайс-листа", err))
+		return
+	}
+	assignmentID, err := strconv.Atoi(ctx.Params.ByName("assignmentId"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID назначения", err))
+		return
+	}
+	if err := h.Services.PriceList.DeleteAssignment(id, assignmentID); err != nil {
+		logger.GetLogger().Error("failed to delete price list assignment",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+			zap.Int("assignment_id", assignmentID),
+		)
+		if strings.Contains(err.Error(), "не найдено") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("назначение прайс-листа", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
+
+// CreateCustomerGroup
+// @Summary Создание группы клиентов
+// @Tags PriceLists
+// @Accept			json
+// @Produce		json
+// @Param group body model.CustomerGroupRequestBody true "Объект группы клиентов"
+// @Success 201 {object} model.CustomerGroup "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/customer-groups [post]
+// @Security BearerAuth.
+func (h *Handler) CreateCustomerGroup(ctx *gin.Context) {
+	var groupReq model.CustomerGroupRequestBody
+	if err := ctx.ShouldBindJSON(&groupReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	group, err := h.Services.PriceList.CreateCustomerGroup(groupReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create customer group",
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "уже существует") {
+			middleware.HandleError(ctx, errors.NewValidationError("Группа клиентов уже существует", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// ListCustomerGroups
+// @Summary Список групп клиентов
+// @Tags PriceLists
+// @Produce		json
+// @Success 200 {object} []model.CustomerGroup
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/customer-groups [get]
+// @Security BearerAuth.
+func (h *Handler) ListCustomerGroups(ctx *gin.Context) {
+	groups, err := h.Services.PriceList.GetCustomerGroups()
+	if err != nil {
+		logger.GetLogger().Error("failed to get customer groups",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, groups)
+}
+
+// DeleteCustomerGroup
+// @Summary Удаление группы клиентов
+// @Tags PriceLists
+// @Produce		json
+// @Param id path string true "id группы клиентов"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/customer-groups/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteCustomerGroup(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID группы клиентов", err))
+		return
+	}
+	if err := h.Services.PriceList.DeleteCustomerGroup(id); err != nil {
+		logger.GetLogger().Error("failed to delete customer group",
+			zap.Error(err),
+			zap.Int("group_id", id),
+		)
+		if strings.Contains(err.Error(), "группа клиентов не найдена") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("группа клиентов", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
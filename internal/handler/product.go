@@ -17,6 +17,7 @@ import (
 
 // CreateProduct
 // @Summary Создание продукта
+// @Description Для комплекта (isBundle) указывается состав, остаток вычисляется по остаткам компонентов
 // @Tags Products
 // @Accept			json
 // @Produce		json
@@ -28,29 +29,23 @@ import (
 // @Router /api/v1/products [post]
 // @Security BearerAuth.
 func (h *Handler) CreateProduct(ctx *gin.Context) {
-	role, exists := ctx.Get(userRoleKey)
 	userID := ctx.GetInt(userIDKey)
-	if !exists {
-		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
-		return
-	}
-	isEmployee := role == model.RoleEmployee
-	if !isEmployee {
-		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
-		return
-	}
 	var productReq model.Product
 	if err := ctx.ShouldBindJSON(&productReq); err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
 		return
 	}
 
-	product, err := h.Services.Product.Create(productReq)
+	product, err := h.Services.Product.Create(productReq, userID)
 	if err != nil {
 		logger.GetLogger().Error("failed to create product",
 			zap.Error(err),
 			zap.Int("user_id", userID),
 		)
+		if strings.Contains(err.Error(), "состав") || strings.Contains(err.Error(), "единиц") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -72,17 +67,7 @@ func (h *Handler) CreateProduct(ctx *gin.Context) {
 // @Router /api/v1/products/{id} [put]
 // @Security BearerAuth.
 func (h *Handler) EditProduct(ctx *gin.Context) {
-	role, exists := ctx.Get(userRoleKey)
 	userID := ctx.GetInt(userIDKey)
-	if !exists {
-		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
-		return
-	}
-	isEmployee := role == model.RoleEmployee
-	if !isEmployee {
-		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
-		return
-	}
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -94,7 +79,7 @@ func (h *Handler) EditProduct(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
 		return
 	}
-	product, err := h.Services.Product.Update(id, productReq)
+	product, err := h.Services.Product.Update(id, productReq, userID)
 	if err != nil {
 		logger.GetLogger().Error("failed to edit product",
 			zap.Error(err),
@@ -104,6 +89,10 @@ func (h *Handler) EditProduct(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("продукт", err))
 			return
 		}
+		if strings.Contains(err.Error(), "состав") || strings.Contains(err.Error(), "единиц") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -112,7 +101,8 @@ func (h *Handler) EditProduct(ctx *gin.Context) {
 
 // ListProduct
 // @Summary Список продуктов
-// @Description Получение списка продуктов с возможностью фильтрации, сортировки и пагинации
+// @Description Получение списка продуктов с возможностью фильтрации, сортировки и пагинации.
+// @Description Цена продажи указывается по прайс-листу текущего пользователя
 // @Tags Products
 // @Accept json
 // @Produce json
@@ -145,7 +135,7 @@ func (h *Handler) ListProduct(ctx *gin.Context) {
 		params.PageSize = 25
 	}
 
-	products, err := h.Services.Product.GetAll(params)
+	products, err := h.Services.Product.GetAll(params, ctx.GetInt(userIDKey))
 	if err != nil {
 		middleware.HandleError(ctx, err)
 		return
@@ -187,7 +177,7 @@ func (h *Handler) GetProductByID(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID продукта", err))
 		return
 	}
-	product, err := h.Services.Product.GetByID(id)
+	product, err := h.Services.Product.GetByID(id, userID)
 	if err != nil {
 		logger.GetLogger().Error("failed to get product",
 			zap.Error(err),
@@ -216,17 +206,7 @@ func (h *Handler) GetProductByID(ctx *gin.Context) {
 // @Router /api/v1/products/{id} [delete]
 // @Security BearerAuth.
 func (h *Handler) DeleteProduct(ctx *gin.Context) {
-	role, exists := ctx.Get(userRoleKey)
 	userID := ctx.GetInt(userIDKey)
-	if !exists {
-		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
-		return
-	}
-	isEmployee := role == model.RoleEmployee
-	if !isEmployee {
-		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
-		return
-	}
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -243,6 +223,10 @@ func (h *Handler) DeleteProduct(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("продукт", err))
 			return
 		}
+		if strings.Contains(err.Error(), "входит в состав комплекта") {
+			middleware.HandleError(ctx, errors.NewValidationError("Продукт входит в состав комплекта", err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -253,3 +237,110 @@ func (h *Handler) DeleteProduct(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, success)
 }
+
+// GetProductPrices
+// @Summary История цен продукта
+// @Description Возвращает примененные и запланированные изменения цен продукта, начиная с последних
+// @Description История содержит закупочные цены, поэтому доступна только с разрешением products:write.
+// @Tags Products
+// @Produce		json
+// @Success 200 {object} []model.PriceChange
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Param id path string true "id продукта"
+// @Router /api/v1/products/{id}/prices [get]
+// @Security BearerAuth.
+func (h *Handler) GetProductPrices(ctx *gin.Context) {
+	idStr := ctx.Params.ByName("id")
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID продукта", err))
+		return
+	}
+	history, err := h.Services.Product.GetPriceHistory(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get product price history",
+			zap.Error(err),
+			zap.Int("product_id", id),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// CreateProductPrice
+// @Summary Изменение цен продукта
+// @Description Если effectiveFrom в будущем, изменение будет применено автоматически в указанное время
+// @Tags Products
+// @Accept			json
+// @Produce		json
+// @Param price body model.PriceChangeRequestBody true "Новые цены продукта"
+// @Success 201 {object} model.PriceChange "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Param id path string true "id продукта"
+// @Router /api/v1/products/{id}/prices [post]
+// @Security BearerAuth.
+func (h *Handler) CreateProductPrice(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	idStr := ctx.Params.ByName("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID продукта", err))
+		return
+	}
+	var priceReq model.PriceChangeRequestBody
+	if err := ctx.ShouldBindJSON(&priceReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	priceChange, err := h.Services.Product.CreatePriceChange(id, priceReq, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to create product price change",
+			zap.Error(err),
+			zap.Int("product_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "продукт не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("продукт", err))
+			return
+		}
+		if strings.Contains(err.Error(), "цена не может быть отрицательной") {
+			middleware.HandleError(ctx, errors.NewValidationError("Цена не может быть отрицательной", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, priceChange)
+}
+
+// ListUnits
+// @Summary Справочник единиц измерения
+// @Tags Products
+// @Produce		json
+// @Success 200 {object} []model.Unit
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/units [get]
+// @Security BearerAuth.
+func (h *Handler) ListUnits(ctx *gin.Context) {
+	units, err := h.Services.Product.GetUnits()
+	if err != nil {
+		logger.GetLogger().Error("failed to get units",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, units)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreatePromoCode
+// @Summary Создание промокода
+// @Tags PromoCodes
+// @Accept			json
+// @Produce		json
+// @Param promoCode body model.PromoCodeRequestBody true "Объект промокода"
+// @Success 201 {object} model.PromoCode "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/promo-codes [post]
+// @Security BearerAuth.
+func (h *Handler) CreatePromoCode(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var promoCodeReq model.PromoCodeRequestBody
+	if err := ctx.ShouldBindJSON(&promoCodeReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	promoCode, err := h.Services.PromoCode.Create(promoCodeReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create promo code",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "уже существует") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, promoCode)
+}
+
+// EditPromoCode
+// @Summary Редактирование промокода
+// @Tags PromoCodes
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id промокода"
+// @Param promoCode body model.PromoCodeRequestBody true "Объект промокода"
+// @Success 200 {object} model.PromoCode
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/promo-codes/{id} [put]
+// @Security BearerAuth.
+func (h *Handler) EditPromoCode(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID промокода", err))
+		return
+	}
+	var promoCodeReq model.PromoCodeRequestBody
+	if err := ctx.ShouldBindJSON(&promoCodeReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	promoCode, err := h.Services.PromoCode.Update(id, promoCodeReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to update promo code",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "промокод не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("промокод", err))
+			return
+		}
+		if strings.Contains(err.Error(), "уже существует") {
+			middleware.HandleError(ctx, errors.NewValidationError(err.Error(), err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, promoCode)
+}
+
+// ListPromoCodes
+// @Summary Список промокодов
+// @Tags PromoCodes
+// @Produce		json
+// @Success 200 {object} []model.PromoCode
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/promo-codes [get]
+// @Security BearerAuth.
+func (h *Handler) ListPromoCodes(ctx *gin.Context) {
+	promoCodes, err := h.Services.PromoCode.GetAll()
+	if err != nil {
+		logger.GetLogger().Error("failed to get promo codes",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, promoCodes)
+}
+
+// GetPromoCodeByID
+// @Summary Получение промокода по id
+// @Tags PromoCodes
+// @Produce		json
+// @Param id path string true "id промокода"
+// @Success 200 {object} model.PromoCode
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/promo-codes/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetPromoCodeByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID промокода", err))
+		return
+	}
+	promoCode, err := h.Services.PromoCode.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get promo code",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, promoCode)
+}
+
+// DeletePromoCode
+// @Summary Удаление промокода
+// @Tags PromoCodes
+// @Produce		json
+// @Param id path string true "id промокода"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/promo-codes/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeletePromoCode(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID промокода", err))
+		return
+	}
+	if err := h.Services.PromoCode.Delete(id); err != nil {
+		logger.GetLogger().Error("failed to delete promo code",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+		)
+		if strings.Contains(err.Error(), "промокод не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("промокод", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
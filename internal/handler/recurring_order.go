@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateRecurringOrder
+// @Summary Создание регулярного заказа
+// @Description По расписанию (каждые intervalCount дней, недель или месяцев) из шаблона создается обычный заказ
+// @Description по текущим ценам. Без startDate первый заказ создается через один период.
+// @Description Сотрудник может оформить регулярный заказ на клиента, указав его в userId.
+// @Tags RecurringOrders
+// @Accept			json
+// @Produce		json
+// @Param recurringOrder body model.RecurringOrderRequestBody true "Объект регулярного заказа"
+// @Success 201 {object} model.RecurringOrder "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders [post]
+// @Security BearerAuth.
+func (h *Handler) CreateRecurringOrder(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	var recurringReq model.RecurringOrderRequestBody
+	if err := ctx.ShouldBindJSON(&recurringReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	recurring, err := h.Services.RecurringOrder.Create(recurringReq, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to create recurring order",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		handleRecurringOrderError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, recurring)
+}
+
+// ListRecurringOrders
+// @Summary Список регулярных заказов
+// @Description Клиент видит только свои регулярные заказы.
+// @Tags RecurringOrders
+// @Produce		json
+// @Success 200 {object} []model.RecurringOrder
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders [get]
+// @Security BearerAuth.
+func (h *Handler) ListRecurringOrders(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	recurringOrders, err := h.Services.RecurringOrder.GetAll(userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get recurring orders",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, recurringOrders)
+}
+
+// GetRecurringOrderByID
+// @Summary Получение регулярного заказа по id
+// @Description Возвращает шаблон вместе с последними запусками.
+// @Tags RecurringOrders
+// @Produce		json
+// @Param id path string true "id регулярного заказа"
+// @Success 200 {object} model.RecurringOrder
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetRecurringOrderByID(ctx *gin.Context) {
+	h.changeRecurringOrder(ctx, "get", h.Services.RecurringOrder.GetByID)
+}
+
+// PauseRecurringOrder
+// @Summary Приостановка регулярного заказа
+// @Tags RecurringOrders
+// @Produce		json
+// @Param id path string true "id регулярного заказа"
+// @Success 200 {object} model.RecurringOrder
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders/{id}/pause [post]
+// @Security BearerAuth.
+func (h *Handler) PauseRecurringOrder(ctx *gin.Context) {
+	h.changeRecurringOrder(ctx, "pause", h.Services.RecurringOrder.Pause)
+}
+
+// ResumeRecurringOrder
+// @Summary Возобновление регулярного заказа
+// @Description Запуски, пропущенные за время паузы, не выполняются.
+// @Tags RecurringOrders
+// @Produce		json
+// @Param id path string true "id регулярного заказа"
+// @Success 200 {object} model.RecurringOrder
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders/{id}/resume [post]
+// @Security BearerAuth.
+func (h *Handler) ResumeRecurringOrder(ctx *gin.Context) {
+	h.changeRecurringOrder(ctx, "resume", h.Services.RecurringOrder.Resume)
+}
+
+// SkipRecurringOrder
+// @Summary Пропуск ближайшего запуска регулярного заказа
+// @Tags RecurringOrders
+// @Produce		json
+// @Param id path string true "id регулярного заказа"
+// @Success 200 {object} model.RecurringOrder
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders/{id}/skip [post]
+// @Security BearerAuth.
+func (h *Handler) SkipRecurringOrder(ctx *gin.Context) {
+	h.changeRecurringOrder(ctx, "skip", h.Services.RecurringOrder.Skip)
+}
+
+// DeleteRecurringOrder
+// @Summary Удаление регулярного заказа
+// @Description Уже созданные по шаблону заказы не удаляются.
+// @Tags RecurringOrders
+// @Produce		json
+// @Param id path string true "id регулярного заказа"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/recurring-orders/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteRecurringOrder(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID регулярного заказа", err))
+		return
+	}
+	if err := h.Services.RecurringOrder.Delete(id, userID, role); err != nil {
+		logger.GetLogger().Error("failed to delete recurring order",
+			zap.Error(err),
+			zap.Int("recurring_order_id", id),
+			zap.Int("user_id", userID),
+		)
+		handleRecurringOrderError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
+
+// changeRecurringOrder выполняет операцию над регулярным заказом из пути запроса.
+func (h *Handler) changeRecurringOrder(
+	ctx *gin.Context,
+	operation string,
+	change func(id, userID int, role model.UserRole) (*model.RecurringOrder, error),
+) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermOrdersReadAll)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID регулярного заказа", err))
+		return
+	}
+	recurring, err := change(id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to "+operation+" recurring order",
+			zap.Error(err),
+			zap.Int("recurring_order_id", id),
+			zap.Int("user_id", userID),
+		)
+		handleRecurringOrderError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, recurring)
+}
+
+// handleRecurringOrderError сопоставляет ошибки репозитория регулярных заказов с ответами API.
+func handleRecurringOrderError(ctx *gin.Context, err error) {
+	if _, ok := errors.IsAppError(err); ok {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "регулярный заказ не найден"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("регулярный заказ", err))
+	case strings.Contains(msg, "адрес или способ доставки не найден"):
+		middleware.HandleError(ctx, errors.NewValidationError(msg, err))
+	default:
+		middleware.HandleError(ctx, err)
+	}
+}
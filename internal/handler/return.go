@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateReturn
+// @Summary Создание заявки на возврат товаров выполненного заказа
+// @Tags Returns
+// @Accept			json
+// @Produce		json
+// @Param return body model.ReturnRequestBody true "Заявка на возврат"
+// @Success 201 {object} model.Return "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/returns [post]
+// @Security BearerAuth.
+func (h *Handler) CreateReturn(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermReturnsDecide)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	var returnReq model.ReturnRequestBody
+	if err := ctx.ShouldBindJSON(&returnReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	ret, err := h.Services.Return.Create(returnReq, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to create return",
+			zap.Error(err),
+			zap.Int("order_id", returnReq.OrderID),
+			zap.Int("user_id", userID),
+		)
+		handleReturnError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, ret)
+}
+
+// ListReturns
+// @Summary Список заявок на возврат
+// @Description Клиент видит только свои заявки, сотрудник — все.
+// @Tags Returns
+// @Produce		json
+// @Success 200 {object} []model.Return
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/returns [get]
+// @Security BearerAuth.
+func (h *Handler) ListReturns(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermReturnsDecide)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	returns, err := h.Services.Return.GetAll(userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get returns",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, returns)
+}
+
+// GetReturnByID
+// @Summary Получение заявки на возврат по id
+// @Tags Returns
+// @Produce		json
+// @Param id path string true "id заявки на возврат"
+// @Success 200 {object} model.Return
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/returns/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetReturnByID(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	role, exists := scopedRole(ctx, model.PermReturnsDecide)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID возврата", err))
+		return
+	}
+	ret, err := h.Services.Return.GetByID(id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get return",
+			zap.Error(err),
+			zap.Int("return_id", id),
+			zap.Int("user_id", userID),
+		)
+		handleReturnError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ret)
+}
+
+// DecideReturn
+// @Summary Одобрение или отклонение заявки на возврат
+// @Description При одобрении товары возвращаются на склад, товары из damagedProductIds списываются.
+// @Tags Returns
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id заявки на возврат"
+// @Param decision body model.ReturnDecisionBody true "Решение по заявке"
+// @Success 200 {object} model.Return
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/returns/{id} [patch]
+// @Security BearerAuth.
+func (h *Handler) DecideReturn(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID возврата", err))
+		return
+	}
+	var decision model.ReturnDecisionBody
+	if err := ctx.ShouldBindJSON(&decision); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	ret, err := h.Services.Return.Decide(id, decision, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to decide return",
+			zap.Error(err),
+			zap.Int("return_id", id),
+			zap.Int("user_id", userID),
+		)
+		handleReturnError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ret)
+}
+
+func handleReturnError(ctx *gin.Context, err error) {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "возврат не найден"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("возврат", err))
+	case strings.Contains(msg, "заказ не найден"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("заказ", err))
+	case strings.Contains(msg, "выполненного заказа"),
+		strings.Contains(msg, "больше, чем куплено"),
+		strings.Contains(msg, "отсутству"),
+		strings.Contains(msg, "несколько раз"),
+		strings.Contains(msg, "уже рассмотрена"):
+		middleware.HandleError(ctx, errors.NewValidationError(msg, err))
+	default:
+		middleware.HandleError(ctx, err)
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateRole
+// @Summary Создание роли
+// @Description Ключ роли — латинские строчные буквы, цифры и _, до 20 символов.
+// @Tags Roles
+// @Accept			json
+// @Produce		json
+// @Param role body model.RoleRequestBody true "Объект роли"
+// @Success 201 {object} model.Role "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/roles [post]
+// @Security BearerAuth.
+func (h *Handler) CreateRole(ctx *gin.Context) {
+	var roleReq model.RoleRequestBody
+	if err := ctx.ShouldBindJSON(&roleReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	role, err := h.Services.Role.Create(roleReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create role",
+			zap.Error(err),
+			zap.String("role", string(roleReq.Key)),
+		)
+		handleRoleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, role)
+}
+
+// EditRole
+// @Summary Изменение роли
+// @Description Разрешения роли admin не изменяются. Ключ в теле запроса игнорируется.
+// @Tags Roles
+// @Accept			json
+// @Produce		json
+// @Param key path string true "Ключ роли"
+// @Param role body model.RoleRequestBody true "Объект роли"
+// @Success 200 {object} model.Role
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/roles/{key} [put]
+// @Security BearerAuth.
+func (h *Handler) EditRole(ctx *gin.Context) {
+	key := model.UserRole(ctx.Params.ByName("key"))
+	var roleReq model.RoleRequestBody
+	if err := ctx.ShouldBindJSON(&roleReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	role, err := h.Services.Role.Update(key, roleReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to update role",
+			zap.Error(err),
+			zap.String("role", string(key)),
+		)
+		handleRoleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, role)
+}
+
+// ListRoles
+// @Summary Список ролей
+// @Tags Roles
+// @Produce		json
+// @Success 200 {object} []model.Role
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/roles [get]
+// @Security BearerAuth.
+func (h *Handler) ListRoles(ctx *gin.Context) {
+	roles, err := h.Services.Role.GetAll()
+	if err != nil {
+		logger.GetLogger().Error("failed to get roles",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, roles)
+}
+
+// GetRoleByKey
+// @Summary Получение роли
+// @Tags Roles
+// @Produce		json
+// @Param key path string true "Ключ роли"
+// @Success 200 {object} model.Role
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/roles/{key} [get]
+// @Security BearerAuth.
+func (h *Handler) GetRoleByKey(ctx *gin.Context) {
+	key := model.UserRole(ctx.Params.ByName("key"))
+	role, err := h.Services.Role.GetByKey(key)
+	if err != nil {
+		logger.GetLogger().Error("failed to get role",
+			zap.Error(err),
+			zap.String("role", string(key)),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, role)
+}
+
+// DeleteRole
+// @Summary Удаление роли
+// @Description Системные роли и роли, назначенные пользователям, не удаляются.
+// @Tags Roles
+// @Produce		json
+// @Param key path string true "Ключ роли"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/roles/{key} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteRole(ctx *gin.Context) {
+	key := model.UserRole(ctx.Params.ByName("key"))
+	if err := h.Services.Role.Delete(key); err != nil {
+		logger.GetLogger().Error("failed to delete role",
+			zap.Error(err),
+			zap.String("role", string(key)),
+		)
+		handleRoleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
+
+// ListPermissions
+// @Summary Список разрешений
+// @Description Разрешения, из которых составляются роли.
+// @Tags Roles
+// @Produce		json
+// @Success 200 {object} []string
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Router /api/v1/permissions [get]
+// @Security BearerAuth.
+func (h *Handler) ListPermissions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, model.AllPermissions)
+}
+
+func handleRoleError(ctx *gin.Context, err error) {
+	if _, ok := errors.IsAppError(err); ok {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "является системной"):
+		middleware.HandleError(ctx, errors.NewValidationError("Роль не найдена или является системной", err))
+	case strings.Contains(msg, "роль не найдена"):
+		middleware.HandleError(ctx, errors.NewNotFoundError("роль", err))
+	case strings.Contains(msg, "уже существует"), strings.Contains(msg, "назначена пользователям"):
+		middleware.HandleError(ctx, errors.NewValidationError(msg, err))
+	default:
+		middleware.HandleError(ctx, err)
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateShippingMethod
+// @Summary Создание способа доставки
+// @Description rule: flat — фиксированная стоимость, weight — baseCost плюс costPerKg за каждый начатый килограмм,
+// @Description free_over — бесплатно от суммы товаров freeThreshold, иначе baseCost.
+// @Tags ShippingMethods
+// @Accept			json
+// @Produce		json
+// @Param method body model.ShippingMethodRequestBody true "Объект способа доставки"
+// @Success 201 {object} model.ShippingMethod "Created"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/shipping-methods [post]
+// @Security BearerAuth.
+func (h *Handler) CreateShippingMethod(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var methodReq model.ShippingMethodRequestBody
+	if err := ctx.ShouldBindJSON(&methodReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	method, err := h.Services.ShippingMethod.Create(methodReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create shipping method",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, method)
+}
+
+// EditShippingMethod
+// @Summary Редактирование способа доставки
+// @Tags ShippingMethods
+// @Accept			json
+// @Produce		json
+// @Param id path string true "id способа доставки"
+// @Param method body model.ShippingMethodRequestBody true "Объект способа доставки"
+// @Success 200 {object} model.ShippingMethod
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/shipping-methods/{id} [put]
+// @Security BearerAuth.
+func (h *Handler) EditShippingMethod(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID способа доставки", err))
+		return
+	}
+	var methodReq model.ShippingMethodRequestBody
+	if err := ctx.ShouldBindJSON(&methodReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	method, err := h.Services.ShippingMethod.Update(id, methodReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to update shipping method",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "способ доставки не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("способ доставки", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, method)
+}
+
+// ListShippingMethods
+// @Summary Список способов доставки
+// @Description Клиенту возвращаются только активные способы.
+// @Tags ShippingMethods
+// @Produce		json
+// @Success 200 {object} []model.ShippingMethod
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/shipping-methods [get]
+// @Security BearerAuth.
+func (h *Handler) ListShippingMethods(ctx *gin.Context) {
+	role, exists := scopedRole(ctx, model.PermShippingManage)
+	if !exists {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
+		return
+	}
+	methods, err := h.Services.ShippingMethod.GetAll(role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get shipping methods",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, methods)
+}
+
+// GetShippingMethodByID
+// @Summary Получение способа доставки по id
+// @Tags ShippingMethods
+// @Produce		json
+// @Param id path string true "id способа доставки"
+// @Success 200 {object} model.ShippingMethod
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/shipping-methods/{id} [get]
+// @Security BearerAuth.
+func (h *Handler) GetShippingMethodByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID способа доставки", err))
+		return
+	}
+	method, err := h.Services.ShippingMethod.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get shipping method",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, method)
+}
+
+// DeleteShippingMethod
+// @Summary Удаление способа доставки
+// @Tags ShippingMethods
+// @Produce		json
+// @Param id path string true "id способа доставки"
+// @Success 200 {object} model.Success "Объект успешно удален"
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/shipping-methods/{id} [delete]
+// @Security BearerAuth.
+func (h *Handler) DeleteShippingMethod(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID способа доставки", err))
+		return
+	}
+	if err := h.Services.ShippingMethod.Delete(id); err != nil {
+		logger.GetLogger().Error("failed to delete shipping method",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+		)
+		if strings.Contains(err.Error(), "способ доставки не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("способ доставки", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Объект успешно удален",
+	})
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/middleware"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LoginTwoFactor
+// @Summary Второй шаг входа
+// @Description Принимает токен из ответа /login и код из приложения-аутентификатора или код восстановления.
+// @Description Если двухфакторная аутентификация настраивалась при входе, в ответе возвращаются коды восстановления.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param code body model.TwoFactorLoginRequest true "Токен второго шага и код"
+// @Success 200 {object} model.TokenSuccess
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 429 {object} model.Error "Too many requests"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/login/2fa [post].
+func (h *Handler) LoginTwoFactor(ctx *gin.Context) {
+	var loginReq model.TwoFactorLoginRequest
+	if err := ctx.ShouldBindJSON(&loginReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	token, err := h.Services.User.LoginTwoFactor(loginReq)
+	if err != nil {
+		logger.GetLogger().Error("two-factor login failed",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, token)
+}
+
+// SetupTwoFactorLogin
+// @Summary Настройка двухфакторной аутентификации при входе
+// @Description Для ролей, которым второй фактор обязателен: выдает секрет по токену из ответа /login,
+// @Description если в нем twoFactorSetupRequired. Затем код из приложения передается в /login/2fa.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param challenge body model.TwoFactorChallengeRequest true "Токен второго шага"
+// @Success 200 {object} model.TwoFactorEnrollment
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/login/2fa/setup [post].
+func (h *Handler) SetupTwoFactorLogin(ctx *gin.Context) {
+	var challengeReq model.TwoFactorChallengeRequest
+	if err := ctx.ShouldBindJSON(&challengeReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	enrollment, err := h.Services.User.SetupTwoFactorChallenge(challengeReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to set up two-factor authentication at login",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+// EnrollTwoFactor
+// @Summary Получение секрета для приложения-аутентификатора
+// @Description Двухфакторная аутентификация включается после подтверждения кодом в /me/2fa/confirm.
+// @Tags Me
+// @Produce		json
+// @Success 200 {object} model.TwoFactorEnrollment
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/2fa [post]
+// @Security BearerAuth.
+func (h *Handler) EnrollTwoFactor(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	enrollment, err := h.Services.User.EnrollTwoFactor(userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to enroll two-factor authentication",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactor
+// @Summary Включение двухфакторной аутентификации
+// @Description Проверяет код из приложения и возвращает коды восстановления. Они показываются один раз.
+// @Tags Me
+// @Accept			json
+// @Produce		json
+// @Param code body model.TwoFactorCodeRequest true "Код из приложения-аутентификатора"
+// @Success 200 {object} model.RecoveryCodes
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/2fa/confirm [post]
+// @Security BearerAuth.
+func (h *Handler) ConfirmTwoFactor(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var codeReq model.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&codeReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	codes, err := h.Services.User.ConfirmTwoFactor(userID, codeReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to confirm two-factor authentication",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, codes)
+}
+
+// DisableTwoFactor
+// @Summary Отключение двухфакторной аутентификации
+// @Description Требует код из приложения или код восстановления. Недоступно ролям, которым второй фактор обязателен.
+// @Tags Me
+// @Accept			json
+// @Produce		json
+// @Param code body model.TwoFactorCodeRequest true "Код подтверждения"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/me/2fa [delete]
+// @Security BearerAuth.
+func (h *Handler) DisableTwoFactor(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	var codeReq model.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&codeReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректное тело запроса", err))
+		return
+	}
+	if err := h.Services.User.DisableTwoFactor(userID, codeReq); err != nil {
+		logger.GetLogger().Error("failed to disable two-factor authentication",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Двухфакторная аутентификация отключена",
+	})
+}
+
+// ResetUserTwoFactor
+// @Summary Сброс двухфакторной аутентификации пользователя
+// @Description Для пользователей, потерявших устройство и коды восстановления. Если второй фактор
+// @Description обязателен для роли, при следующем входе его придется настроить заново.
+// @Tags Users
+// @Produce		json
+// @Param id path string true "id пользователя"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/users/{id}/2fa [delete]
+// @Security BearerAuth.
+func (h *Handler) ResetUserTwoFactor(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
+		return
+	}
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	if err := h.Services.User.ResetTwoFactor(id); err != nil {
+		logger.GetLogger().Error("failed to reset user two-factor authentication",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Двухфакторная аутентификация сброшена",
+	})
+}
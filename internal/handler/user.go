@@ -44,6 +44,8 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 
 // Login
 // @Summary Аутентификация пользователя
+// @Description После нескольких неудачных попыток вход временно блокируется: ответ 429,
+// @Description время до снятия блокировки передается в заголовке Retry-After.
 // @Tags Login
 // @Accept			json
 // @Produce		json
@@ -51,6 +53,7 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 // @Success 200 {object} model.TokenSuccess
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 429 {object} model.Error "Too many requests"
 // @Failure 500 {object} model.Error "Internal server error"
 // @Router /api/v1/login [post].
 func (h *Handler) Login(ctx *gin.Context) {
@@ -59,16 +62,19 @@ func (h *Handler) Login(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
 		return
 	}
-	TokenSuccess, err := h.Services.User.Login(loginRequest)
+	TokenSuccess, err := h.Services.User.Login(loginRequest, ctx.ClientIP())
 	if err != nil {
-		var appErr *errors.AppError
-		switch err.Error() {
-		case "логин или пароль пользователя недействителен":
-			appErr = errors.NewUnauthorizedError("Логин или пароль пользователя недействителен", err)
-		case "ошибка генерации токена":
-			appErr = errors.NewInternalError("Ошибка генерации токена", err)
-		default:
-			appErr = errors.NewInternalError("Ошибка аутентификации", err)
+		// Ошибки сервиса, например блокировка входа, передаются как есть.
+		appErr, ok := errors.IsAppError(err)
+		if !ok {
+			switch err.Error() {
+			case "логин или пароль пользователя недействителен":
+				appErr = errors.NewUnauthorizedError("Логин или пароль пользователя недействителен", err)
+			case "ошибка генерации токена":
+				appErr = errors.NewInternalError("Ошибка генерации токена", err)
+			default:
+				appErr = errors.NewInternalError("Ошибка аутентификации", err)
+			}
 		}
 
 		logger.GetLogger().Error("login failed",
@@ -81,6 +87,229 @@ func (h *Handler) Login(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, TokenSuccess)
 }
 
+// RefreshToken
+// @Summary Обновление токенов
+// @Description Обменивает refresh-токен на новую пару токенов. Каждый refresh-токен действует один раз:
+// @Description повторное предъявление уже использованного токена отзывает всю сессию.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param token body model.RefreshTokenRequest true "Refresh-токен"
+// @Success 200 {object} model.TokenSuccess
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/token/refresh [post].
+func (h *Handler) RefreshToken(ctx *gin.Context) {
+	var refreshReq model.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&refreshReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	tokenSuccess, err := h.Services.User.Refresh(refreshReq)
+	if err != nil {
+		logger.GetLogger().Error("token refresh failed",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tokenSuccess)
+}
+
+// ForgotPassword
+// @Summary Запрос сброса пароля
+// @Description Отправляет на email учетной записи одноразовую ссылку для сброса пароля.
+// @Description Ответ не зависит от того, зарегистрирован ли адрес.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param email body model.ForgotPasswordRequest true "Email учетной записи"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/password/forgot [post].
+func (h *Handler) ForgotPassword(ctx *gin.Context) {
+	var forgotReq model.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&forgotReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	if err := h.Services.User.ForgotPassword(forgotReq); err != nil {
+		logger.GetLogger().Error("password reset request failed",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Если адрес зарегистрирован, на него отправлено письмо со ссылкой для сброса пароля",
+	})
+}
+
+// ResetPassword
+// @Summary Сброс пароля
+// @Description Устанавливает новый пароль по токену из письма. Токен действует один раз,
+// @Description после смены пароля все сессии пользователя завершаются.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param reset body model.ResetPasswordRequest true "Токен и новый пароль"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/password/reset [post].
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	var resetReq model.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&resetReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	success, err := h.Services.User.ResetPassword(resetReq)
+	if err != nil {
+		logger.GetLogger().Error("password reset failed",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, success)
+}
+
+// VerifyEmail
+// @Summary Подтверждение email
+// @Description Подтверждает адрес электронной почты по токену из письма.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param verify body model.VerifyEmailRequest true "Токен из письма"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/email/verify [post].
+func (h *Handler) VerifyEmail(ctx *gin.Context) {
+	var verifyReq model.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&verifyReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	success, err := h.Services.User.VerifyEmail(verifyReq)
+	if err != nil {
+		logger.GetLogger().Error("email verification failed",
+			zap.Error(err),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, success)
+}
+
+// Logout
+// @Summary Выход из системы
+// @Description Отзывает текущий access-токен. Если в теле передан refresh-токен, отзывается и он.
+// @Tags Login
+// @Accept			json
+// @Produce		json
+// @Param token body model.LogoutRequest false "Refresh-токен текущей сессии"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/logout [post]
+// @Security BearerAuth.
+func (h *Handler) Logout(ctx *gin.Context) {
+	value, _ := ctx.Get(claimsKey)
+	claims, ok := value.(*model.Claims)
+	if !ok {
+		middleware.HandleError(ctx, errors.NewUnauthorizedError("Некорректный токен", nil))
+		return
+	}
+	var logoutReq model.LogoutRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&logoutReq); err != nil {
+			middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+			return
+		}
+	}
+	if err := h.Services.User.Logout(claims, logoutReq); err != nil {
+		logger.GetLogger().Error("logout failed",
+			zap.Error(err),
+			zap.Int("user_id", claims.UserID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Выход выполнен",
+	})
+}
+
+// LogoutAll
+// @Summary Выход на всех устройствах
+// @Description Отзывает все access- и refresh-токены текущего пользователя.
+// @Tags Login
+// @Produce		json
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/logout/all [post]
+// @Security BearerAuth.
+func (h *Handler) LogoutAll(ctx *gin.Context) {
+	userID := ctx.GetInt(userIDKey)
+	if err := h.Services.User.LogoutAll(userID); err != nil {
+		logger.GetLogger().Error("logout from all sessions failed",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Все сессии завершены",
+	})
+}
+
+// LogoutUser
+// @Summary Завершение всех сессий пользователя
+// @Description Сотрудник отзывает все токены указанного пользователя.
+// @Tags Users
+// @Produce		json
+// @Param id path string true "id пользователя"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal server error"
+// @Router /api/v1/users/{id}/logout [post]
+// @Security BearerAuth.
+func (h *Handler) LogoutUser(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
+		return
+	}
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	if err := h.Services.User.LogoutAll(id); err != nil {
+		logger.GetLogger().Error("failed to revoke user sessions",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Все сессии пользователя завершены",
+	})
+}
+
 // EditUser
 // @Summary Редактирование пользователя
 // @Tags Users
@@ -90,6 +319,7 @@ func (h *Handler) Login(ctx *gin.Context) {
 // @Success 200 {object} model.User
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Param id path string true "id пользователя"
@@ -102,21 +332,39 @@ func (h *Handler) EditUser(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
-	var userEdit model.UserEditBody
-	if err := ctx.ShouldBindJSON(&userEdit); err != nil {
-		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+	if err := h.checkUserChangeable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
 		return
 	}
-	if userEdit.Email == "" && userEdit.FirstName == "" && userEdit.LastName == "" {
-		middleware.HandleError(ctx, errors.NewValidationError(
-			"Тело запроса должно содержать хотя бы одно поле для обновления",
-			nil,
-		))
+	h.editUser(ctx, id)
+}
+
+// UnlockUser
+// @Summary Снятие блокировки входа
+// @Description Снимает блокировку, установленную после неудачных попыток входа, и обнуляет их счетчик.
+// @Tags Users
+// @Produce		json
+// @Param id path string true "id пользователя"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Router /api/v1/users/{id}/unlock [post]
+// @Security BearerAuth.
+func (h *Handler) UnlockUser(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Params.ByName("id"))
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
-	user, err := h.Services.User.Update(id, userEdit)
-	if err != nil {
-		logger.GetLogger().Error("failed to edit user",
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	if err := h.Services.User.Unlock(id); err != nil {
+		logger.GetLogger().Error("failed to unlock user",
 			zap.Error(err),
 			zap.Int("user_id", id),
 		)
@@ -127,7 +375,10 @@ func (h *Handler) EditUser(ctx *gin.Context) {
 		middleware.HandleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, model.Success{
+		Status:  "Success",
+		Message: "Блокировка входа снята",
+	})
 }
 
 // ChangeUserRole
@@ -139,28 +390,23 @@ func (h *Handler) EditUser(ctx *gin.Context) {
 // @Success 200 {object} model.User
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Param id path string true "id пользователя"
 // @Router /api/v1/users/{id}/role [patch]
 // @Security BearerAuth.
 func (h *Handler) ChangeUserRole(ctx *gin.Context) {
-	role, exists := ctx.Get(userRoleKey)
-	if !exists {
-		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
-		return
-	}
-	isEmployee := role == model.RoleEmployee
-	if !isEmployee {
-		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
-		return
-	}
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
 	var userRole *model.UserRoleBody
 	if err := ctx.ShouldBindJSON(&userRole); err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
@@ -170,6 +416,17 @@ func (h *Handler) ChangeUserRole(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Роль не существует", err))
 		return
 	}
+	// Назначить можно только роль, все разрешения которой есть у самого вызывающего.
+	// Разрешения берутся из контекста, поэтому ключ API ограничен своими scopes.
+	targetPermissions, err := h.Services.Role.Permissions(userRole.Role)
+	if err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	if !middleware.HasAllPermissions(ctx, targetPermissions) {
+		middleware.HandleError(ctx, errors.NewForbiddenError("Нельзя назначить роль с правами шире собственных", nil))
+		return
+	}
 
 	user, err := h.Services.User.ChangeUserRole(id, *userRole)
 	if err != nil {
@@ -181,6 +438,10 @@ func (h *Handler) ChangeUserRole(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
 			return
 		}
+		if strings.Contains(err.Error(), "недопустимая роль") {
+			middleware.HandleError(ctx, errors.NewValidationError("Роль не существует", err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
@@ -188,40 +449,42 @@ func (h *Handler) ChangeUserRole(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, user)
 }
 
-// ChangeUserPassword
-// @Summary Изменение пароля пользователя
+// ChangeUserCustomerGroup
+// @Summary Изменение группы клиентов пользователя
+// @Description Для исключения пользователя из группы передайте customerGroupId = null
 // @Tags Users
 // @Accept			json
 // @Produce		json
-// @Param user body model.UserChangePasswordBody true "Объект с паролем пользователя"
-// @Success 200 {object} model.Success
+// @Param user body model.UserCustomerGroupBody true "Объект с группой клиентов"
+// @Success 200 {object} model.User
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Param id path string true "id пользователя"
-// @Router /api/v1/users/{id}/password [patch]
+// @Router /api/v1/users/{id}/customer-group [patch]
 // @Security BearerAuth.
-func (h *Handler) ChangeUserPassword(ctx *gin.Context) {
+func (h *Handler) ChangeUserCustomerGroup(ctx *gin.Context) {
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
-	var userPassword model.UserChangePasswordBody
-	if err := ctx.ShouldBindJSON(&userPassword); err != nil {
-		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
 		return
 	}
-	if userPassword.Password != userPassword.PasswordConfirm {
-		middleware.HandleError(ctx, errors.NewValidationError("Ошибка подтверждения пароля", err))
+	var groupReq model.UserCustomerGroupBody
+	if err := ctx.ShouldBindJSON(&groupReq); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
 		return
 	}
 
-	success, err := h.Services.User.ChangePassword(id, userPassword)
+	user, err := h.Services.User.ChangeCustomerGroup(id, groupReq)
 	if err != nil {
-		logger.GetLogger().Error("failed to change user password",
+		logger.GetLogger().Error("failed to change user customer group",
 			zap.Error(err),
 			zap.Int("user_id", id),
 		)
@@ -229,10 +492,44 @@ func (h *Handler) ChangeUserPassword(ctx *gin.Context) {
 			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
 			return
 		}
+		if strings.Contains(err.Error(), "группа клиентов не найдена") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("группа клиентов", err))
+			return
+		}
 		middleware.HandleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, success)
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// ChangeUserPassword
+// @Summary Изменение пароля пользователя
+// @Tags Users
+// @Accept			json
+// @Produce		json
+// @Param user body model.UserChangePasswordBody true "Объект с паролем пользователя"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.Error "Invalid request"
+// @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
+// @Failure 404 {object} model.Error "Not found"
+// @Failure 500 {object} model.Error "Internal"
+// @Param id path string true "id пользователя"
+// @Router /api/v1/users/{id}/password [patch]
+// @Security BearerAuth.
+func (h *Handler) ChangeUserPassword(ctx *gin.Context) {
+	idStr := ctx.Params.ByName("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
+		return
+	}
+	if err := h.checkUserChangeable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
+	h.changeUserPassword(ctx, id)
 }
 
 // UserList
@@ -263,6 +560,7 @@ func (h *Handler) ListUsers(ctx *gin.Context) {
 // @Success 200 {object} model.User
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Param id path string true "id пользователя"
@@ -275,20 +573,11 @@ func (h *Handler) GetUserByID(ctx *gin.Context) {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
-	user, err := h.Services.User.GetByID(id)
-	if err != nil {
-		logger.GetLogger().Error("failed to get user",
-			zap.Error(err),
-			zap.Int("user_id", id),
-		)
-		if strings.Contains(err.Error(), "пользователь не найден") {
-			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
-			return
-		}
-		middleware.HandleError(ctx, err)
+	if !canAccessUser(ctx, id) {
+		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
 		return
 	}
-	ctx.JSON(http.StatusOK, user)
+	h.getUser(ctx, id)
 }
 
 // DeleteUser
@@ -298,28 +587,23 @@ func (h *Handler) GetUserByID(ctx *gin.Context) {
 // @Success 200 {object} model.Success "Объект успешно удален"
 // @Failure 400 {object} model.Error "Invalid request"
 // @Failure 401 {object} model.Error "Unauthorized"
+// @Failure 403 {object} model.Error "Forbidden"
 // @Failure 404 {object} model.Error "Not found"
 // @Failure 500 {object} model.Error "Internal"
 // @Param id path string true "id пользователя"
 // @Router /api/v1/users/{id} [delete]
 // @Security BearerAuth.
 func (h *Handler) DeleteUser(ctx *gin.Context) {
-	role, exists := ctx.Get(userRoleKey)
-	if !exists {
-		middleware.HandleError(ctx, errors.NewValidationError("Некорректная роль пользователя", nil))
-		return
-	}
-	isEmployee := role == model.RoleEmployee
-	if !isEmployee {
-		middleware.HandleError(ctx, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
-		return
-	}
 	idStr := ctx.Params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		middleware.HandleError(ctx, errors.NewValidationError("Некорректный ID пользователя", err))
 		return
 	}
+	if err := h.checkUserManageable(ctx, id); err != nil {
+		middleware.HandleError(ctx, err)
+		return
+	}
 	if err := h.Services.User.Delete(id); err != nil {
 		logger.GetLogger().Error("failed to delete user",
 			zap.Error(err),
@@ -338,3 +622,116 @@ func (h *Handler) DeleteUser(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, success)
 }
+
+// canAccessUser разрешает работать с учетной записью ее владельцу и сотрудникам
+// с разрешением на управление пользователями.
+func canAccessUser(ctx *gin.Context, id int) bool {
+	return ctx.GetInt(userIDKey) == id || middleware.HasPermission(ctx, model.PermUsersManage)
+}
+
+// checkUserChangeable разрешает владельцу менять свою учетную запись, а сотруднику — чужую,
+// если он может управлять этим пользователем.
+func (h *Handler) checkUserChangeable(ctx *gin.Context, id int) error {
+	if !canAccessUser(ctx, id) {
+		return errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil)
+	}
+	if ctx.GetInt(userIDKey) == id {
+		return nil
+	}
+	return h.checkUserManageable(ctx, id)
+}
+
+// checkUserManageable не дает управлять пользователем, у роли которого есть разрешения,
+// отсутствующие у сотрудника: сменив такому пользователю email, пароль или второй фактор,
+// сотрудник мог бы войти под ним и получить более широкие права.
+func (h *Handler) checkUserManageable(ctx *gin.Context, id int) error {
+	user, err := h.Services.User.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get managed user",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		return err
+	}
+	permissions, err := h.Services.Role.Permissions(user.Role)
+	if err != nil {
+		return err
+	}
+	if !middleware.HasAllPermissions(ctx, permissions) {
+		return errors.NewForbiddenError("Нельзя управлять пользователем с правами шире собственных", nil)
+	}
+	return nil
+}
+
+func (h *Handler) editUser(ctx *gin.Context, id int) {
+	var userEdit model.UserEditBody
+	if err := ctx.ShouldBindJSON(&userEdit); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	if userEdit.Email == "" && userEdit.FirstName == "" && userEdit.LastName == "" {
+		middleware.HandleError(ctx, errors.NewValidationError(
+			"Тело запроса должно содержать хотя бы одно поле для обновления",
+			nil,
+		))
+		return
+	}
+	user, err := h.Services.User.Update(id, userEdit)
+	if err != nil {
+		logger.GetLogger().Error("failed to edit user",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) changeUserPassword(ctx *gin.Context, id int) {
+	var userPassword model.UserChangePasswordBody
+	if err := ctx.ShouldBindJSON(&userPassword); err != nil {
+		middleware.HandleError(ctx, errors.NewValidationError("Неверный формат данных", err))
+		return
+	}
+	if userPassword.Password != userPassword.PasswordConfirm {
+		middleware.HandleError(ctx, errors.NewValidationError("Ошибка подтверждения пароля", nil))
+		return
+	}
+
+	success, err := h.Services.User.ChangePassword(id, userPassword)
+	if err != nil {
+		logger.GetLogger().Error("failed to change user password",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, success)
+}
+
+func (h *Handler) getUser(ctx *gin.Context, id int) {
+	user, err := h.Services.User.GetByID(id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			middleware.HandleError(ctx, errors.NewNotFoundError("пользователь", err))
+			return
+		}
+		middleware.HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/service"
+	mock_service "github.com/mikhailshtv/stockLkBack/internal/service/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ChangeUserRole(t *testing.T) {
+	type mockBehavior func(u *mock_service.MockUser, r *mock_service.MockRole)
+
+	manager := []model.Permission{model.PermUsersManage, model.PermOrdersRead, model.PermOrdersExecute}
+	warehouse := model.NewPermissionSet([]model.Permission{model.PermOrdersRead, model.PermOrdersExecute})
+	admin := model.NewPermissionSet([]model.Permission{model.PermUsersManage, model.PermRolesManage})
+	client := model.NewPermissionSet([]model.Permission{model.PermOrdersRead})
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"role":"warehouse"}`,
+			mockBehavior: func(u *mock_service.MockUser, r *mock_service.MockRole) {
+				u.EXPECT().GetByID(2).Return(&model.User{ID: 2, Role: model.RoleClient}, nil)
+				r.EXPECT().Permissions(model.RoleClient).Return(client, nil)
+				r.EXPECT().Permissions(model.RoleWarehouse).Return(warehouse, nil)
+				u.EXPECT().ChangeUserRole(2, model.UserRoleBody{Role: model.RoleWarehouse}).Return(
+					&model.User{ID: 2, Login: "ivanov", Role: model.RoleWarehouse}, nil,
+				)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{
+				"id":2,
+				"login":"ivanov",
+				"firstName":"",
+				"lastName":"",
+				"email":"",
+				"role":"warehouse",
+				"emailVerifiedAt":null
+			}`,
+		},
+		{
+			name:      "Роль с правами шире собственных",
+			inputBody: `{"role":"admin"}`,
+			mockBehavior: func(u *mock_service.MockUser, r *mock_service.MockRole) {
+				u.EXPECT().GetByID(2).Return(&model.User{ID: 2, Role: model.RoleClient}, nil)
+				r.EXPECT().Permissions(model.RoleClient).Return(client, nil)
+				r.EXPECT().Permissions(model.RoleAdmin).Return(admin, nil)
+			},
+			expectedStatusCode: 403,
+			expectedResponseBody: `{
+				"code":403,
+				"message":"Нельзя назначить роль с правами шире собственных",
+				"type":"FORBIDDEN"
+			}`,
+		},
+		{
+			name:      "Пользователь с правами шире собственных",
+			inputBody: `{"role":"client"}`,
+			mockBehavior: func(u *mock_service.MockUser, r *mock_service.MockRole) {
+				u.EXPECT().GetByID(2).Return(&model.User{ID: 2, Role: model.RoleAdmin}, nil)
+				r.EXPECT().Permissions(model.RoleAdmin).Return(admin, nil)
+			},
+			expectedStatusCode: 403,
+			expectedResponseBody: `{
+				"code":403,
+				"message":"Нельзя управлять пользователем с правами шире собственных",
+				"type":"FORBIDDEN"
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			t.Cleanup(func() { c.Finish() })
+			users := mock_service.NewMockUser(c)
+			roles := mock_service.NewMockRole(c)
+			test.mockBehavior(users, roles)
+			handler := NewHandler(&service.Service{User: users, Role: roles})
+			r := gin.New()
+			r.PATCH("/users/:id/role", func(ctx *gin.Context) {
+				ctx.Set("userId", 1)
+				ctx.Set("role", model.RoleEmployee)
+				ctx.Set("permissions", model.NewPermissionSet(manager))
+				handler.ChangeUserRole(ctx)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/users/2/role", bytes.NewBufferString(test.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.JSONEq(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
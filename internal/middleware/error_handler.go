@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 
 	"github.com/mikhailshtv/stockLkBack/pkg/errors"
 	"github.com/mikhailshtv/stockLkBack/pkg/logger"
@@ -55,6 +57,10 @@ func HandleError(c *gin.Context, err error) {
 			zap.String("message", appErr.Message),
 			zap.Error(appErr.Internal),
 		)
+		if appErr.RetryAfter > 0 {
+			// Retry-After указывается в целых секундах, округляем вверх.
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+		}
 		c.JSON(appErr.Code, ErrorResponse{
 			Type:    string(appErr.Type),
 			Message: appErr.Message,
@@ -4,14 +4,42 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-// TokenAuthMiddleware Middleware для проверки JWT токена.
-func TokenAuthMiddleware() gin.HandlerFunc {
+// TokenRevocationChecker проверяет, не отозван ли токен до истечения срока.
+type TokenRevocationChecker interface {
+	IsTokenRevoked(claims *model.Claims) (bool, error)
+}
+
+// APIKeyAuthenticator проверяет ключ API интеграции.
+type APIKeyAuthenticator interface {
+	Authenticate(key string) (*model.APIKey, error)
+}
+
+// APIKeyHeader заголовок, в котором интеграции передают ключ API вместо JWT.
+const APIKeyHeader = "X-API-Key"
+
+// TokenAuthMiddleware Middleware для проверки JWT токена или ключа API.
+// Кроме пользователя в контекст запроса добавляются разрешения его роли,
+// а для ключа API — только те из них, что выданы ключу.
+func TokenAuthMiddleware(
+	revocations TokenRevocationChecker,
+	roles RolePermissions,
+	apiKeys APIKeyAuthenticator,
+) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			apiKeyAuth(c, key, apiKeys)
+			return
+		}
+
 		// Получаем токен из заголовка Authorization
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
@@ -33,10 +61,86 @@ func TokenAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Проверяем, не отозван ли токен при выходе или смене роли и пароля
+		revoked, err := revocations.IsTokenRevoked(claims)
+		if err != nil {
+			logger.GetLogger().Error("failed to check token revocation",
+				zap.Error(err),
+				zap.Int("user_id", claims.UserID),
+			)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "token revocation check failed"})
+			c.Abort()
+
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": jwtgen.ErrRevokedToken.Error()})
+			c.Abort()
+
+			return
+		}
+
+		permissions, err := roles.Permissions(claims.Role)
+		if err != nil {
+			logger.GetLogger().Error("failed to get role permissions",
+				zap.Error(err),
+				zap.String("role", string(claims.Role)),
+			)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "role permissions check failed"})
+			c.Abort()
+
+			return
+		}
+
 		// Если токен валиден, добавляем пользователя в контекст запроса
 		c.Set("login", claims.Login)
 		c.Set("role", claims.Role)
 		c.Set("userId", claims.UserID)
+		c.Set("claims", claims)
+		c.Set(permissionsKey, permissions)
+
+		c.Next()
+	}
+}
+
+func apiKeyAuth(c *gin.Context, key string, apiKeys APIKeyAuthenticator) {
+	apiKey, err := apiKeys.Authenticate(key)
+	if err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Type == errors.ErrorTypeUnauthorized {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": appErr.Message})
+			c.Abort()
+
+			return
+		}
+		logger.GetLogger().Error("failed to check api key",
+			zap.Error(err),
+		)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "api key check failed"})
+		c.Abort()
+
+		return
+	}
+
+	c.Set("login", apiKey.Login)
+	c.Set("role", apiKey.Role)
+	c.Set("userId", apiKey.UserID)
+	c.Set("apiKey", apiKey)
+	c.Set(permissionsKey, apiKey.Permissions)
+
+	c.Next()
+}
+
+// RequireUserSession пропускает только запросы с JWT пользователя. Ставится после
+// TokenAuthMiddleware на маршрутах без отдельного разрешения — профиль, корзина, адреса
+// и другие действия от лица самого пользователя: ключу API они недоступны при любых scopes.
+func RequireUserSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("apiKey"); ok {
+			HandleError(c, errors.NewForbiddenError("Ключ API не дает доступа к этому маршруту", nil))
+			c.Abort()
+
+			return
+		}
 
 		c.Next()
 	}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const permissionsKey = "permissions"
+
+// RolePermissions возвращает разрешения роли пользователя.
+type RolePermissions interface {
+	Permissions(role model.UserRole) (model.PermissionSet, error)
+}
+
+// RequirePermission пропускает запрос, только если у роли пользователя есть разрешение.
+// Ставится в маршруте после TokenAuthMiddleware.
+func RequirePermission(permission model.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			HandleError(c, errors.NewForbiddenError("Недостаточно прав для выполнения операции", nil))
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// HasPermission проверяет разрешение пользователя внутри обработчика, когда
+// право нужно не на весь маршрут, а на отдельное действие.
+func HasPermission(c *gin.Context, permission model.Permission) bool {
+	value, exists := c.Get(permissionsKey)
+	if !exists {
+		return false
+	}
+	permissions, ok := value.(model.PermissionSet)
+	return ok && permissions.Has(permission)
+}
+
+// HasAllPermissions проверяет, что у пользователя есть все разрешения набора.
+func HasAllPermissions(c *gin.Context, required model.PermissionSet) bool {
+	value, exists := c.Get(permissionsKey)
+	if !exists {
+		return false
+	}
+	permissions, ok := value.(model.PermissionSet)
+	return ok && permissions.Contains(required)
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions any
+		wantCode    int
+	}{
+		{
+			name:        "permission granted",
+			permissions: model.NewPermissionSet([]model.Permission{model.PermOrdersRead, model.PermOrdersExecute}),
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "permission missing",
+			permissions: model.NewPermissionSet([]model.Permission{model.PermOrdersRead, model.PermOrdersWrite}),
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:     "no permissions in context",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:        "unexpected permissions type",
+			permissions: []model.Permission{model.PermOrdersExecute},
+			wantCode:    http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.PATCH("/orders/:id", func(c *gin.Context) {
+				if tt.permissions != nil {
+					c.Set(permissionsKey, tt.permissions)
+				}
+			}, RequirePermission(model.PermOrdersExecute), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/orders/1", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Код ответа = %d, want %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHasAllPermissions(t *testing.T) {
+	own := model.NewPermissionSet([]model.Permission{model.PermUsersManage, model.PermOrdersRead})
+
+	tests := []struct {
+		name     string
+		required model.PermissionSet
+		want     bool
+	}{
+		{
+			name:     "subset",
+			required: model.NewPermissionSet([]model.Permission{model.PermOrdersRead}),
+			want:     true,
+		},
+		{
+			name:     "same permissions",
+			required: own,
+			want:     true,
+		},
+		{
+			name:     "role without permissions",
+			required: model.PermissionSet{},
+			want:     true,
+		},
+		{
+			name:     "wider permissions",
+			required: model.NewPermissionSet([]model.Permission{model.PermOrdersRead, model.PermRolesManage}),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Set(permissionsKey, own)
+			if got := HasAllPermissions(c, tt.required); got != tt.want {
+				t.Errorf("HasAllPermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// APIKey ключ API для интеграций. Ключ действует от имени пользователя UserID, но только
+// в пределах Scopes: разрешения роли пользователя, которых нет в Scopes, ключу недоступны.
+type APIKey struct {
+	ID          int          `json:"id" db:"id"`
+	Name        string       `json:"name" db:"name"`
+	Prefix      string       `json:"prefix" db:"prefix"`
+	KeyHash     string       `json:"-" db:"key_hash"`
+	UserID      int          `json:"userId" db:"user_id"`
+	Login       string       `json:"login" db:"login"`
+	Role        UserRole     `json:"role" db:"role"`
+	Scopes      []Permission `json:"scopes" db:"-"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty" db:"expires_at"`
+	LastUsedAt  *time.Time   `json:"lastUsedAt,omitempty" db:"last_used_at"`
+	CreatedBy   *int         `json:"createdBy,omitempty" db:"created_by"`
+	CreatedDate time.Time    `json:"createdDate" db:"created_date"`
+	// Permissions действующие разрешения ключа: Scopes, которые есть у роли владельца.
+	// Заполняется при проверке предъявленного ключа.
+	Permissions PermissionSet `json:"-" db:"-"`
+}
+
+// Expired возвращает true, если срок действия ключа истек.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+type APIKeyRequestBody struct {
+	Name      string       `json:"name" binding:"required"`
+	UserID    int          `json:"userId" binding:"required"`
+	Scopes    []Permission `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time   `json:"expiresAt"`
+}
+
+// APIKeyCreated выпущенный ключ. Сам ключ показывается только один раз, при создании.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
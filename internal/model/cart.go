@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cart корзина клиента. Цены и остатки в ней не фиксируются, а берутся актуальными
+// на момент запроса; фиксируются они только при оформлении заказа.
+type Cart struct {
+	Items    []CartItem  `json:"items"`
+	Subtotal int64       `json:"subtotal"` // Сумма доступных строк по текущим ценам без скидок
+	Currency string      `json:"currency,omitempty"`
+	Issues   []CartIssue `json:"issues,omitempty"` // Причины, по которым корзину нельзя оформить
+}
+
+// CartItem строка корзины. Количество хранится в базовых единицах измерения товара.
+type CartItem struct {
+	ProductID int       `json:"productId" db:"product_id"`
+	Code      int32     `json:"code" db:"code"`
+	Name      string    `json:"name" db:"name"`
+	Unit      string    `json:"unit" db:"unit_code"`
+	Quantity  Quantity  `json:"quantity" db:"quantity"`
+	Price     int64     `json:"price" db:"price"` // Текущая цена по прайс-листу клиента
+	Currency  string    `json:"currency" db:"currency"`
+	LineCost  int64     `json:"lineCost" db:"line_cost"`
+	Available Quantity  `json:"available" db:"available"` // Текущий остаток на складе
+	Deleted   bool      `json:"-" db:"deleted"`           // Товар удален из каталога
+	AddedDate time.Time `json:"addedDate" db:"added_date"`
+}
+
+// CartIssue проблема строки корзины. Для проблем корзины целиком ProductID не заполняется.
+type CartIssue struct {
+	ProductID int    `json:"productId,omitempty"`
+	Message   string `json:"message"`
+}
+
+// CartItemRequest тело запроса на добавление товара в корзину или изменение его количества.
+// При изменении количества ProductID берется из пути запроса.
+type CartItemRequest struct {
+	ProductID int      `json:"productId"`
+	Quantity  Quantity `json:"quantity" swaggertype:"number"`
+	// Unit единица измерения количества: базовая единица товара (по умолчанию) или его упаковка.
+	Unit string `json:"unit,omitempty"`
+}
+
+// CartCheckoutBody параметры заказа, оформляемого из корзины.
+type CartCheckoutBody struct {
+	PromoCode        string   `json:"promoCode,omitempty"`
+	TaxMode          *TaxMode `json:"taxMode,omitempty"`
+	AddressID        *int     `json:"addressId,omitempty"`
+	ShippingMethodID *int     `json:"shippingMethodId,omitempty"`
+}
+
+// Check проверяет, можно ли оформить корзину, заполняет Issues и пересчитывает сумму доступных строк.
+func (c *Cart) Check() {
+	c.Issues = []CartIssue{}
+	c.Subtotal = 0
+	c.Currency = ""
+
+	currencies := map[string]bool{}
+	for _, item := range c.Items {
+		switch {
+		case item.Deleted:
+			c.Issues = append(c.Issues, CartIssue{
+				ProductID: item.ProductID,
+				Message:   fmt.Sprintf("товар %d больше не продается", item.ProductID),
+			})
+		case item.Available <= 0:
+			c.Issues = append(c.Issues, CartIssue{
+				ProductID: item.ProductID,
+				Message:   fmt.Sprintf("товара «%s» нет в наличии", item.Name),
+			})
+		case item.Available < item.Quantity:
+			c.Issues = append(c.Issues, CartIssue{
+				ProductID: item.ProductID,
+				Message: fmt.Sprintf("товара «%s» недостаточно: в корзине %s %s, доступно %s",
+					item.Name, item.Quantity, item.Unit, item.Available),
+			})
+		default:
+			c.Subtotal += item.LineCost
+			currencies[item.Currency] = true
+		}
+	}
+
+	switch len(currencies) {
+	case 0:
+	case 1:
+		for currency := range currencies {
+			c.Currency = currency
+		}
+	default:
+		codes := make([]string, 0, len(currencies))
+		for currency := range currencies {
+			codes = append(codes, currency)
+		}
+		sort.Strings(codes)
+		c.Subtotal = 0
+		c.Issues = append(c.Issues, CartIssue{
+			Message: "корзина содержит товары в разных валютах: " + strings.Join(codes, ", "),
+		})
+	}
+}
+
+// Valid сообщает, можно ли оформить корзину в заказ.
+func (c Cart) Valid() bool {
+	return len(c.Items) > 0 && len(c.Issues) == 0
+}
+
+// OrderRequest собирает тело запроса на создание заказа из строк корзины.
+func (c Cart) OrderRequest(checkout CartCheckoutBody) OrderRequestBody {
+	products := make([]OrderProduct, 0, len(c.Items))
+	for _, item := range c.Items {
+		products = append(products, OrderProduct{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return OrderRequestBody{
+		Products:         products,
+		PromoCode:        checkout.PromoCode,
+		TaxMode:          checkout.TaxMode,
+		AddressID:        checkout.AddressID,
+		ShippingMethodID: checkout.ShippingMethodID,
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// OrderComment комментарий к заказу. Внутренние заметки (Internal) видны только сотрудникам.
+type OrderComment struct {
+	ID          int                 `json:"id" db:"id"`
+	OrderID     int                 `json:"orderId" db:"order_id"`
+	ParentID    *int                `json:"parentId,omitempty" db:"parent_id"` // Комментарий, на который дан ответ
+	AuthorID    *int                `json:"authorId,omitempty" db:"author_id"` // Пусто, если автор удален
+	AuthorName  string              `json:"authorName" db:"author_name"`
+	AuthorRole  *UserRole           `json:"authorRole,omitempty" db:"author_role"`
+	Body        string              `json:"body" db:"body"`
+	Internal    bool                `json:"internal" db:"internal"`
+	CreatedDate time.Time           `json:"createdDate" db:"created_date"`
+	Attachments []CommentAttachment `json:"attachments" db:"-"`
+	Replies     []OrderComment      `json:"replies" db:"-"`
+}
+
+// CommentAttachment метаданные вложения комментария. Сам файл хранится во внешнем хранилище по URL.
+type CommentAttachment struct {
+	ID          int    `json:"id" db:"id"`
+	CommentID   int    `json:"-" db:"comment_id"`
+	FileName    string `json:"fileName" db:"file_name"`
+	ContentType string `json:"contentType" db:"content_type"`
+	Size        int64  `json:"size" db:"size"` // Размер файла в байтах
+	URL         string `json:"url" db:"url"`
+}
+
+type OrderCommentRequestBody struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID *int   `json:"parentId,omitempty"`
+	// Internal заметка только для сотрудников, клиент оставлять такие заметки не может.
+	Internal    bool                       `json:"internal"`
+	Attachments []CommentAttachmentRequest `json:"attachments,omitempty"`
+}
+
+type CommentAttachmentRequest struct {
+	FileName    string `json:"fileName" binding:"required"`
+	ContentType string `json:"contentType" example:"image/png"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url" binding:"required"`
+}
+
+// BuildCommentThreads собирает плоский список комментариев в дерево ответов.
+// Ответы на комментарии, которых нет в списке, поднимаются на верхний уровень.
+func BuildCommentThreads(comments []OrderComment) []OrderComment {
+	children := make(map[int][]int, len(comments))
+	known := make(map[int]bool, len(comments))
+	for _, comment := range comments {
+		known[comment.ID] = true
+	}
+	roots := make([]int, 0, len(comments))
+	for i, comment := range comments {
+		if comment.ParentID != nil && known[*comment.ParentID] {
+			children[*comment.ParentID] = append(children[*comment.ParentID], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	var build func(i int) OrderComment
+	build = func(i int) OrderComment {
+		comment := comments[i]
+		comment.Replies = make([]OrderComment, 0, len(children[comment.ID]))
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, build(child))
+		}
+		return comment
+	}
+
+	threads := make([]OrderComment, 0, len(roots))
+	for _, i := range roots {
+		threads = append(threads, build(i))
+	}
+	return threads
+}
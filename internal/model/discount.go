@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "percent"
+	DiscountFixed   DiscountType = "fixed"
+)
+
+func (t DiscountType) Valid() bool {
+	switch t {
+	case DiscountPercent, DiscountFixed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateDiscount проверяет, что тип и размер скидки заданы вместе и корректны.
+func ValidateDiscount(discountType *DiscountType, discountValue *int) error {
+	if discountType == nil && discountValue == nil {
+		return nil
+	}
+	if discountType == nil || discountValue == nil {
+		return fmt.Errorf("для скидки необходимо указать тип и размер")
+	}
+	if !discountType.Valid() {
+		return fmt.Errorf("неизвестный тип скидки: %s", *discountType)
+	}
+	if *discountValue <= 0 {
+		return fmt.Errorf("размер скидки должен быть положительным")
+	}
+	if *discountType == DiscountPercent && *discountValue > 100 {
+		return fmt.Errorf("скидка в процентах не может превышать 100")
+	}
+	return nil
+}
+
+type PromoCode struct {
+	ID             int          `json:"id" db:"id"`
+	Code           string       `json:"code" db:"code"`
+	DiscountType   DiscountType `json:"discountType" db:"discount_type"`
+	DiscountValue  int          `json:"discountValue" db:"discount_value"`
+	Currency       string       `json:"currency" db:"currency"` // Валюта фиксированной скидки
+	MaxUses        *int         `json:"maxUses,omitempty" db:"max_uses"`
+	MaxUsesPerUser *int         `json:"maxUsesPerUser,omitempty" db:"max_uses_per_user"`
+	UsedCount      int          `json:"usedCount" db:"used_count"`
+	ValidFrom      *time.Time   `json:"validFrom,omitempty" db:"valid_from"`
+	ValidTo        *time.Time   `json:"validTo,omitempty" db:"valid_to"`
+	Active         bool         `json:"active" db:"active"`
+	CreatedDate    time.Time    `json:"createdDate" db:"created_date"`
+}
+
+type PromoCodeRequestBody struct {
+	Code           string       `json:"code" binding:"required"`
+	DiscountType   DiscountType `json:"discountType" binding:"required"`
+	DiscountValue  int          `json:"discountValue" binding:"required"`
+	Currency       string       `json:"currency,omitempty"`
+	MaxUses        *int         `json:"maxUses,omitempty"`
+	MaxUsesPerUser *int         `json:"maxUsesPerUser,omitempty"`
+	ValidFrom      *time.Time   `json:"validFrom,omitempty"`
+	ValidTo        *time.Time   `json:"validTo,omitempty"`
+	Active         *bool        `json:"active,omitempty"`
+}
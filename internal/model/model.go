@@ -5,9 +5,18 @@ type Success struct {
 	Message string `json:"message"`
 }
 
+// TokenSuccess результат входа. Если нужен второй фактор, токены не выдаются:
+// вместо них возвращается ChallengeToken для второго шага входа.
 type TokenSuccess struct {
-	Message string `json:"message"`
-	Token   string `json:"token"`
+	Message      string `json:"message"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	ExpiresIn    int    `json:"expiresIn,omitempty"` // срок действия token в секундах
+
+	TwoFactorRequired      bool     `json:"twoFactorRequired,omitempty"`
+	TwoFactorSetupRequired bool     `json:"twoFactorSetupRequired,omitempty"`
+	ChallengeToken         string   `json:"challengeToken,omitempty"`
+	RecoveryCodes          []string `json:"recoveryCodes,omitempty"`
 }
 
 type Error struct {
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyMinorUnits количество знаков минимальной денежной единицы для валют,
+// у которых оно отличается от 2 (ISO 4217).
+var currencyMinorUnits = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// Money денежная сумма в минимальных единицах валюты (копейках, центах) с кодом валюты ISO 4217.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney создает сумму в минимальных единицах валюты.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// NormalizeCurrency приводит код валюты к верхнему регистру и проверяет формат ISO 4217.
+// Пустой код заменяется валютой по умолчанию.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	if len(code) != 3 {
+		return "", fmt.Errorf("код валюты должен состоять из 3 букв (ISO 4217)")
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("код валюты должен состоять из 3 букв (ISO 4217)")
+		}
+	}
+	return code, nil
+}
+
+// MinorUnits возвращает количество знаков после запятой для валюты.
+func MinorUnits(currency string) int {
+	if digits, ok := currencyMinorUnits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Add складывает суммы одной валюты.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("нельзя складывать суммы в разных валютах: %s и %s", m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("переполнение суммы")
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub вычитает сумму той же валюты.
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// FormatAmount возвращает сумму в основных единицах валюты без кода: 123456 RUB -> "1234.56".
+func (m Money) FormatAmount() string {
+	digits := MinorUnits(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if digits == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+	factor := int64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/factor, digits, amount%factor)
+}
+
+// String возвращает сумму с кодом валюты: "1234.56 RUB".
+func (m Money) String() string {
+	return m.FormatAmount() + " " + m.Currency
+}
+
+// Int32 возвращает сумму для API, которые передают ее как int32, с проверкой переполнения.
+func (m Money) Int32() (int32, error) {
+	if m.Amount > math.MaxInt32 || m.Amount < math.MinInt32 {
+		return 0, fmt.Errorf("сумма %s выходит за допустимый диапазон", m)
+	}
+	return int32(m.Amount), nil
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMoney_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Money
+		other   Money
+		want    Money
+		wantErr bool
+	}{
+		{name: "same currency", m: NewMoney(150, "RUB"), other: NewMoney(250, "RUB"), want: NewMoney(400, "RUB")},
+		{name: "negative", m: NewMoney(100, "RUB"), other: NewMoney(-300, "RUB"), want: NewMoney(-200, "RUB")},
+		{name: "different currencies", m: NewMoney(100, "RUB"), other: NewMoney(100, "USD"), wantErr: true},
+		{name: "overflow", m: NewMoney(math.MaxInt64, "RUB"), other: NewMoney(1, "RUB"), wantErr: true},
+		{name: "negative overflow", m: NewMoney(math.MinInt64, "RUB"), other: NewMoney(-1, "RUB"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Add(tt.other)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Sub(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Money
+		other   Money
+		want    Money
+		wantErr bool
+	}{
+		{name: "same currency", m: NewMoney(500, "RUB"), other: NewMoney(120, "RUB"), want: NewMoney(380, "RUB")},
+		{name: "different currencies", m: NewMoney(500, "RUB"), other: NewMoney(120, "EUR"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Sub(tt.other)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sub() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		want string
+	}{
+		{name: "kopecks", m: NewMoney(123456, "RUB"), want: "1234.56 RUB"},
+		{name: "leading zero in minor units", m: NewMoney(105, "USD"), want: "1.05 USD"},
+		{name: "negative", m: NewMoney(-5, "RUB"), want: "-0.05 RUB"},
+		{name: "currency without minor units", m: NewMoney(1500, "JPY"), want: "1500 JPY"},
+		{name: "three minor digits", m: NewMoney(1234, "KWD"), want: "1.234 KWD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
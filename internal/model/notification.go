@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// NotificationKind тип уведомления пользователя.
+type NotificationKind string
+
+const (
+	// NotificationRecurringOrderFailed регулярный заказ не удалось оформить.
+	NotificationRecurringOrderFailed NotificationKind = "recurring_order_failed"
+)
+
+// Notification уведомление пользователя в личном кабинете.
+type Notification struct {
+	ID          int              `json:"id" db:"id"`
+	UserID      int              `json:"-" db:"user_id"`
+	Kind        NotificationKind `json:"kind" db:"kind"`
+	Message     string           `json:"message" db:"message"`
+	Read        bool             `json:"read" db:"read"`
+	CreatedDate time.Time        `json:"createdDate" db:"created_date"`
+}
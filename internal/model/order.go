@@ -32,18 +32,50 @@ var (
 // сделал конкретные int32 из-за protobuf и линтера (gosec).
 
 type Order struct {
-	ID               int         `json:"id" bson:"_id,omitempty" db:"id"`
-	Number           int         `json:"number" bson:"number" db:"order_number"`
-	TotalCost        int         `json:"totalCost" bson:"totalCost" db:"total_cost"`
-	CreatedDate      time.Time   `json:"createdDate" bson:"createdDate" db:"created_date"`
-	LastModifiedDate time.Time   `json:"lastModifiedDate" bson:"lastModifiedDate" db:"last_modified_date"`
-	Status           OrderStatus `json:"status" bson:"status" db:"status"`
-	Products         []Product   `json:"products" binding:"required" bson:"products" db:"-"`
-	UserID           int         `json:"userId" db:"user_id"`
+	ID               int                `json:"id" bson:"_id,omitempty" db:"id"`
+	Number           int                `json:"number" bson:"number" db:"order_number"`
+	Subtotal         int64              `json:"subtotal" db:"subtotal"`            // Сумма без скидок
+	DiscountTotal    int64              `json:"discountTotal" db:"discount_total"` // Сумма скидок по строкам и на заказ
+	TotalCost        int64              `json:"totalCost" bson:"totalCost" db:"total_cost"`
+	Currency         string             `json:"currency" db:"currency"` // Все суммы заказа в одной валюте
+	TaxMode          TaxMode            `json:"taxMode" db:"tax_mode"`
+	TaxTotal         int64              `json:"taxTotal" db:"tax_total"` // Сумма НДС; при начислении сверх цены входит в TotalCost
+	TaxBreakdown     []TaxBreakdownItem `json:"taxBreakdown,omitempty" db:"-"`
+	DiscountType     *DiscountType      `json:"discountType,omitempty" db:"discount_type"`
+	DiscountValue    *int               `json:"discountValue,omitempty" db:"discount_value"`
+	PromoCodeID      *int               `json:"promoCodeId,omitempty" db:"promo_code_id"`
+	ShippingMethodID *int               `json:"shippingMethodId,omitempty" db:"shipping_method_id"`
+	ShippingCost     int64              `json:"shippingCost" db:"shipping_cost"` // Входит в TotalCost
+	AddressID        *int               `json:"addressId,omitempty" db:"address_id"`
+	ShippingAddress  *string            `json:"shippingAddress,omitempty" db:"shipping_address"` // Копия адреса на момент выбора
+	Carrier          *string            `json:"carrier,omitempty" db:"carrier"`
+	TrackingNumber   *string            `json:"trackingNumber,omitempty" db:"tracking_number"`
+	ShippedDate      *time.Time         `json:"shippedDate,omitempty" db:"shipped_date"`
+	CreatedDate      time.Time          `json:"createdDate" bson:"createdDate" db:"created_date"`
+	LastModifiedDate time.Time          `json:"lastModifiedDate" bson:"lastModifiedDate" db:"last_modified_date"`
+	Status           OrderStatus        `json:"status" bson:"status" db:"status"`
+	Products         []Product          `json:"products" binding:"required" bson:"products" db:"-"`
+	UserID           int                `json:"userId" db:"user_id"`
 }
 
+// Total возвращает итоговую сумму заказа с валютой.
+func (o Order) Total() Money {
+	return NewMoney(o.TotalCost, o.Currency)
+}
+
+// OrderRequestBody тело запроса на создание или изменение заказа.
+// Скидку на заказ задает либо промокод клиента, либо сотрудник вручную (DiscountType и DiscountValue).
+// UserID указывает сотрудник при оформлении заказа на клиента; по умолчанию владелец заказа — автор запроса.
 type OrderRequestBody struct {
-	Products []OrderProduct `json:"products" binding:"required" bson:"products"`
+	UserID        *int           `json:"userId,omitempty"`
+	Products      []OrderProduct `json:"products" binding:"required" bson:"products"`
+	PromoCode     string         `json:"promoCode,omitempty"`
+	DiscountType  *DiscountType  `json:"discountType,omitempty"`
+	DiscountValue *int           `json:"discountValue,omitempty"`
+	TaxMode       *TaxMode       `json:"taxMode,omitempty"`
+	// AddressID адрес из адресной книги владельца заказа, ShippingMethodID — способ доставки.
+	AddressID        *int `json:"addressId,omitempty"`
+	ShippingMethodID *int `json:"shippingMethodId,omitempty"`
 }
 
 type OrderStatusRequest struct {
@@ -51,9 +83,26 @@ type OrderStatusRequest struct {
 }
 
 type OrderProduct struct {
-	ProductID int `json:"productId" bindings:"required" db:"product_id"`
-	Quantity  int `json:"quantity" bindings:"required" db:"quantity"`    // Количество покупаемых товаров
-	SellPrice int `json:"sellPrice" bindings:"required" db:"sell_price"` // Цена товара на момент создания заказа
+	ProductID int      `json:"productId" bindings:"required" db:"product_id"`
+	Quantity  Quantity `json:"quantity" bindings:"required" db:"quantity"` // Количество покупаемых товаров
+	// Unit единица измерения количества: базовая единица товара (по умолчанию) или его упаковка.
+	Unit          string        `json:"unit,omitempty" db:"-"`
+	SellPrice     int64         `json:"sellPrice" db:"sell_price"`                 // Цена на момент создания заказа (по прайс-листу)
+	DiscountType  *DiscountType `json:"discountType,omitempty" db:"discount_type"` // Скидка на строку, задается сотрудником
+	DiscountValue *int          `json:"discountValue,omitempty" db:"discount_value"`
+}
+
+// HasManualDiscount сообщает, задана ли в запросе скидка, которую может назначить только сотрудник.
+func (r OrderRequestBody) HasManualDiscount() bool {
+	if r.DiscountType != nil || r.DiscountValue != nil {
+		return true
+	}
+	for _, p := range r.Products {
+		if p.DiscountType != nil || p.DiscountValue != nil {
+			return true
+		}
+	}
+	return false
 }
 
 func (os *OrderStatus) Scan(value interface{}) error {
@@ -0,0 +1,109 @@
+package model
+
+// Permission разрешение на группу операций. Роль пользователя — набор разрешений.
+type Permission string
+
+const (
+	// PermOrdersRead просмотр своих заказов.
+	PermOrdersRead Permission = "orders:read"
+	// PermOrdersReadAll доступ к заказам всех клиентов, их документам, комментариям
+	// и повторяющимся заказам, в том числе к внутренним комментариям.
+	PermOrdersReadAll Permission = "orders:read_all"
+	// PermOrdersWrite создание, изменение и удаление заказов.
+	PermOrdersWrite Permission = "orders:write"
+	// PermOrdersExecute выполнение заказов: отправка и трек-номер.
+	PermOrdersExecute Permission = "orders:execute"
+	// PermOrdersDiscount назначение ручных скидок в заказе.
+	PermOrdersDiscount Permission = "orders:discount"
+	// PermProductsWrite ведение каталога, остатков и цен товаров.
+	PermProductsWrite Permission = "products:write"
+	// PermPricingManage прайс-листы, группы клиентов и промокоды.
+	PermPricingManage Permission = "pricing:manage"
+	// PermShippingManage способы доставки.
+	PermShippingManage Permission = "shipping:manage"
+	// PermReturnsDecide решение по заявкам на возврат.
+	PermReturnsDecide Permission = "returns:decide"
+	// PermUsersManage управление чужими учетными записями и их сессиями.
+	PermUsersManage Permission = "users:manage"
+	// PermRolesManage управление ролями и их разрешениями.
+	PermRolesManage Permission = "roles:manage"
+	// PermAPIKeysManage выпуск и отзыв ключей API для интеграций.
+	PermAPIKeysManage Permission = "api_keys:manage"
+)
+
+// AllPermissions все разрешения, известные приложению.
+var AllPermissions = []Permission{
+	PermOrdersRead,
+	PermOrdersReadAll,
+	PermOrdersWrite,
+	PermOrdersExecute,
+	PermOrdersDiscount,
+	PermProductsWrite,
+	PermPricingManage,
+	PermShippingManage,
+	PermReturnsDecide,
+	PermUsersManage,
+	PermRolesManage,
+	PermAPIKeysManage,
+}
+
+func (p Permission) Valid() bool {
+	for _, permission := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet разрешения роли для быстрой проверки.
+type PermissionSet map[Permission]struct{}
+
+func NewPermissionSet(permissions []Permission) PermissionSet {
+	set := make(PermissionSet, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = struct{}{}
+	}
+	return set
+}
+
+func (s PermissionSet) Has(permission Permission) bool {
+	_, ok := s[permission]
+	return ok
+}
+
+// Contains возвращает true, если в наборе есть все разрешения other.
+func (s PermissionSet) Contains(other PermissionSet) bool {
+	for permission := range other {
+		if !s.Has(permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect возвращает разрешения, которые есть и в s, и в other.
+func (s PermissionSet) Intersect(other PermissionSet) PermissionSet {
+	set := make(PermissionSet)
+	for permission := range other {
+		if s.Has(permission) {
+			set[permission] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Role роль пользователя. Системные роли создаются миграцией и не удаляются,
+// разрешения роли admin не изменяются.
+type Role struct {
+	Key         UserRole     `json:"key" db:"key"`
+	Name        string       `json:"name" db:"name"`
+	System      bool         `json:"system" db:"system"`
+	Permissions []Permission `json:"permissions" db:"-"`
+}
+
+type RoleRequestBody struct {
+	Key         UserRole     `json:"key"`
+	Name        string       `json:"name" binding:"required"`
+	Permissions []Permission `json:"permissions"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+const DefaultCurrency = "RUB"
+
+type PriceList struct {
+	ID          int                   `json:"id" db:"id"`
+	Name        string                `json:"name" db:"name"`
+	Currency    string                `json:"currency" db:"currency"`
+	ValidFrom   *time.Time            `json:"validFrom,omitempty" db:"valid_from"`
+	ValidTo     *time.Time            `json:"validTo,omitempty" db:"valid_to"`
+	Priority    int                   `json:"priority" db:"priority"`
+	CreatedDate time.Time             `json:"createdDate" db:"created_date"`
+	Items       []PriceListItem       `json:"items" db:"-"`
+	Assignments []PriceListAssignment `json:"assignments" db:"-"`
+}
+
+type PriceListItem struct {
+	ProductID int   `json:"productId" db:"product_id"`
+	Price     int64 `json:"price" db:"price"`
+}
+
+type PriceListRequestBody struct {
+	Name      string          `json:"name" binding:"required"`
+	Currency  string          `json:"currency"`
+	ValidFrom *time.Time      `json:"validFrom,omitempty"`
+	ValidTo   *time.Time      `json:"validTo,omitempty"`
+	Priority  int             `json:"priority"`
+	Items     []PriceListItem `json:"items"`
+}
+
+// PriceListAssignment назначение прайс-листа пользователю либо группе клиентов (заполнено ровно одно поле).
+type PriceListAssignment struct {
+	ID              int  `json:"id" db:"id"`
+	PriceListID     int  `json:"priceListId" db:"price_list_id"`
+	UserID          *int `json:"userId,omitempty" db:"user_id"`
+	CustomerGroupID *int `json:"customerGroupId,omitempty" db:"customer_group_id"`
+}
+
+type PriceListAssignmentBody struct {
+	UserID          *int `json:"userId,omitempty"`
+	CustomerGroupID *int `json:"customerGroupId,omitempty"`
+}
+
+type CustomerGroup struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
+type CustomerGroupRequestBody struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type UserCustomerGroupBody struct {
+	CustomerGroupID *int `json:"customerGroupId"`
+}
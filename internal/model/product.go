@@ -1,21 +1,73 @@
 package model
 
+import "time"
+
 type Product struct {
-	ID            int32  `json:"id" db:"id"`
-	Code          int32  `json:"code" db:"code"`
-	Quantity      int32  `json:"quantity" db:"quantity"`
-	Name          string `json:"name" db:"name"`
-	PurchasePrice int32  `json:"purchasePrice,omitempty" db:"purchase_price"`
-	SellPrice     int32  `json:"sellPrice" db:"sell_price"`
-	Version       int    `json:"-" db:"version"`
+	ID            int32    `json:"id" db:"id"`
+	Code          int32    `json:"code" db:"code"`
+	Quantity      Quantity `json:"quantity" db:"quantity"` // Остаток в базовых единицах измерения
+	Name          string   `json:"name" db:"name"`
+	PurchasePrice int64    `json:"purchasePrice,omitempty" db:"purchase_price"` // В минимальных единицах валюты
+	SellPrice     int64    `json:"sellPrice" db:"sell_price"`
+	Currency      string   `json:"currency,omitempty" db:"currency"`
+	Version       int      `json:"-" db:"version"`
+	// UnitCode базовая единица измерения, PackUnitCode и PackSize — упаковка и число базовых единиц в ней.
+	UnitCode     string    `json:"unit,omitempty" db:"unit_code"`
+	PackUnitCode *string   `json:"packUnit,omitempty" db:"pack_unit_code"`
+	PackSize     *Quantity `json:"packSize,omitempty" db:"pack_size"`
+	VATRate      *int      `json:"vatRate,omitempty" db:"vat_rate"` // Ставка НДС, %
+	Weight       *int      `json:"weight,omitempty" db:"weight"`    // Вес базовой единицы товара, г
+	// DiscountAmount, TaxBase и TaxAmount заполняются только для товаров в составе заказа.
+	DiscountAmount int64 `json:"discountAmount,omitempty" db:"discount_amount"`
+	TaxBase        int64 `json:"taxBase,omitempty" db:"tax_base"`
+	TaxAmount      int64 `json:"taxAmount,omitempty" db:"tax_amount"`
+	// Для комплекта остаток вычисляется по остаткам компонентов.
+	IsBundle            bool              `json:"isBundle,omitempty" db:"is_bundle"`
+	BundlePricing       *BundlePricing    `json:"bundlePricing,omitempty" db:"bundle_pricing"`
+	BundleDiscountType  *DiscountType     `json:"bundleDiscountType,omitempty" db:"bundle_discount_type"`
+	BundleDiscountValue *int              `json:"bundleDiscountValue,omitempty" db:"bundle_discount_value"`
+	Components          []BundleComponent `json:"components,omitempty" db:"-"`
+}
+
+// SellMoney возвращает цену продажи с валютой товара.
+func (p Product) SellMoney() Money {
+	return NewMoney(p.SellPrice, p.Currency)
 }
 
 type ProductRequestBody struct {
-	Code          int32  `json:"code" db:"code"`
-	Quantity      int32  `json:"quantity" db:"quantity"`
-	Name          string `json:"name" db:"name"`
-	PurchasePrice int32  `json:"purchasePrice" db:"purchase_price"`
-	SellPrice     int32  `json:"sellPrice" db:"sell_price"`
+	Code                int32             `json:"code" db:"code"`
+	Quantity            Quantity          `json:"quantity" db:"quantity" swaggertype:"number"`
+	Name                string            `json:"name" db:"name"`
+	PurchasePrice       int64             `json:"purchasePrice" db:"purchase_price"`
+	SellPrice           int64             `json:"sellPrice" db:"sell_price"`
+	Currency            string            `json:"currency,omitempty" example:"RUB"`
+	VATRate             *int              `json:"vatRate,omitempty" example:"20"`
+	Weight              *int              `json:"weight,omitempty" example:"500"`
+	Unit                string            `json:"unit,omitempty" example:"kg"`
+	PackUnit            *string           `json:"packUnit,omitempty" example:"box"`
+	PackSize            *Quantity         `json:"packSize,omitempty" swaggertype:"number" example:"12"`
+	IsBundle            bool              `json:"isBundle,omitempty"`
+	BundlePricing       *BundlePricing    `json:"bundlePricing,omitempty"`
+	BundleDiscountType  *DiscountType     `json:"bundleDiscountType,omitempty"`
+	BundleDiscountValue *int              `json:"bundleDiscountValue,omitempty"`
+	Components          []BundleComponent `json:"components,omitempty"`
+}
+
+// BundlePricing способ расчета цены комплекта.
+type BundlePricing string
+
+const (
+	// BundlePricingFixed цена комплекта задается в sellPrice.
+	BundlePricingFixed BundlePricing = "fixed"
+	// BundlePricingComponents цена комплекта равна сумме цен компонентов за вычетом скидки комплекта.
+	BundlePricingComponents BundlePricing = "components"
+)
+
+// BundleComponent товар в составе комплекта и его количество в одном комплекте.
+type BundleComponent struct {
+	ProductID int      `json:"productId" db:"component_id" binding:"required"`
+	Name      string   `json:"name,omitempty" db:"name"`
+	Quantity  Quantity `json:"quantity" db:"quantity" binding:"required"`
 }
 
 // ProductQueryParams параметры запроса для списка продуктов
@@ -40,3 +92,24 @@ type ProductListResponse struct {
 	PageSize int       `json:"pageSize"`
 	Total    int       `json:"total,omitempty"`
 }
+
+// PriceChange запись истории цен товара.
+// Запись с EffectiveFrom в будущем и Applied = false — запланированное изменение цены.
+type PriceChange struct {
+	ID            int       `json:"id" db:"id"`
+	ProductID     int       `json:"productId" db:"product_id"`
+	PurchasePrice int64     `json:"purchasePrice" db:"purchase_price"`
+	SellPrice     int64     `json:"sellPrice" db:"sell_price"`
+	EffectiveFrom time.Time `json:"effectiveFrom" db:"effective_from"`
+	CreatedBy     *int      `json:"createdBy,omitempty" db:"created_by"`
+	CreatedDate   time.Time `json:"createdDate" db:"created_date"`
+	Applied       bool      `json:"applied" db:"applied"`
+}
+
+// PriceChangeRequestBody тело запроса на изменение цен товара.
+// Если EffectiveFrom не указан или уже наступил, цены применяются сразу.
+type PriceChangeRequestBody struct {
+	PurchasePrice *int64     `json:"purchasePrice" binding:"required"`
+	SellPrice     *int64     `json:"sellPrice" binding:"required"`
+	EffectiveFrom *time.Time `json:"effectiveFrom,omitempty"`
+}
@@ -0,0 +1,192 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// QuantityScale количество знаков после запятой, с которым хранятся количества.
+const QuantityScale = 3
+
+const quantityFactor = 1000 // 10^QuantityScale
+
+// Quantity количество товара в виде десятичного числа с фиксированной точкой.
+// Хранится в тысячных долях единицы измерения: 1.5 кг = Quantity(1500).
+type Quantity int64
+
+// NewQuantity создает количество из целого числа единиц.
+func NewQuantity(units int) Quantity {
+	return Quantity(units * quantityFactor)
+}
+
+// ParseQuantity разбирает десятичную запись количества ("12", "0.5", "-1.250").
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("пустое значение количества")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if (intPart == "" && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("некорректное количество %s", s)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > QuantityScale {
+		return 0, fmt.Errorf("количество %s содержит больше %d знаков после запятой", s, QuantityScale)
+	}
+	fracPart += strings.Repeat("0", QuantityScale-len(fracPart))
+
+	whole, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("некорректное количество %s: %w", s, err)
+	}
+	frac, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("некорректное количество %s: %w", s, err)
+	}
+	if whole > (math.MaxInt64-frac)/quantityFactor {
+		return 0, fmt.Errorf("количество %s слишком большое", s)
+	}
+
+	q := Quantity(whole*quantityFactor + frac)
+	if negative {
+		q = -q
+	}
+	return q, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String возвращает десятичную запись без лишних нулей: 1500 -> "1.5".
+func (q Quantity) String() string {
+	sign := ""
+	v := int64(q)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	whole := v / quantityFactor
+	frac := v % quantityFactor
+	if frac == 0 {
+		return sign + strconv.FormatInt(whole, 10)
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*d", QuantityScale, frac), "0")
+	return sign + strconv.FormatInt(whole, 10) + "." + fracStr
+}
+
+// IsWhole сообщает, является ли количество целым числом единиц.
+func (q Quantity) IsWhole() bool {
+	return q%quantityFactor == 0
+}
+
+// FitsPrecision проверяет, что количество не содержит больше precision знаков после запятой.
+func (q Quantity) FitsPrecision(precision int) bool {
+	if precision >= QuantityScale {
+		return true
+	}
+	step := Quantity(math.Pow10(QuantityScale - precision))
+	return q%step == 0
+}
+
+// Mul умножает количество на другое количество (например, число упаковок на размер упаковки).
+// Результат округляется до QuantityScale знаков.
+func (q Quantity) Mul(other Quantity) (Quantity, error) {
+	a, b := int64(q), int64(other)
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("произведение количеств %s и %s выходит за допустимый диапазон", q, other)
+	}
+	half := int64(quantityFactor / 2)
+	if product < 0 {
+		half = -half
+	}
+	if (half > 0 && product > math.MaxInt64-half) || (half < 0 && product < math.MinInt64-half) {
+		return 0, fmt.Errorf("произведение количеств %s и %s выходит за допустимый диапазон", q, other)
+	}
+	return Quantity((product + half) / quantityFactor), nil
+}
+
+// Int32 возвращает целое количество для API, которые не поддерживают дробные значения.
+func (q Quantity) Int32() (int32, error) {
+	if !q.IsWhole() {
+		return 0, fmt.Errorf("дробное количество %s не поддерживается", q)
+	}
+	units := int64(q) / quantityFactor
+	if units > math.MaxInt32 || units < math.MinInt32 {
+		return 0, fmt.Errorf("количество %s выходит за допустимый диапазон", q)
+	}
+	return int32(units), nil
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalJSON принимает количество как числом (1.5), так и строкой ("1.5").
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	parsed, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+func (q *Quantity) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*q = 0
+		return nil
+	case int64:
+		*q = NewQuantity(int(v))
+		return nil
+	case float64:
+		*q = Quantity(math.Round(v * quantityFactor))
+		return nil
+	case []byte:
+		parsed, err := ParseQuantity(string(v))
+		if err != nil {
+			return err
+		}
+		*q = parsed
+		return nil
+	case string:
+		parsed, err := ParseQuantity(v)
+		if err != nil {
+			return err
+		}
+		*q = parsed
+		return nil
+	default:
+		return fmt.Errorf("неверный тип для Quantity: %T", value)
+	}
+}
+
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
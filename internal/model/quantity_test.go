@@ -0,0 +1,77 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Quantity
+		wantErr bool
+	}{
+		{name: "integer", input: "12", want: 12000},
+		{name: "fraction", input: "0.5", want: 500},
+		{name: "negative with trailing zeros", input: "-1.250", want: -1250},
+		{name: "explicit plus", input: "+2", want: 2000},
+		{name: "no integer part", input: ".5", want: 500},
+		{name: "no fraction digits", input: "5.", want: 5000},
+		{name: "spaces", input: " 3 ", want: 3000},
+		{name: "empty", input: "", wantErr: true},
+		{name: "dot only", input: ".", wantErr: true},
+		{name: "minus plus", input: "-+5", wantErr: true},
+		{name: "plus minus", input: "+-5", wantErr: true},
+		{name: "double minus", input: "--5", wantErr: true},
+		{name: "too many decimals", input: "1.2345", wantErr: true},
+		{name: "two dots", input: "1.2.3", wantErr: true},
+		{name: "letters", input: "abc", wantErr: true},
+		{name: "too large", input: "9223372036854776", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuantity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseQuantity(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseQuantity(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantity_Mul(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       Quantity
+		other   Quantity
+		want    Quantity
+		wantErr bool
+	}{
+		{name: "packs by pack size", q: NewQuantity(2), other: 1500, want: 3000},
+		{name: "zero", q: 0, other: NewQuantity(5), want: 0},
+		{name: "rounds half up", q: 1, other: 500, want: 1},
+		{name: "rounds below half down", q: 1, other: 499, want: 0},
+		{name: "negative rounds away from zero", q: -1, other: 500, want: -1},
+		{name: "overflow", q: math.MaxInt64, other: NewQuantity(2), wantErr: true},
+		{name: "negative overflow", q: math.MinInt64, other: -1, wantErr: true},
+		{name: "overflow on rounding", q: math.MaxInt64, other: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Mul(tt.other)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Mul() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Mul() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package model
+
+import "time"
+
+// RecurringFrequency единица периода регулярного заказа.
+type RecurringFrequency string
+
+const (
+	RecurringDaily   RecurringFrequency = "daily"
+	RecurringWeekly  RecurringFrequency = "weekly"
+	RecurringMonthly RecurringFrequency = "monthly"
+)
+
+func (f RecurringFrequency) Valid() bool {
+	switch f {
+	case RecurringDaily, RecurringWeekly, RecurringMonthly:
+		return true
+	}
+	return false
+}
+
+// RecurringStatus состояние шаблона регулярного заказа.
+type RecurringStatus string
+
+const (
+	RecurringActive RecurringStatus = "active"
+	RecurringPaused RecurringStatus = "paused"
+)
+
+// RecurringRunStatus результат очередного запуска регулярного заказа.
+type RecurringRunStatus string
+
+const (
+	RecurringRunCreated RecurringRunStatus = "created"
+	RecurringRunFailed  RecurringRunStatus = "failed"
+	RecurringRunSkipped RecurringRunStatus = "skipped"
+)
+
+// RecurringOrder шаблон регулярного заказа: по расписанию из него создаются обычные заказы
+// по текущим ценам. Период — IntervalCount единиц Frequency, например каждые 2 недели.
+type RecurringOrder struct {
+	ID               int                     `json:"id" db:"id"`
+	UserID           int                     `json:"userId" db:"user_id"`
+	Frequency        RecurringFrequency      `json:"frequency" db:"frequency"`
+	IntervalCount    int                     `json:"intervalCount" db:"interval_count"`
+	StartDate        time.Time               `json:"startDate" db:"start_date"` // Дата первого запуска
+	NextRunDate      time.Time               `json:"nextRunDate" db:"next_run_date"`
+	LastRunDate      *time.Time              `json:"lastRunDate,omitempty" db:"last_run_date"`
+	Status           RecurringStatus         `json:"status" db:"status"`
+	AddressID        *int                    `json:"addressId,omitempty" db:"address_id"`
+	ShippingMethodID *int                    `json:"shippingMethodId,omitempty" db:"shipping_method_id"`
+	TaxMode          *TaxMode                `json:"taxMode,omitempty" db:"tax_mode"`
+	CreatedDate      time.Time               `json:"createdDate" db:"created_date"`
+	Products         []RecurringOrderProduct `json:"products" db:"-"`
+	Runs             []RecurringOrderRun     `json:"runs,omitempty" db:"-"` // Последние запуски, только при получении по id
+}
+
+// RecurringOrderProduct товар шаблона, количество хранится в базовых единицах товара.
+type RecurringOrderProduct struct {
+	ProductID int      `json:"productId" db:"product_id"`
+	Name      string   `json:"name,omitempty" db:"name"`
+	Quantity  Quantity `json:"quantity" db:"quantity"`
+}
+
+// RecurringOrderRun запись о запуске регулярного заказа.
+type RecurringOrderRun struct {
+	ID            int                `json:"id" db:"id"`
+	ScheduledDate time.Time          `json:"scheduledDate" db:"scheduled_date"`
+	Status        RecurringRunStatus `json:"status" db:"status"`
+	OrderID       *int               `json:"orderId,omitempty" db:"order_id"`
+	Error         *string            `json:"error,omitempty" db:"error"`
+	CreatedDate   time.Time          `json:"createdDate" db:"created_date"`
+}
+
+// RecurringOrderRequestBody параметры регулярного заказа. UserID указывает сотрудник,
+// оформляющий регулярный заказ на клиента. Без StartDate первый заказ создается через один период.
+type RecurringOrderRequestBody struct {
+	UserID           *int               `json:"userId,omitempty"`
+	Frequency        RecurringFrequency `json:"frequency" binding:"required" example:"weekly"`
+	IntervalCount    int                `json:"intervalCount,omitempty" example:"1"`
+	StartDate        *time.Time         `json:"startDate,omitempty"`
+	Products         []OrderProduct     `json:"products" binding:"required"`
+	AddressID        *int               `json:"addressId,omitempty"`
+	ShippingMethodID *int               `json:"shippingMethodId,omitempty"`
+	TaxMode          *TaxMode           `json:"taxMode,omitempty"`
+}
+
+// Next возвращает дату запуска, следующую за from. Ежемесячные запуски привязаны к числу
+// первого запуска: шаблон от 31-го числа в коротком месяце срабатывает в последний день,
+// а в следующем месяце — снова 31-го.
+func (r RecurringOrder) Next(from time.Time) time.Time {
+	return r.Frequency.Next(from, r.IntervalCount, r.StartDate.In(from.Location()).Day())
+}
+
+// Next сдвигает дату на count периодов. Ежемесячный период переносит дату на число day,
+// а если в месяце столько дней нет — на его последний день.
+func (f RecurringFrequency) Next(from time.Time, count, day int) time.Time {
+	switch f {
+	case RecurringDaily:
+		return from.AddDate(0, 0, count)
+	case RecurringWeekly:
+		return from.AddDate(0, 0, 7*count)
+	default:
+		year, month, _ := from.Date()
+		hour, minute, sec := from.Clock()
+		first := time.Date(year, month+time.Month(count), 1, hour, minute, sec, from.Nanosecond(), from.Location())
+		lastDay := first.AddDate(0, 1, -1).Day()
+		return first.AddDate(0, 0, min(day, lastDay)-1)
+	}
+}
+
+// NextAfter возвращает первую дату запуска по расписанию шаблона позже now.
+// Пропущенные во время простоя или паузы запуски не наверстываются.
+func (r RecurringOrder) NextAfter(now time.Time) time.Time {
+	next := r.NextRunDate
+	for !next.After(now) {
+		next = r.Next(next)
+	}
+	return next
+}
+
+// OrderRequest формирует запрос на создание заказа из шаблона.
+func (r RecurringOrder) OrderRequest() OrderRequestBody {
+	products := make([]OrderProduct, 0, len(r.Products))
+	for _, product := range r.Products {
+		products = append(products, OrderProduct{
+			ProductID: product.ProductID,
+			Quantity:  product.Quantity,
+		})
+	}
+	return OrderRequestBody{
+		Products:         products,
+		TaxMode:          r.TaxMode,
+		AddressID:        r.AddressID,
+		ShippingMethodID: r.ShippingMethodID,
+	}
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurringFrequency_Next(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		frequency RecurringFrequency
+		from      time.Time
+		count     int
+		day       int
+		want      time.Time
+	}{
+		{
+			name:      "daily",
+			frequency: RecurringDaily,
+			from:      date(2025, time.January, 31),
+			count:     3,
+			day:       31,
+			want:      date(2025, time.February, 3),
+		},
+		{
+			name:      "every two weeks",
+			frequency: RecurringWeekly,
+			from:      date(2025, time.December, 25),
+			count:     2,
+			day:       25,
+			want:      date(2026, time.January, 8),
+		},
+		{
+			name:      "monthly clamps to the last day",
+			frequency: RecurringMonthly,
+			from:      date(2025, time.January, 31),
+			count:     1,
+			day:       31,
+			want:      date(2025, time.February, 28),
+		},
+		{
+			name:      "monthly returns to the start day after a short month",
+			frequency: RecurringMonthly,
+			from:      date(2025, time.February, 28),
+			count:     1,
+			day:       31,
+			want:      date(2025, time.March, 31),
+		},
+		{
+			name:      "monthly in a leap year",
+			frequency: RecurringMonthly,
+			from:      date(2024, time.January, 30),
+			count:     1,
+			day:       30,
+			want:      date(2024, time.February, 29),
+		},
+		{
+			name:      "quarterly across a year",
+			frequency: RecurringMonthly,
+			from:      date(2025, time.November, 30),
+			count:     3,
+			day:       31,
+			want:      date(2026, time.February, 28),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.frequency.Next(tt.from, tt.count, tt.day); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurringOrder_NextAfter(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC)
+	recurring := RecurringOrder{
+		Frequency:     RecurringMonthly,
+		IntervalCount: 1,
+		StartDate:     start,
+		NextRunDate:   start,
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "next month",
+			now:  start,
+			want: time.Date(2025, time.February, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "missed runs are not caught up and the start day is kept",
+			now:  time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2025, time.April, 30, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "long months get the start day back",
+			now:  time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2025, time.May, 31, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recurring.NextAfter(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
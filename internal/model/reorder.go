@@ -0,0 +1,47 @@
+package model
+
+// ReorderLineStatus результат переноса строки исходного заказа в повторный.
+type ReorderLineStatus string
+
+const (
+	// ReorderLineOK строка перенесена без изменений количества.
+	ReorderLineOK ReorderLineStatus = "ok"
+	// ReorderLineAdjusted количество уменьшено до доступного остатка.
+	ReorderLineAdjusted ReorderLineStatus = "adjusted"
+	// ReorderLineUnavailable товар удален из каталога или отсутствует на складе, строка не перенесена.
+	ReorderLineUnavailable ReorderLineStatus = "unavailable"
+)
+
+// ReorderLine строка отчета о повторном заказе. Цены указаны в минимальных единицах валюты.
+type ReorderLine struct {
+	ProductID         int               `json:"productId" db:"product_id"`
+	Name              string            `json:"name" db:"name"`
+	RequestedQuantity Quantity          `json:"requestedQuantity" db:"requested_quantity"` // Количество в исходном заказе
+	Quantity          Quantity          `json:"quantity" db:"-"`                           // Количество в новом заказе
+	Available         Quantity          `json:"-" db:"available"`
+	PreviousPrice     int64             `json:"previousPrice" db:"previous_price"`
+	Price             int64             `json:"price" db:"price"` // Текущая цена по прайс-листу владельца заказа
+	Deleted           bool              `json:"-" db:"deleted"`
+	Status            ReorderLineStatus `json:"status" db:"-"`
+}
+
+// ReorderResult новый заказ и отчет о том, как в него перенесены строки исходного.
+type ReorderResult struct {
+	Order *Order        `json:"order"`
+	Lines []ReorderLine `json:"lines"`
+}
+
+// Resolve определяет, в каком количестве строку можно перенести в новый заказ.
+func (l *ReorderLine) Resolve() {
+	switch {
+	case l.Deleted || l.Available <= 0:
+		l.Status = ReorderLineUnavailable
+		l.Quantity = 0
+	case l.Available < l.RequestedQuantity:
+		l.Status = ReorderLineAdjusted
+		l.Quantity = l.Available
+	default:
+		l.Status = ReorderLineOK
+		l.Quantity = l.RequestedQuantity
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// ReturnStatus статус заявки на возврат.
+type ReturnStatus string
+
+const (
+	ReturnPending  ReturnStatus = "pending"
+	ReturnApproved ReturnStatus = "approved"
+	ReturnRejected ReturnStatus = "rejected"
+)
+
+// Return заявка на возврат товаров выполненного заказа.
+type Return struct {
+	ID           int          `json:"id" db:"id"`
+	OrderID      int          `json:"orderId" db:"order_id"`
+	UserID       int          `json:"userId" db:"user_id"`
+	Status       ReturnStatus `json:"status" db:"status"`
+	RefundAmount int64        `json:"refundAmount" db:"refund_amount"` // Сумма к возврату с учетом скидок и НДС
+	Currency     string       `json:"currency" db:"currency"`
+	Comment      *string      `json:"comment,omitempty" db:"comment"` // Комментарий сотрудника к решению
+	DecidedBy    *int         `json:"decidedBy,omitempty" db:"decided_by"`
+	DecidedDate  *time.Time   `json:"decidedDate,omitempty" db:"decided_date"`
+	CreatedDate  time.Time    `json:"createdDate" db:"created_date"`
+	Lines        []ReturnLine `json:"lines" db:"-"`
+}
+
+// ReturnLine возвращаемый товар заявки.
+type ReturnLine struct {
+	ProductID    int      `json:"productId" db:"product_id"`
+	Name         string   `json:"name,omitempty" db:"name"`
+	Quantity     Quantity `json:"quantity" db:"quantity"`
+	Reason       string   `json:"reason" db:"reason"`
+	RefundAmount int64    `json:"refundAmount" db:"refund_amount"`
+	// Damaged поврежденный товар списывается, а не возвращается на склад.
+	Damaged bool `json:"damaged" db:"damaged"`
+}
+
+type ReturnRequestBody struct {
+	OrderID int                 `json:"orderId" binding:"required"`
+	Lines   []ReturnLineRequest `json:"lines" binding:"required"`
+}
+
+type ReturnLineRequest struct {
+	ProductID int      `json:"productId" binding:"required"`
+	Quantity  Quantity `json:"quantity" swaggertype:"number"`
+	Reason    string   `json:"reason"`
+}
+
+// ReturnDecisionBody решение сотрудника по заявке на возврат.
+type ReturnDecisionBody struct {
+	Status  ReturnStatus `json:"status" binding:"required" example:"approved"`
+	Comment *string      `json:"comment,omitempty"`
+	// DamagedProductIDs товары, которые при одобрении списываются вместо возврата на склад.
+	DamagedProductIDs []int `json:"damagedProductIds,omitempty"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ShippingRule правило расчета стоимости доставки.
+type ShippingRule string
+
+const (
+	// ShippingFlat фиксированная стоимость BaseCost.
+	ShippingFlat ShippingRule = "flat"
+	// ShippingByWeight BaseCost плюс CostPerKg за каждый начатый килограмм веса заказа.
+	ShippingByWeight ShippingRule = "weight"
+	// ShippingFreeOver BaseCost, если сумма товаров заказа меньше FreeThreshold, иначе бесплатно.
+	ShippingFreeOver ShippingRule = "free_over"
+)
+
+func (r ShippingRule) Valid() bool {
+	switch r {
+	case ShippingFlat, ShippingByWeight, ShippingFreeOver:
+		return true
+	}
+	return false
+}
+
+// ShippingMethod способ доставки. Суммы указаны в минимальных единицах валюты Currency.
+type ShippingMethod struct {
+	ID            int          `json:"id" db:"id"`
+	Name          string       `json:"name" db:"name"`
+	Rule          ShippingRule `json:"rule" db:"rule"`
+	BaseCost      int64        `json:"baseCost" db:"base_cost"`
+	CostPerKg     *int64       `json:"costPerKg,omitempty" db:"cost_per_kg"`
+	FreeThreshold *int64       `json:"freeThreshold,omitempty" db:"free_threshold"`
+	Currency      string       `json:"currency" db:"currency"`
+	Active        bool         `json:"active" db:"active"`
+	CreatedDate   time.Time    `json:"createdDate" db:"created_date"`
+}
+
+type ShippingMethodRequestBody struct {
+	Name          string       `json:"name" binding:"required"`
+	Rule          ShippingRule `json:"rule" binding:"required" example:"flat"`
+	BaseCost      int64        `json:"baseCost"`
+	CostPerKg     *int64       `json:"costPerKg,omitempty"`
+	FreeThreshold *int64       `json:"freeThreshold,omitempty"`
+	Currency      string       `json:"currency,omitempty" example:"RUB"`
+	Active        *bool        `json:"active,omitempty"`
+}
+
+// Address адрес доставки из адресной книги пользователя.
+type Address struct {
+	ID          int       `json:"id" db:"id"`
+	UserID      int       `json:"userId" db:"user_id"`
+	Label       *string   `json:"label,omitempty" db:"label"` // Название адреса для пользователя: «Дом», «Офис»
+	Recipient   string    `json:"recipient" db:"recipient"`
+	Phone       string    `json:"phone" db:"phone"`
+	PostalCode  string    `json:"postalCode" db:"postal_code"`
+	City        string    `json:"city" db:"city"`
+	Street      string    `json:"street" db:"street"` // Улица, дом, квартира
+	IsDefault   bool      `json:"isDefault" db:"is_default"`
+	CreatedDate time.Time `json:"createdDate" db:"created_date"`
+}
+
+// String возвращает адрес одной строкой, в таком виде он сохраняется в заказе.
+func (a Address) String() string {
+	parts := make([]string, 0, 5)
+	for _, part := range []string{a.PostalCode, a.City, a.Street, a.Recipient, a.Phone} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+type AddressRequestBody struct {
+	Label      *string `json:"label,omitempty"`
+	Recipient  string  `json:"recipient" binding:"required"`
+	Phone      string  `json:"phone" binding:"required"`
+	PostalCode string  `json:"postalCode"`
+	City       string  `json:"city" binding:"required"`
+	Street     string  `json:"street" binding:"required"`
+	IsDefault  bool    `json:"isDefault"`
+}
+
+// ShipmentRequestBody данные об отправке заказа.
+type ShipmentRequestBody struct {
+	Carrier        string `json:"carrier" binding:"required" example:"СДЭК"`
+	TrackingNumber string `json:"trackingNumber" binding:"required"`
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TaxMode способ учета НДС в ценах заказа.
+type TaxMode string
+
+const (
+	// TaxInclusive НДС включен в цену, налог выделяется из суммы строки.
+	TaxInclusive TaxMode = "inclusive"
+	// TaxExclusive НДС начисляется сверх цены и увеличивает итог заказа.
+	TaxExclusive TaxMode = "exclusive"
+)
+
+// DefaultVATRate ставка НДС по умолчанию, %.
+const DefaultVATRate = 20
+
+// vatRates допустимые ставки НДС в РФ, %.
+var vatRates = map[int]struct{}{
+	0:  {},
+	10: {},
+	20: {},
+}
+
+func (m TaxMode) Valid() bool {
+	switch m {
+	case TaxInclusive, TaxExclusive:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateVATRate проверяет, что ставка НДС входит в список допустимых.
+func ValidateVATRate(rate int) error {
+	if _, ok := vatRates[rate]; !ok {
+		return fmt.Errorf("недопустимая ставка НДС %d%%: допустимы 0, 10 и 20", rate)
+	}
+	return nil
+}
+
+// TaxBreakdownItem сумма налога по одной ставке НДС.
+type TaxBreakdownItem struct {
+	Rate   int   `json:"rate"`   // Ставка НДС, %
+	Base   int64 `json:"base"`   // Сумма строк по ставке с учетом скидок
+	Amount int64 `json:"amount"` // Сумма НДС
+}
+
+// BuildTaxBreakdown группирует налог по ставкам НДС товаров заказа.
+func BuildTaxBreakdown(products []Product) []TaxBreakdownItem {
+	byRate := make(map[int]*TaxBreakdownItem)
+	for _, p := range products {
+		if p.VATRate == nil {
+			continue
+		}
+		item, ok := byRate[*p.VATRate]
+		if !ok {
+			item = &TaxBreakdownItem{Rate: *p.VATRate}
+			byRate[*p.VATRate] = item
+		}
+		item.Base += p.TaxBase
+		item.Amount += p.TaxAmount
+	}
+
+	breakdown := make([]TaxBreakdownItem, 0, len(byRate))
+	for _, item := range byRate {
+		breakdown = append(breakdown, *item)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Rate > breakdown[j].Rate
+	})
+	return breakdown
+}
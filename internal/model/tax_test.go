@@ -0,0 +1,54 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildTaxBreakdown(t *testing.T) {
+	rate := func(r int) *int { return &r }
+
+	tests := []struct {
+		name     string
+		products []Product
+		want     []TaxBreakdownItem
+	}{
+		{
+			name:     "empty order",
+			products: nil,
+			want:     []TaxBreakdownItem{},
+		},
+		{
+			name: "groups by rate, highest rate first",
+			products: []Product{
+				{VATRate: rate(10), TaxBase: 11000, TaxAmount: 1000},
+				{VATRate: rate(20), TaxBase: 12000, TaxAmount: 2000},
+				{VATRate: rate(20), TaxBase: 6000, TaxAmount: 1000},
+				{VATRate: rate(0), TaxBase: 500},
+			},
+			want: []TaxBreakdownItem{
+				{Rate: 20, Base: 18000, Amount: 3000},
+				{Rate: 10, Base: 11000, Amount: 1000},
+				{Rate: 0, Base: 500, Amount: 0},
+			},
+		},
+		{
+			name: "products without rate are skipped",
+			products: []Product{
+				{TaxBase: 1000, TaxAmount: 200},
+				{VATRate: rate(20), TaxBase: 1200, TaxAmount: 200},
+			},
+			want: []TaxBreakdownItem{
+				{Rate: 20, Base: 1200, Amount: 200},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildTaxBreakdown(tt.products); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildTaxBreakdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
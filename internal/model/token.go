@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// RefreshToken запись о выданном refresh-токене. Сам токен не хранится, только его хеш.
+type RefreshToken struct {
+	ID          int        `db:"id"`
+	UserID      int        `db:"user_id"`
+	FamilyID    string     `db:"family_id"`
+	TokenHash   string     `db:"token_hash"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	UsedDate    *time.Time `db:"used_date"`
+	RevokedDate *time.Time `db:"revoked_date"`
+	CreatedDate time.Time  `db:"created_date"`
+}
+
+// Active возвращает true, если токен еще не использован и не отозван.
+func (t *RefreshToken) Active() bool {
+	return t.UsedDate == nil && t.RevokedDate == nil
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// LogoutRequest необязательное тело запроса выхода: переданный refresh-токен отзывается вместе с сессией.
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
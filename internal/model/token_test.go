@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshToken_Active(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		token RefreshToken
+		want  bool
+	}{
+		{name: "new token", token: RefreshToken{}, want: true},
+		{name: "used token", token: RefreshToken{UsedDate: &now}, want: false},
+		{name: "revoked token", token: RefreshToken{RevokedDate: &now}, want: false},
+		{name: "used and revoked with its family", token: RefreshToken{UsedDate: &now, RevokedDate: &now}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.Active(); got != tt.want {
+				t.Errorf("Active() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// TwoFactor настройка двухфакторной аутентификации пользователя по TOTP.
+type TwoFactor struct {
+	UserID      int        `db:"user_id"`
+	Secret      string     `db:"secret"`
+	EnabledAt   *time.Time `db:"enabled_at"`
+	CreatedDate time.Time  `db:"created_date"`
+}
+
+// Enabled возвращает true, если настройка подтверждена кодом и код требуется при входе.
+func (t *TwoFactor) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TwoFactorEnrollment данные для добавления учетной записи в приложение-аутентификатор:
+// секрет для ручного ввода, otpauth-ссылка и она же в виде QR-кода (PNG в base64).
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauthUri"`
+	QRCode []byte `json:"qrCode" swaggertype:"string" format:"base64"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorChallengeRequest токен второго шага входа, выданный после проверки пароля.
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+// TwoFactorLoginRequest второй шаг входа. Вместо кода из приложения можно передать код восстановления.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// RecoveryCodes коды восстановления. Показываются один раз, при включении двухфакторной аутентификации.
+type RecoveryCodes struct {
+	Codes []string `json:"recoveryCodes"`
+}
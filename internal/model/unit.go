@@ -0,0 +1,12 @@
+package model
+
+// DefaultUnitCode единица измерения товара по умолчанию (штуки).
+const DefaultUnitCode = "pcs"
+
+// Unit единица измерения. Precision — допустимое количество знаков после запятой
+// (0 для штучного товара, 3 для килограммов и метров).
+type Unit struct {
+	Code      string `json:"code" db:"code"`
+	Name      string `json:"name" db:"name"`
+	Precision int    `json:"precision" db:"precision"`
+}
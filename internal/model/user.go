@@ -2,6 +2,8 @@ package model
 
 import (
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
@@ -9,19 +11,33 @@ import (
 
 type UserRole string
 
+// Роли хранятся в базе вместе с наборами разрешений (см. Role). Здесь перечислены
+// системные роли, которые создаются миграцией.
 const (
-	RoleClient   UserRole = "client"
-	RoleEmployee UserRole = "employee"
+	RoleClient    UserRole = "client"
+	RoleEmployee  UserRole = "employee"
+	RoleAdmin     UserRole = "admin"
+	RoleWarehouse UserRole = "warehouse"
 )
 
+var roleKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
 type User struct {
-	ID           int      `json:"id" db:"id"`
-	Login        string   `json:"login" binding:"required" db:"login"`
-	PasswordHash string   `json:"-" db:"password_hash"`
-	FirstName    string   `json:"firstName" binding:"required" db:"first_name"`
-	LastName     string   `json:"lastName" binding:"required" db:"last_name"`
-	Email        string   `json:"email" binding:"required,email" db:"email"`
-	Role         UserRole `json:"role" db:"role"`
+	ID              int        `json:"id" db:"id"`
+	Login           string     `json:"login" binding:"required" db:"login"`
+	PasswordHash    string     `json:"-" db:"password_hash"`
+	FirstName       string     `json:"firstName" binding:"required" db:"first_name"`
+	LastName        string     `json:"lastName" binding:"required" db:"last_name"`
+	Email           string     `json:"email" binding:"required,email" db:"email"`
+	Role            UserRole   `json:"role" db:"role"`
+	CustomerGroupID *int       `json:"customerGroupId,omitempty" db:"customer_group_id"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt" db:"email_verified_at"`
+}
+
+// EmailVerified возвращает true, если пользователь подтвердил email.
+// Пока email не подтвержден, оформлять заказы нельзя.
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 type UserProxy struct {
@@ -54,6 +70,23 @@ type UserRoleBody struct {
 	Role UserRole `json:"role" binding:"required"`
 }
 
+// ForgotPasswordRequest запрос ссылки для сброса пароля на email учетной записи.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// VerifyEmailRequest токен из письма для подтверждения email.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResetPasswordRequest новый пароль и токен из письма со ссылкой для сброса.
+type ResetPasswordRequest struct {
+	Token           string `json:"token" binding:"required"`
+	Password        string `json:"password" binding:"required"`
+	PasswordConfirm string `json:"passwordConfirm" binding:"required"`
+}
+
 type UserChangePasswordBody struct {
 	Password        string `json:"password" binding:"required"`
 	PasswordConfirm string `json:"passwordConfirm" binding:"required"`
@@ -65,12 +98,22 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
-// Claims Структура для JWT токена.
+// LoginLockout запись журнала о блокировке входа. Subject — логин или IP-адрес,
+// с которого подбирали пароль.
+type LoginLockout struct {
+	Subject     string    `json:"subject"`
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"lockedUntil"`
+}
 
+// Claims Структура для JWT токена.
+// Идентификатор токена (jti) передается в RegisteredClaims.ID и нужен для отзыва отдельного токена,
+// TokenVersion сравнивается с текущей версией пользователя для отзыва всех его токенов.
 type Claims struct {
 	Login                string   `json:"login"`
 	Role                 UserRole `json:"role"`
 	UserID               int      `json:"userId"`
+	TokenVersion         int      `json:"tokenVersion"`
 	jwt.RegisteredClaims          // Данное поле нужно для правильной генерации JWT.
 }
 
@@ -85,22 +128,22 @@ func (user *User) HashPassword(password string) error {
 	return err
 }
 
+// Valid проверяет формат ключа роли. Существование роли проверяет база.
 func (r UserRole) Valid() bool {
-	switch r {
-	case RoleClient, RoleEmployee:
-		return true
-	default:
-		return false
-	}
+	return roleKeyPattern.MatchString(string(r))
+}
+
+// IsClient возвращает true, если запрос ограничен собственными записями пользователя.
+// Обработчики передают RoleClient любой роли без разрешения на чужие данные
+// (orders:read_all, returns:decide, shipping:manage), поэтому ключ роли здесь не решает.
+func (r UserRole) IsClient() bool {
+	return r == RoleClient
 }
 
 func ParseUserRole(roleStr string) (UserRole, error) {
-	switch roleStr {
-	case string(RoleClient):
-		return RoleClient, nil
-	case string(RoleEmployee):
-		return RoleEmployee, nil
-	default:
+	role := UserRole(roleStr)
+	if !role.Valid() {
 		return "", fmt.Errorf("неизвестная роль: %s", roleStr)
 	}
+	return role, nil
 }
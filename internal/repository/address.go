@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type AddressesRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewAddressesRepository(db *sqlx.DB, redis *redis.Client) *AddressesRepository {
+	return &AddressesRepository{db: db, redis: redis}
+}
+
+// Create добавляет адрес в адресную книгу пользователя. Первый адрес пользователя
+// становится адресом по умолчанию.
+func (ar *AddressesRepository) Create(
+	ctx context.Context,
+	userID int,
+	addressReq model.AddressRequestBody,
+) (*model.Address, error) {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if addressReq.IsDefault {
+		if err := resetDefaultAddress(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var address model.Address
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO users.addresses (
+			user_id,
+			label,
+			recipient,
+			phone,
+			postal_code,
+			city,
+			street,
+			is_default
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7,
+			$8 OR NOT EXISTS (SELECT 1 FROM users.addresses WHERE user_id = $1)
+		)
+		RETURNING *
+	`,
+		userID,
+		addressReq.Label,
+		addressReq.Recipient,
+		addressReq.Phone,
+		addressReq.PostalCode,
+		addressReq.City,
+		addressReq.Street,
+		addressReq.IsDefault,
+	).StructScan(&address)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания адреса: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &address, nil
+}
+
+func (ar *AddressesRepository) GetAll(ctx context.Context, userID int) ([]model.Address, error) {
+	addresses := []model.Address{}
+	err := ar.db.SelectContext(ctx, &addresses, `
+		SELECT * FROM users.addresses WHERE user_id = $1 ORDER BY is_default DESC, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка адресов: %w", err)
+	}
+	return addresses, nil
+}
+
+func (ar *AddressesRepository) GetByID(ctx context.Context, id, userID int) (*model.Address, error) {
+	var address model.Address
+	err := ar.db.GetContext(ctx, &address, `
+		SELECT * FROM users.addresses WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("адрес не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения адреса: %w", err)
+	}
+	return &address, nil
+}
+
+// Update изменяет адрес. Заказы, уже оформленные на этот адрес, хранят его прежнее значение.
+func (ar *AddressesRepository) Update(
+	ctx context.Context,
+	id, userID int,
+	addressReq model.AddressRequestBody,
+) (*model.Address, error) {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if addressReq.IsDefault {
+		if err := resetDefaultAddress(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var address model.Address
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE users.addresses SET
+			label = $1,
+			recipient = $2,
+			phone = $3,
+			postal_code = $4,
+			city = $5,
+			street = $6,
+			is_default = is_default OR $7
+		WHERE id = $8 AND user_id = $9
+		RETURNING *
+	`,
+		addressReq.Label,
+		addressReq.Recipient,
+		addressReq.Phone,
+		addressReq.PostalCode,
+		addressReq.City,
+		addressReq.Street,
+		addressReq.IsDefault,
+		id,
+		userID,
+	).StructScan(&address)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("адрес не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка обновления адреса: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &address, nil
+}
+
+// Delete удаляет адрес. Если удален адрес по умолчанию, им становится самый ранний из оставшихся.
+func (ar *AddressesRepository) Delete(ctx context.Context, id, userID int) (*model.Address, error) {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var address model.Address
+	err = tx.QueryRowxContext(ctx, `
+		DELETE FROM users.addresses WHERE id = $1 AND user_id = $2
+		RETURNING *
+	`, id, userID).StructScan(&address)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("адрес не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка удаления адреса: %w", err)
+	}
+
+	if address.IsDefault {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE users.addresses SET is_default = TRUE
+			WHERE id = (SELECT MIN(id) FROM users.addresses WHERE user_id = $1)
+		`, userID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка назначения адреса по умолчанию: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &address, nil
+}
+
+func (ar *AddressesRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, ar.redis)
+}
+
+func resetDefaultAddress(ctx context.Context, tx *sqlx.Tx, userID int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE users.addresses SET is_default = FALSE WHERE user_id = $1 AND is_default
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка сброса адреса по умолчанию: %w", err)
+	}
+	return nil
+}
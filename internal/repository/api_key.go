@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const apiKeysQuery = `
+	SELECT k.*, u.login, u.role
+	FROM users.api_keys k
+	JOIN users.users u ON u.id = k.user_id
+`
+
+type APIKeysRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewAPIKeysRepository(db *sqlx.DB, redis *redis.Client) *APIKeysRepository {
+	return &APIKeysRepository{db: db, redis: redis}
+}
+
+func (ar *APIKeysRepository) Create(ctx context.Context, key model.APIKey) (*model.APIKey, error) {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO users.api_keys (name, prefix, key_hash, user_id, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, key.Name, key.Prefix, key.KeyHash, key.UserID, key.ExpiresAt, key.CreatedBy).Scan(&id)
+	if err != nil {
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("пользователь не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка создания ключа API: %w", err)
+	}
+	for _, scope := range key.Scopes {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users.api_key_scopes (api_key_id, permission) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, id, scope)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сохранения разрешений ключа API: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return ar.GetByID(ctx, id)
+}
+
+func (ar *APIKeysRepository) GetAll(ctx context.Context) ([]model.APIKey, error) {
+	keys := []model.APIKey{}
+	if err := ar.db.SelectContext(ctx, &keys, apiKeysQuery+" ORDER BY k.id"); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка ключей API: %w", err)
+	}
+	if err := ar.loadScopes(ctx, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (ar *APIKeysRepository) GetByID(ctx context.Context, id int) (*model.APIKey, error) {
+	return ar.get(ctx, "k.id = $1", id)
+}
+
+// GetByHash ищет ключ по хешу предъявленного значения.
+func (ar *APIKeysRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	return ar.get(ctx, "k.key_hash = $1", keyHash)
+}
+
+func (ar *APIKeysRepository) get(ctx context.Context, condition string, arg any) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := ar.db.GetContext(ctx, &key, apiKeysQuery+" WHERE "+condition, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ключ API не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения ключа API: %w", err)
+	}
+	keys := []model.APIKey{key}
+	if err := ar.loadScopes(ctx, keys); err != nil {
+		return nil, err
+	}
+	return &keys[0], nil
+}
+
+func (ar *APIKeysRepository) loadScopes(ctx context.Context, keys []model.APIKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(keys))
+	index := make(map[int]int, len(keys))
+	for i := range keys {
+		ids = append(ids, int64(keys[i].ID))
+		index[keys[i].ID] = i
+		keys[i].Scopes = []model.Permission{}
+	}
+	scopes := []struct {
+		APIKeyID   int              `db:"api_key_id"`
+		Permission model.Permission `db:"permission"`
+	}{}
+	err := ar.db.SelectContext(ctx, &scopes, `
+		SELECT api_key_id, permission FROM users.api_key_scopes
+		WHERE api_key_id = ANY($1)
+		ORDER BY api_key_id, permission
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("ошибка получения разрешений ключей API: %w", err)
+	}
+	for _, scope := range scopes {
+		if i, ok := index[scope.APIKeyID]; ok {
+			keys[i].Scopes = append(keys[i].Scopes, scope.Permission)
+		}
+	}
+	return nil
+}
+
+// Touch отмечает время последнего использования ключа.
+func (ar *APIKeysRepository) Touch(ctx context.Context, id int) error {
+	if _, err := ar.db.ExecContext(ctx, `UPDATE users.api_keys SET last_used_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("ошибка обновления времени использования ключа API: %w", err)
+	}
+	return nil
+}
+
+func (ar *APIKeysRepository) Delete(ctx context.Context, id int) (*model.APIKey, error) {
+	key, err := ar.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ar.db.ExecContext(ctx, `DELETE FROM users.api_keys WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("ошибка удаления ключа API: %w", err)
+	}
+	return key, nil
+}
+
+func (ar *APIKeysRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, ar.redis)
+}
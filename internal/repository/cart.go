@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type CartsRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewCartsRepository(db *sqlx.DB, redis *redis.Client) *CartsRepository {
+	return &CartsRepository{db: db, redis: redis}
+}
+
+// GetItems возвращает строки корзины с актуальными ценами по прайс-листу пользователя и остатками.
+// Удаленные из каталога товары остаются в корзине, чтобы клиент увидел, почему их нельзя заказать.
+func (cr *CartsRepository) GetItems(ctx context.Context, userID int) ([]model.CartItem, error) {
+	items := []model.CartItem{}
+	err := cr.db.SelectContext(ctx, &items, `
+		SELECT
+			ci.product_id,
+			COALESCE(p.code, 0) AS code,
+			COALESCE(p.name, '') AS name,
+			COALESCE(p.unit_code, '') AS unit_code,
+			ci.quantity,
+			COALESCE(pricing.effective_price(p.id, ci.user_id), 0) AS price,
+			COALESCE(pricing.effective_currency(p.id, ci.user_id), '') AS currency,
+			COALESCE(orders.line_cost(ci.quantity, pricing.effective_price(p.id, ci.user_id)), 0) AS line_cost,
+			COALESCE(p.quantity, 0) AS available,
+			p.id IS NULL AS deleted,
+			ci.added_date
+		FROM orders.cart_items ci
+		LEFT JOIN products.products p ON p.id = ci.product_id
+		WHERE ci.user_id = $1
+		ORDER BY ci.added_date, ci.product_id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения корзины: %w", err)
+	}
+	return items, nil
+}
+
+// AddItem добавляет товар в корзину; если товар уже есть, количество суммируется.
+func (cr *CartsRepository) AddItem(ctx context.Context, userID int, item model.CartItemRequest) error {
+	quantity, err := cr.baseQuantity(ctx, item)
+	if err != nil {
+		return err
+	}
+	_, err = cr.db.ExecContext(ctx, `
+		INSERT INTO orders.cart_items (user_id, product_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, product_id)
+		DO UPDATE SET quantity = orders.cart_items.quantity + EXCLUDED.quantity
+	`, userID, item.ProductID, quantity)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления товара %d в корзину: %w", item.ProductID, err)
+	}
+	return nil
+}
+
+// UpdateItem заменяет количество товара в корзине.
+func (cr *CartsRepository) UpdateItem(ctx context.Context, userID int, item model.CartItemRequest) error {
+	quantity, err := cr.baseQuantity(ctx, item)
+	if err != nil {
+		return err
+	}
+	result, err := cr.db.ExecContext(ctx, `
+		UPDATE orders.cart_items SET quantity = $1
+		WHERE user_id = $2 AND product_id = $3
+	`, quantity, userID, item.ProductID)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения количества товара %d в корзине: %w", item.ProductID, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("товар %d отсутствует в корзине", item.ProductID)
+	}
+	return nil
+}
+
+func (cr *CartsRepository) RemoveItem(ctx context.Context, userID, productID int) error {
+	result, err := cr.db.ExecContext(ctx, `
+		DELETE FROM orders.cart_items WHERE user_id = $1 AND product_id = $2
+	`, userID, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления товара %d из корзины: %w", productID, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("товар %d отсутствует в корзине", productID)
+	}
+	return nil
+}
+
+func (cr *CartsRepository) Clear(ctx context.Context, userID int) error {
+	_, err := cr.db.ExecContext(ctx, "DELETE FROM orders.cart_items WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("ошибка очистки корзины: %w", err)
+	}
+	return nil
+}
+
+func (cr *CartsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, cr.redis)
+}
+
+// baseQuantity переводит количество в базовые единицы товара по тем же правилам, что и при создании заказа.
+func (cr *CartsRepository) baseQuantity(ctx context.Context, item model.CartItemRequest) (model.Quantity, error) {
+	products := []model.OrderProduct{{
+		ProductID: item.ProductID,
+		Quantity:  item.Quantity,
+		Unit:      item.Unit,
+	}}
+	if err := toBaseQuantities(ctx, cr.db, products); err != nil {
+		return 0, err
+	}
+	return products[0].Quantity, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectOrderComments = `
+	SELECT
+		c.id,
+		c.order_id,
+		c.parent_id,
+		c.author_id,
+		COALESCE(TRIM(u.first_name || ' ' || u.last_name), '') AS author_name,
+		u.role AS author_role,
+		c.body,
+		c.internal,
+		c.created_date
+	FROM orders.order_comments c
+	LEFT JOIN users.users u ON u.id = c.author_id
+`
+
+type CommentsRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewCommentsRepository(db *sqlx.DB, redis *redis.Client) *CommentsRepository {
+	return &CommentsRepository{db: db, redis: redis}
+}
+
+// Create добавляет комментарий к заказу. Ответ на внутреннюю заметку тоже становится внутренним,
+// чтобы ветка обсуждения сотрудников не стала видна клиенту.
+func (cr *CommentsRepository) Create(
+	ctx context.Context,
+	orderID int,
+	commentReq model.OrderCommentRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.OrderComment, error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkOrderAccess(ctx, tx, orderID, userID, role); err != nil {
+		return nil, err
+	}
+
+	internal := commentReq.Internal
+	if commentReq.ParentID != nil {
+		var parentInternal bool
+		err = tx.GetContext(ctx, &parentInternal, `
+			SELECT internal FROM orders.order_comments WHERE id = $1 AND order_id = $2
+		`, *commentReq.ParentID, orderID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && parentInternal && role.IsClient()) {
+			return nil, fmt.Errorf("комментарий %d не найден", *commentReq.ParentID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения комментария: %w", err)
+		}
+		internal = internal || parentInternal
+	}
+
+	var commentID int
+	err = tx.GetContext(ctx, &commentID, `
+		INSERT INTO orders.order_comments (order_id, parent_id, author_id, body, internal)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, orderID, commentReq.ParentID, userID, commentReq.Body, internal)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания комментария: %w", err)
+	}
+
+	for _, attachment := range commentReq.Attachments {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO orders.comment_attachments (comment_id, file_name, content_type, size, url)
+			VALUES ($1, $2, $3, $4, $5)
+		`, commentID, attachment.FileName, attachment.ContentType, attachment.Size, attachment.URL)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сохранения вложения %s: %w", attachment.FileName, err)
+		}
+	}
+
+	var comment model.OrderComment
+	if err := tx.GetContext(ctx, &comment, selectOrderComments+" WHERE c.id = $1", commentID); err != nil {
+		return nil, fmt.Errorf("ошибка получения комментария: %w", err)
+	}
+	comments := []model.OrderComment{comment}
+	if err := loadCommentAttachments(ctx, tx, orderID, comments); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &comments[0], nil
+}
+
+// GetAll возвращает комментарии заказа в порядке создания. Клиент видит только
+// публичные комментарии своих заказов.
+func (cr *CommentsRepository) GetAll(
+	ctx context.Context,
+	orderID, userID int,
+	role model.UserRole,
+) ([]model.OrderComment, error) {
+	if err := checkOrderAccess(ctx, cr.db, orderID, userID, role); err != nil {
+		return nil, err
+	}
+
+	query := selectOrderComments + " WHERE c.order_id = $1"
+	if role.IsClient() {
+		query += " AND NOT c.internal"
+	}
+	comments := []model.OrderComment{}
+	if err := cr.db.SelectContext(ctx, &comments, query+" ORDER BY c.created_date, c.id", orderID); err != nil {
+		return nil, fmt.Errorf("ошибка получения комментариев заказа: %w", err)
+	}
+	if err := loadCommentAttachments(ctx, cr.db, orderID, comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// Delete удаляет комментарий вместе с ответами. Клиент может удалить только свой комментарий,
+// сотрудник — любой.
+func (cr *CommentsRepository) Delete(
+	ctx context.Context,
+	id, orderID, userID int,
+	role model.UserRole,
+) (*model.OrderComment, error) {
+	query := "DELETE FROM orders.order_comments WHERE id = $1 AND order_id = $2"
+	args := []any{id, orderID}
+	if role.IsClient() {
+		query += " AND author_id = $3 AND NOT internal"
+		args = append(args, userID)
+	}
+
+	var comment model.OrderComment
+	err := cr.db.QueryRowxContext(ctx, query+`
+		RETURNING id, order_id, parent_id, author_id, body, internal, created_date
+	`, args...).StructScan(&comment)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("комментарий %d не найден", id)
+		}
+		return nil, fmt.Errorf("ошибка удаления комментария: %w", err)
+	}
+	return &comment, nil
+}
+
+func (cr *CommentsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, cr.redis)
+}
+
+// checkOrderAccess проверяет, что заказ существует и доступен пользователю.
+func checkOrderAccess(ctx context.Context, q sqlx.QueryerContext, orderID, userID int, role model.UserRole) error {
+	query, args := ownedOrderQuery("SELECT id FROM orders.orders WHERE id = $1", orderID, userID, role)
+	var id int
+	err := sqlx.GetContext(ctx, q, &id, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("заказ не найден или не принадлежит пользователю")
+		}
+		return fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	return nil
+}
+
+// loadCommentAttachments заполняет вложения комментариев заказа orderID.
+func loadCommentAttachments(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	orderID int,
+	comments []model.OrderComment,
+) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(comments))
+	for i := range comments {
+		index[comments[i].ID] = i
+		comments[i].Attachments = []model.CommentAttachment{}
+	}
+
+	attachments := []model.CommentAttachment{}
+	err := sqlx.SelectContext(ctx, q, &attachments, `
+		SELECT a.*
+		FROM orders.comment_attachments a
+		JOIN orders.order_comments c ON c.id = a.comment_id
+		WHERE c.order_id = $1
+		ORDER BY a.id
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения вложений: %w", err)
+	}
+	for _, attachment := range attachments {
+		if i, ok := index[attachment.CommentID]; ok {
+			comments[i].Attachments = append(comments[i].Attachments, attachment)
+		}
+	}
+	return nil
+}
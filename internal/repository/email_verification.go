@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type EmailVerificationsRepository struct {
+	db *sqlx.DB
+}
+
+func NewEmailVerificationsRepository(db *sqlx.DB) *EmailVerificationsRepository {
+	return &EmailVerificationsRepository{db: db}
+}
+
+// Create сохраняет токен подтверждения адреса email. Ранее выданные пользователю
+// токены погашаются, чтобы действовала только ссылка из последнего письма.
+// Записи не удаляются: по ним считается частота отправки писем.
+func (vr *EmailVerificationsRepository) Create(
+	ctx context.Context,
+	userID int,
+	email, tokenHash string,
+	expiresAt time.Time,
+) error {
+	tx, err := vr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.email_verification_tokens SET used_date = NOW()
+		WHERE user_id = $1 AND used_date IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка погашения токенов подтверждения email: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users.email_verification_tokens (user_id, email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, userID, email, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена подтверждения email: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// CountSince возвращает число писем с подтверждением, отправленных пользователю начиная с since.
+func (vr *EmailVerificationsRepository) CountSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	var count int
+	err := vr.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users.email_verification_tokens
+		WHERE user_id = $1 AND created_date >= $2
+	`, userID, since)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения числа отправленных писем: %w", err)
+	}
+	return count, nil
+}
+
+// Verify погашает токен и отмечает email пользователя подтвержденным.
+// Возвращает ID пользователя.
+func (vr *EmailVerificationsRepository) Verify(ctx context.Context, tokenHash string) (int, error) {
+	tx, err := vr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var token struct {
+		ID        int        `db:"id"`
+		UserID    int        `db:"user_id"`
+		Email     string     `db:"email"`
+		ExpiresAt time.Time  `db:"expires_at"`
+		UsedDate  *time.Time `db:"used_date"`
+	}
+	err = tx.GetContext(ctx, &token, `
+		SELECT id, user_id, email, expires_at, used_date
+		FROM users.email_verification_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("ссылка для подтверждения email недействительна")
+		}
+		return 0, fmt.Errorf("ошибка получения токена подтверждения email: %w", err)
+	}
+	if token.UsedDate != nil {
+		return 0, fmt.Errorf("ссылка для подтверждения email недействительна")
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return 0, fmt.Errorf("срок действия ссылки для подтверждения email истек")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.email_verification_tokens SET used_date = NOW() WHERE id = $1
+	`, token.ID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка погашения токена подтверждения email: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users.users SET email_verified_at = COALESCE(email_verified_at, NOW())
+		WHERE id = $1 AND email = $2
+	`, token.UserID, token.Email)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения email: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка подтверждения email: %w", err)
+	}
+	if rowsAffected == 0 {
+		// Пользователь сменил адрес после отправки письма.
+		return 0, fmt.Errorf("ссылка для подтверждения email недействительна")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return token.UserID, nil
+}
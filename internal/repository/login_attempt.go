@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	loginFailuresKeyPrefix = "loginFailures"
+	loginLockKeyPrefix     = "loginLock"
+)
+
+// LoginAttemptsRepository считает в Redis неудачные попытки входа и хранит временные
+// блокировки. Субъект попытки — логин или IP-адрес, например "login:ivanov" или "ip:10.0.0.1".
+type LoginAttemptsRepository struct {
+	redis *redis.Client
+}
+
+func NewLoginAttemptsRepository(redis *redis.Client) *LoginAttemptsRepository {
+	return &LoginAttemptsRepository{redis: redis}
+}
+
+// LockedFor возвращает, сколько еще продлится самая долгая из блокировок субъектов.
+// Ноль означает, что ни один субъект не заблокирован.
+func (lr *LoginAttemptsRepository) LockedFor(ctx context.Context, subjects ...string) (time.Duration, error) {
+	pipe := lr.redis.Pipeline()
+	ttls := make([]*redis.DurationCmd, 0, len(subjects))
+	for _, subject := range subjects {
+		ttls = append(ttls, pipe.PTTL(ctx, fmt.Sprintf("%s:%s", loginLockKeyPrefix, subject)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("ошибка проверки блокировки входа: %w", err)
+	}
+	var lockedFor time.Duration
+	for _, ttl := range ttls {
+		// Для отсутствующего ключа PTTL возвращает отрицательное значение.
+		if ttl.Val() > lockedFor {
+			lockedFor = ttl.Val()
+		}
+	}
+	return lockedFor, nil
+}
+
+// RegisterFailure увеличивает счетчик неудачных попыток субъекта и возвращает его значение.
+// Счетчик сбрасывается, если в течение window не было новых неудачных попыток.
+func (lr *LoginAttemptsRepository) RegisterFailure(
+	ctx context.Context,
+	subject string,
+	window time.Duration,
+) (int, error) {
+	key := fmt.Sprintf("%s:%s", loginFailuresKeyPrefix, subject)
+	pipe := lr.redis.TxPipeline()
+	failures := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("ошибка учета неудачной попытки входа: %w", err)
+	}
+	return int(failures.Val()), nil
+}
+
+// Lock блокирует вход субъекта на duration.
+func (lr *LoginAttemptsRepository) Lock(ctx context.Context, subject string, duration time.Duration) error {
+	key := fmt.Sprintf("%s:%s", loginLockKeyPrefix, subject)
+	if err := lr.redis.Set(ctx, key, 1, duration).Err(); err != nil {
+		return fmt.Errorf("ошибка блокировки входа: %w", err)
+	}
+	return nil
+}
+
+// Reset снимает блокировку субъектов и обнуляет их счетчики неудачных попыток.
+func (lr *LoginAttemptsRepository) Reset(ctx context.Context, subjects ...string) error {
+	keys := make([]string, 0, 2*len(subjects))
+	for _, subject := range subjects {
+		keys = append(keys,
+			fmt.Sprintf("%s:%s", loginFailuresKeyPrefix, subject),
+			fmt.Sprintf("%s:%s", loginLockKeyPrefix, subject),
+		)
+	}
+	if err := lr.redis.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("ошибка снятия блокировки входа: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationsRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationsRepository(db *sqlx.DB) *NotificationsRepository {
+	return &NotificationsRepository{db: db}
+}
+
+func (nr *NotificationsRepository) Create(
+	ctx context.Context,
+	userID int,
+	kind model.NotificationKind,
+	message string,
+) (*model.Notification, error) {
+	var notification model.Notification
+	err := nr.db.QueryRowxContext(ctx, `
+		INSERT INTO users.notifications (user_id, kind, message)
+		VALUES ($1, $2, $3)
+		RETURNING *
+	`, userID, kind, message).StructScan(&notification)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания уведомления: %w", err)
+	}
+	return &notification, nil
+}
+
+// GetAll возвращает уведомления пользователя, начиная с новых.
+func (nr *NotificationsRepository) GetAll(ctx context.Context, userID int, unreadOnly bool) ([]model.Notification, error) {
+	notifications := []model.Notification{}
+	err := nr.db.SelectContext(ctx, &notifications, `
+		SELECT * FROM users.notifications
+		WHERE user_id = $1 AND (NOT read OR NOT $2)
+		ORDER BY created_date DESC, id DESC
+	`, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения уведомлений: %w", err)
+	}
+	return notifications, nil
+}
+
+func (nr *NotificationsRepository) MarkRead(ctx context.Context, id, userID int) (*model.Notification, error) {
+	var notification model.Notification
+	err := nr.db.QueryRowxContext(ctx, `
+		UPDATE users.notifications SET read = TRUE
+		WHERE id = $1 AND user_id = $2
+		RETURNING *
+	`, id, userID).StructScan(&notification)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("уведомление не найдено")
+		}
+		return nil, fmt.Errorf("ошибка изменения уведомления: %w", err)
+	}
+	return &notification, nil
+}
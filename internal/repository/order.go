@@ -71,7 +71,7 @@ func (or *OrdersRepository) GetAll(ctx context.Context, userID int, role model.U
 	builder.WriteString(query)
 	args := []interface{}{}
 
-	if role != model.RoleEmployee {
+	if role.IsClient() {
 		builder.WriteString(" WHERE o.user_id = $1")
 		args = append(args, userID)
 	}
@@ -91,8 +91,13 @@ func (or *OrdersRepository) GetAll(ctx context.Context, userID int, role model.U
 				p.id,
 				p.code,
 				p.name,
+				p.unit_code,
 				op.quantity,
-				op.sell_price
+				op.sell_price,
+				op.discount_amount,
+				op.vat_rate,
+				op.tax_base,
+				op.tax_amount
 			FROM orders.order_products op
 			JOIN products.products p ON op.product_id = p.id
 			WHERE op.order_id = $1
@@ -100,6 +105,7 @@ func (or *OrdersRepository) GetAll(ctx context.Context, userID int, role model.U
 		if err != nil {
 			return nil, fmt.Errorf("ошибка получения товаров для заказа %d: %w", orders[i].ID, err)
 		}
+		orders[i].TaxBreakdown = model.BuildTaxBreakdown(orders[i].Products)
 	}
 
 	return orders, nil
@@ -114,7 +120,7 @@ func (or *OrdersRepository) GetByID(ctx context.Context, id, userID int, role mo
 	args := []interface{}{id}
 
 	var builder strings.Builder
-	if role != model.RoleEmployee {
+	if role.IsClient() {
 		builder.WriteString(query)
 		builder.WriteString(" AND user_id = $2")
 		args = append(args, userID)
@@ -137,8 +143,13 @@ func (or *OrdersRepository) GetByID(ctx context.Context, id, userID int, role mo
 			p.id,
 			p.code,
 			p.name,
+			p.unit_code,
 			op.quantity,
-			op.sell_price
+			op.sell_price,
+			op.discount_amount,
+			op.vat_rate,
+			op.tax_base,
+			op.tax_amount
 		FROM orders.order_products op
 		JOIN products.products p ON op.product_id = p.id
 		WHERE op.order_id = $1
@@ -146,15 +157,16 @@ func (or *OrdersRepository) GetByID(ctx context.Context, id, userID int, role mo
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения товаров заказа: %w", err)
 	}
+	order.TaxBreakdown = model.BuildTaxBreakdown(order.Products)
 
 	return &order, nil
 }
 
-func (or *OrdersRepository) Delete(ctx context.Context, id, userID int) (*model.Order, error) {
+func (or *OrdersRepository) Delete(ctx context.Context, id, userID int, role model.UserRole) (*model.Order, error) {
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
-		order, err := or.tryDeleteOrder(ctx, id, userID)
+		order, err := or.tryDeleteOrder(ctx, id, userID, role)
 		if err == nil {
 			return order, nil
 		}
@@ -175,6 +187,7 @@ func (or *OrdersRepository) Update(
 	id int,
 	orderRequest model.OrderRequestBody,
 	userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
 	if len(orderRequest.Products) == 0 {
 		return nil, fmt.Errorf("список товаров не может быть пустым")
@@ -183,7 +196,7 @@ func (or *OrdersRepository) Update(
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
-		order, err := or.tryUpdateOrder(ctx, id, orderRequest, userID)
+		order, err := or.tryUpdateOrder(ctx, id, orderRequest, userID, role)
 		if err == nil {
 			return order, nil
 		}
@@ -204,11 +217,12 @@ func (or *OrdersRepository) UpdateStatus(
 	id int,
 	orderStatusRequest model.OrderStatusRequest,
 	userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
-		order, err := or.tryUpdateStatus(ctx, id, orderStatusRequest, userID)
+		order, err := or.tryUpdateStatus(ctx, id, orderStatusRequest, userID, role)
 		if err == nil {
 			return order, nil
 		}
@@ -224,6 +238,67 @@ func (or *OrdersRepository) UpdateStatus(
 	return nil, fmt.Errorf("не удалось обновить статус заказа после %d попыток: %w", maxRetries, lastErr)
 }
 
+// GetReorderLines возвращает строки заказа с текущими ценами по прайс-листу владельца заказа
+// и текущими остатками. Доступ к заказу должен быть проверен вызывающим.
+func (or *OrdersRepository) GetReorderLines(ctx context.Context, id int) ([]model.ReorderLine, error) {
+	lines := []model.ReorderLine{}
+	err := or.db.SelectContext(ctx, &lines, `
+		SELECT
+			op.product_id,
+			COALESCE(p.name, '') AS name,
+			op.quantity AS requested_quantity,
+			COALESCE(p.quantity, 0) AS available,
+			op.sell_price AS previous_price,
+			COALESCE(pricing.effective_price(p.id, o.user_id), 0) AS price,
+			p.id IS NULL AS deleted
+		FROM orders.order_products op
+		JOIN orders.orders o ON o.id = op.order_id
+		LEFT JOIN products.products p ON p.id = op.product_id
+		WHERE op.order_id = $1
+		ORDER BY op.product_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения товаров заказа: %w", err)
+	}
+	return lines, nil
+}
+
+// Ship сохраняет перевозчика и трек-номер отправленного заказа. Удаленный заказ отправить нельзя.
+func (or *OrdersRepository) Ship(ctx context.Context, id int, shipment model.ShipmentRequestBody) (*model.Order, error) {
+	tx, err := or.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status model.OrderStatus
+	err = tx.GetContext(ctx, &status, `
+		UPDATE orders.orders
+		SET carrier = $1,
+			tracking_number = $2,
+			shipped_date = COALESCE(shipped_date, NOW()),
+			last_modified_date = NOW()
+		WHERE id = $3 AND status <> $4
+		RETURNING status
+	`, shipment.Carrier, shipment.TrackingNumber, id, model.StatusDeleted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заказ не найден или удален")
+		}
+		return nil, fmt.Errorf("ошибка сохранения данных об отправке: %w", err)
+	}
+
+	order, err := or.getUpdatedOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return order, nil
+}
+
 func (or *OrdersRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
 	return WriteLog(result, operation, status, tableName, or.redis)
 }
@@ -241,6 +316,10 @@ func (or *OrdersRepository) tryCreateOrder(
 	}
 	defer tx.Rollback()
 
+	if err := toBaseQuantities(ctx, tx, request.Products); err != nil {
+		return nil, err
+	}
+
 	var order model.Order
 
 	// 1. Создаем запись заказа
@@ -249,27 +328,46 @@ func (or *OrdersRepository) tryCreateOrder(
 			user_id, 
 			order_number, 
 			status,
-			total_cost
+			total_cost,
+			discount_type,
+			discount_value,
+			tax_mode
 		) VALUES (
 			$1, 
 			(SELECT COALESCE(MAX(order_number), 0) + 1 FROM orders.orders),
 			'active',
-			0
+			0,
+			$2,
+			$3,
+			COALESCE($4, 'inclusive')
 		)
 		RETURNING id, order_number, status, created_date, last_modified_date, user_id
-	`, userID).StructScan(&order)
+	`, userID, request.DiscountType, request.DiscountValue, request.TaxMode).StructScan(&order)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка создания заказа: %w", err)
 	}
 
+	// Промокод применяем до добавления товаров, чтобы триггер сразу посчитал итог со скидкой
+	if request.PromoCode != "" {
+		if err := or.applyPromoCode(ctx, tx, order.ID, userID, request.PromoCode); err != nil {
+			return nil, err
+		}
+	}
+
+	// Доставку назначаем до добавления товаров по той же причине: итог пересчитывается при добавлении строк
+	if err := or.setOrderShipping(ctx, tx, order.ID, userID, request.AddressID, request.ShippingMethodID, true); err != nil {
+		return nil, err
+	}
+
 	// 2. Добавляем товары в заказ
 	for _, product := range request.Products {
 		log.Println(product.ProductID)
-		var available int
+		var available model.Quantity
 		var version int
+		var isBundle bool
 		err = tx.QueryRowContext(ctx, `
-			SELECT quantity, version FROM products.products WHERE id = $1
-		`, product.ProductID).Scan(&available, &version)
+			SELECT quantity, version, is_bundle FROM products.products WHERE id = $1
+		`, product.ProductID).Scan(&available, &version, &isBundle)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return nil, fmt.Errorf("товар с ID %d не найден", product.ProductID)
@@ -278,45 +376,45 @@ func (or *OrdersRepository) tryCreateOrder(
 		}
 
 		if available < product.Quantity {
-			return nil, fmt.Errorf("недостаточно товара с ID %d (доступно: %d)", product.ProductID, available)
-		}
-
-		res, err := tx.ExecContext(ctx, `
-			UPDATE products.products 
-			SET quantity = quantity - $1, version = version + 1 
-			WHERE id = $2 AND version = $3
-		`, product.Quantity, product.ProductID, version)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка обновления остатков: %w", err)
+			return nil, fmt.Errorf("недостаточно товара с ID %d (доступно: %s)", product.ProductID, available)
 		}
 
-		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
-			return nil, fmt.Errorf("конфликт версий товара %d (параллельное изменение)", product.ProductID)
+		if isBundle {
+			// Остаток комплекта пересчитает триггер после списания компонентов
+			if err := changeBundleStock(ctx, tx, product.ProductID, -product.Quantity); err != nil {
+				return nil, err
+			}
+		} else if err := or.decrementProductStock(ctx, tx, product, version); err != nil {
+			return nil, err
 		}
 
+		// Цену определяет прайс-лист владельца заказа, а не клиент.
 		_, err = tx.ExecContext(ctx, `
 			INSERT INTO orders.order_products (
 				order_id, 
 				product_id, 
 				quantity, 
-				sell_price
-			) VALUES ($1, $2, $3, $4)
-		`, order.ID, product.ProductID, product.Quantity, product.SellPrice)
+				sell_price,
+				discount_type,
+				discount_value,
+				vat_rate
+			) VALUES (
+				$1, $2, $3, pricing.effective_price($2, $4), $5, $6,
+				(SELECT vat_rate FROM products.products WHERE id = $2)
+			)
+		`, order.ID, product.ProductID, product.Quantity, userID, product.DiscountType, product.DiscountValue)
 		if err != nil {
 			return nil, fmt.Errorf("ошибка добавления товара в заказ: %w", err)
 		}
 	}
 
+	if err := or.setOrderCurrency(ctx, tx, order.ID, userID); err != nil {
+		return nil, err
+	}
+
 	// 3. Получаем полные данные заказа
 	err = tx.GetContext(ctx, &order, `
-		SELECT 
-			id, 
-			order_number, 
-			total_cost, 
-			created_date, 
-			last_modified_date, 
-			status,
-			user_id
+		SELECT *
 		FROM orders.orders 
 		WHERE id = $1
 	`, order.ID)
@@ -331,8 +429,13 @@ func (or *OrdersRepository) tryCreateOrder(
 			p.id,
 			p.code,
 			p.name,
+			p.unit_code,
 			op.quantity,
-			op.sell_price
+			op.sell_price,
+			op.discount_amount,
+			op.vat_rate,
+			op.tax_base,
+			op.tax_amount
 		FROM orders.order_products op
 		JOIN products.products p ON op.product_id = p.id
 		WHERE op.order_id = $1
@@ -342,6 +445,7 @@ func (or *OrdersRepository) tryCreateOrder(
 	}
 
 	order.Products = products
+	order.TaxBreakdown = model.BuildTaxBreakdown(products)
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
@@ -355,6 +459,7 @@ func (or *OrdersRepository) tryUpdateOrder(
 	id int,
 	orderRequest model.OrderRequestBody,
 	userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
 	tx, err := or.db.BeginTxx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
@@ -364,26 +469,66 @@ func (or *OrdersRepository) tryUpdateOrder(
 	}
 	defer tx.Rollback()
 
-	order, err := or.getOrderForUpdate(ctx, tx, id, userID)
+	order, err := or.getOrderForUpdate(ctx, tx, id, userID, role)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := toBaseQuantities(ctx, tx, orderRequest.Products); err != nil {
+		return nil, err
+	}
+
 	currentProducts, err := or.getCurrentOrderProducts(ctx, tx, order.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = or.processProductChanges(ctx, tx, order.ID, currentProducts, orderRequest.Products)
+	err = or.processProductChanges(ctx, tx, order, currentProducts, orderRequest.Products)
 	if err != nil {
 		return nil, err
 	}
 
-	err = or.addNewProducts(ctx, tx, order.ID, currentProducts, orderRequest.Products)
+	err = or.addNewProducts(ctx, tx, order, currentProducts, orderRequest.Products)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderRequest.DiscountType != nil {
+		// Ручная скидка заменяет промокод, его использование возвращается.
+		if err := or.releasePromoCode(ctx, tx, order.ID); err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE orders.orders
+			SET discount_type = $1, discount_value = $2, promo_code_id = NULL
+			WHERE id = $3
+		`, orderRequest.DiscountType, orderRequest.DiscountValue, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка установки скидки на заказ: %w", err)
+		}
+	}
+
+	if orderRequest.TaxMode != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE orders.orders SET tax_mode = $1 WHERE id = $2", orderRequest.TaxMode, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка установки режима НДС: %w", err)
+		}
+	}
+
+	err = or.setOrderShipping(ctx, tx, order.ID, order.UserID, orderRequest.AddressID, orderRequest.ShippingMethodID, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := or.setOrderCurrency(ctx, tx, order.ID, order.UserID); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "SELECT orders.recalculate_order_total($1)", order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка пересчета суммы заказа: %w", err)
+	}
+
 	err = or.updateOrderModifiedDate(ctx, tx, order.ID)
 	if err != nil {
 		return nil, err
@@ -401,18 +546,18 @@ func (or *OrdersRepository) tryUpdateOrder(
 	return updatedOrder, nil
 }
 
+// getOrderForUpdate блокирует заказ для изменения. Сотрудник может изменять любой заказ,
+// клиент — только свой.
 func (or *OrdersRepository) getOrderForUpdate(
 	ctx context.Context,
 	tx *sqlx.Tx,
 	id, userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
+	query, args := ownedOrderQuery("SELECT * FROM orders.orders WHERE id = $1", id, userID, role)
+
 	var order model.Order
-	err := tx.GetContext(ctx, &order, `
-		SELECT *
-		FROM orders.orders 
-		WHERE id = $1 AND user_id = $2
-		FOR UPDATE
-	`, id, userID)
+	err := tx.GetContext(ctx, &order, query+" FOR UPDATE", args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("заказ не найден или не принадлежит пользователю")
@@ -429,7 +574,7 @@ func (or *OrdersRepository) getCurrentOrderProducts(
 ) ([]model.OrderProduct, error) {
 	var products []model.OrderProduct
 	err := tx.SelectContext(ctx, &products, `
-		SELECT product_id, quantity, sell_price
+		SELECT product_id, quantity, sell_price, discount_type, discount_value
 		FROM orders.order_products
 		WHERE order_id = $1
 	`, orderID)
@@ -442,10 +587,11 @@ func (or *OrdersRepository) getCurrentOrderProducts(
 func (or *OrdersRepository) processProductChanges(
 	ctx context.Context,
 	tx *sqlx.Tx,
-	orderID int,
+	order *model.Order,
 	currentProducts []model.OrderProduct,
 	newProducts []model.OrderProduct,
 ) error {
+	orderID := order.ID
 	oldProductsMap := make(map[int]model.OrderProduct)
 	for _, p := range currentProducts {
 		oldProductsMap[p.ProductID] = p
@@ -462,17 +608,12 @@ func (or *OrdersRepository) processProductChanges(
 
 		// Товар удален из заказа - возвращаем остатки
 		if !exists {
-			_, err := tx.ExecContext(ctx, `
-				UPDATE products.products
-				SET quantity = quantity + $1
-				WHERE id = $2
-			`, oldProduct.Quantity, productID)
-			if err != nil {
+			if err := changeStock(ctx, tx, productID, oldProduct.Quantity); err != nil {
 				return fmt.Errorf("ошибка возврата товара %d: %w", productID, err)
 			}
 
 			// Удаляем товар из заказа
-			_, err = tx.ExecContext(ctx, `
+			_, err := tx.ExecContext(ctx, `
 				DELETE FROM orders.order_products
 				WHERE order_id = $1 AND product_id = $2
 			`, orderID, productID)
@@ -485,21 +626,21 @@ func (or *OrdersRepository) processProductChanges(
 		// Количество изменилось - корректируем остатки
 		if oldProduct.Quantity != newProduct.Quantity {
 			diff := oldProduct.Quantity - newProduct.Quantity
-			_, err := tx.ExecContext(ctx, `
-				UPDATE products.products
-				SET quantity = quantity + $1
-				WHERE id = $2
-			`, diff, productID)
-			if err != nil {
+			if err := changeStock(ctx, tx, productID, diff); err != nil {
 				return fmt.Errorf("ошибка обновления количества товара %d: %w", productID, err)
 			}
+		}
 
-			// Обновляем количество в заказе
-			_, err = tx.ExecContext(ctx, `
+		// Обновляем строку заказа, цену пересчитываем по прайс-листу владельца заказа
+		if oldProduct.Quantity != newProduct.Quantity || !sameDiscount(oldProduct, newProduct) {
+			_, err := tx.ExecContext(ctx, `
 				UPDATE orders.order_products
-				SET quantity = $1, sell_price = $2
+				SET quantity = $1,
+					sell_price = pricing.effective_price($4, $2),
+					discount_type = $5,
+					discount_value = $6
 				WHERE order_id = $3 AND product_id = $4
-			`, newProduct.Quantity, newProduct.SellPrice, orderID, productID)
+			`, newProduct.Quantity, order.UserID, orderID, productID, newProduct.DiscountType, newProduct.DiscountValue)
 			if err != nil {
 				return fmt.Errorf("ошибка обновления товара %d в заказе: %w", productID, err)
 			}
@@ -511,10 +652,11 @@ func (or *OrdersRepository) processProductChanges(
 func (or *OrdersRepository) addNewProducts(
 	ctx context.Context,
 	tx *sqlx.Tx,
-	orderID int,
+	order *model.Order,
 	currentProducts []model.OrderProduct,
 	newProducts []model.OrderProduct,
 ) error {
+	orderID := order.ID
 	oldProductsMap := make(map[int]model.OrderProduct)
 	for _, p := range currentProducts {
 		oldProductsMap[p.ProductID] = p
@@ -523,7 +665,7 @@ func (or *OrdersRepository) addNewProducts(
 	for _, newProduct := range newProducts {
 		if _, exists := oldProductsMap[newProduct.ProductID]; !exists {
 			// Проверяем доступность товара
-			var available int
+			var available model.Quantity
 			err := tx.GetContext(ctx, &available, `
 				SELECT quantity FROM products.products WHERE id = $1
 			`, newProduct.ProductID)
@@ -532,16 +674,12 @@ func (or *OrdersRepository) addNewProducts(
 			}
 
 			if available < newProduct.Quantity {
-				return fmt.Errorf("недостаточно товара %d (доступно: %d, требуется: %d)",
+				return fmt.Errorf("недостаточно товара %d (доступно: %s, требуется: %s)",
 					newProduct.ProductID, available, newProduct.Quantity)
 			}
 
 			// Резервируем товар
-			_, err = tx.ExecContext(ctx, `
-				UPDATE products.products
-				SET quantity = quantity - $1
-				WHERE id = $2
-			`, newProduct.Quantity, newProduct.ProductID)
+			err = changeStock(ctx, tx, newProduct.ProductID, -newProduct.Quantity)
 			if err != nil {
 				return fmt.Errorf("ошибка резервирования товара %d: %w", newProduct.ProductID, err)
 			}
@@ -549,9 +687,13 @@ func (or *OrdersRepository) addNewProducts(
 			// Добавляем в заказ
 			_, err = tx.ExecContext(ctx, `
 				INSERT INTO orders.order_products
-				(order_id, product_id, quantity, sell_price)
-				VALUES ($1, $2, $3, $4)
-			`, orderID, newProduct.ProductID, newProduct.Quantity, newProduct.SellPrice)
+				(order_id, product_id, quantity, sell_price, discount_type, discount_value, vat_rate)
+				VALUES (
+					$1, $2, $3, pricing.effective_price($2, $4), $5, $6,
+					(SELECT vat_rate FROM products.products WHERE id = $2)
+				)
+			`, orderID, newProduct.ProductID, newProduct.Quantity, order.UserID,
+				newProduct.DiscountType, newProduct.DiscountValue)
 			if err != nil {
 				return fmt.Errorf("ошибка добавления товара %d: %w", newProduct.ProductID, err)
 			}
@@ -586,7 +728,8 @@ func (or *OrdersRepository) getUpdatedOrder(ctx context.Context, tx *sqlx.Tx, or
 
 	var products []model.Product
 	err = tx.SelectContext(ctx, &products, `
-		SELECT p.id, p.code, p.name, op.quantity, op.sell_price
+		SELECT p.id, p.code, p.name, p.unit_code, op.quantity, op.sell_price, op.discount_amount,
+			op.vat_rate, op.tax_base, op.tax_amount
 		FROM orders.order_products op
 		JOIN products.products p ON op.product_id = p.id
 		WHERE op.order_id = $1
@@ -596,10 +739,15 @@ func (or *OrdersRepository) getUpdatedOrder(ctx context.Context, tx *sqlx.Tx, or
 	}
 
 	order.Products = products
+	order.TaxBreakdown = model.BuildTaxBreakdown(products)
 	return &order, nil
 }
 
-func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID int) (*model.Order, error) {
+func (or *OrdersRepository) tryDeleteOrder(
+	ctx context.Context,
+	orderID, userID int,
+	role model.UserRole,
+) (*model.Order, error) {
 	tx, err := or.db.BeginTxx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
 	})
@@ -609,19 +757,11 @@ func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID
 	defer tx.Rollback()
 
 	// 1. Получаем заказ
-	var order model.Order
-	err = tx.GetContext(ctx, &order, `
-		SELECT *
-		FROM orders.orders 
-		WHERE id = $1 AND user_id = $2
-		FOR UPDATE
-	`, orderID, userID)
+	lockedOrder, err := or.getOrderForUpdate(ctx, tx, orderID, userID, role)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("заказ не найден или не принадлежит пользователю")
-		}
-		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+		return nil, err
 	}
+	order := *lockedOrder
 
 	// 2. Если заказ уже удален, просто возвращаем его
 	if order.Status.Key == "deleted" {
@@ -643,18 +783,18 @@ func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID
 
 		// Возвращаем каждый товар на склад
 		for _, product := range products {
-			_, err = tx.ExecContext(ctx, `
-				UPDATE products.products
-				SET quantity = quantity + $1
-				WHERE id = $2
-			`, product.Quantity, product.ProductID)
-			if err != nil {
+			if err := changeStock(ctx, tx, product.ProductID, product.Quantity); err != nil {
 				return nil, fmt.Errorf("ошибка возврата товара %d: %w", product.ProductID, err)
 			}
 		}
 	}
 
-	// 4. Помечаем заказ как удаленный
+	// 4. Возвращаем использование промокода: удаленный заказ не должен расходовать его лимиты
+	if err := or.releasePromoCode(ctx, tx, order.ID); err != nil {
+		return nil, err
+	}
+
+	// 5. Помечаем заказ как удаленный
 	_, err = tx.ExecContext(ctx, `
 		UPDATE orders.orders
 		SET status = 'deleted',
@@ -665,7 +805,7 @@ func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID
 		return nil, fmt.Errorf("ошибка обновления статуса заказа: %w", err)
 	}
 
-	// 5. Получаем обновленный заказ
+	// 6. Получаем обновленный заказ
 	err = tx.GetContext(ctx, &order, `
 		SELECT *
 		FROM orders.orders 
@@ -675,10 +815,11 @@ func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID
 		return nil, fmt.Errorf("ошибка получения обновленного заказа: %w", err)
 	}
 
-	// 6. Получаем товары заказа (для возврата в ответе и последующего логирования)
+	// 7. Получаем товары заказа (для возврата в ответе и последующего логирования)
 	var products []model.Product
 	err = tx.SelectContext(ctx, &products, `
-		SELECT p.id, p.code, p.name, op.quantity, op.sell_price
+		SELECT p.id, p.code, p.name, p.unit_code, op.quantity, op.sell_price, op.discount_amount,
+			op.vat_rate, op.tax_base, op.tax_amount
 		FROM orders.order_products op
 		JOIN products.products p ON op.product_id = p.id
 		WHERE op.order_id = $1
@@ -688,6 +829,7 @@ func (or *OrdersRepository) tryDeleteOrder(ctx context.Context, orderID, userID
 	}
 
 	order.Products = products
+	order.TaxBreakdown = model.BuildTaxBreakdown(products)
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
@@ -701,6 +843,7 @@ func (or *OrdersRepository) tryUpdateStatus(
 	id int,
 	orderStatusRequest model.OrderStatusRequest,
 	userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
 	tx, err := or.db.BeginTxx(ctx, &sql.TxOptions{
 		Isolation: sql.LevelSerializable,
@@ -711,16 +854,14 @@ func (or *OrdersRepository) tryUpdateStatus(
 	defer tx.Rollback()
 
 	// 1. Получаем текущий заказ с блокировкой
-	var order model.Order
-	query := `
-		SELECT id, order_number, status, user_id 
-		FROM orders.orders 
+	query, args := ownedOrderQuery(`
+		SELECT id, order_number, status, user_id
+		FROM orders.orders
 		WHERE id = $1
-		AND user_id = $2
-		FOR UPDATE
-	`
+	`, id, userID, role)
 
-	err = tx.GetContext(ctx, &order, query, id, userID)
+	var order model.Order
+	err = tx.GetContext(ctx, &order, query+" FOR UPDATE", args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("заказ не найден или доступ запрещен")
@@ -746,8 +887,7 @@ func (or *OrdersRepository) tryUpdateStatus(
 
 	// 4. Получаем обновленный заказ с товарами
 	err = tx.GetContext(ctx, &order, `
-		SELECT id, order_number, status, total_cost, 
-			created_date, last_modified_date, user_id
+		SELECT *
 		FROM orders.orders 
 		WHERE id = $1
 	`, id)
@@ -756,7 +896,8 @@ func (or *OrdersRepository) tryUpdateStatus(
 	}
 
 	err = tx.SelectContext(ctx, &order.Products, `
-		SELECT p.id, p.code, p.name, op.quantity, op.sell_price
+		SELECT p.id, p.code, p.name, p.unit_code, op.quantity, op.sell_price, op.discount_amount,
+			op.vat_rate, op.tax_base, op.tax_amount
 		FROM orders.order_products op
 		JOIN products.products p ON op.product_id = p.id
 		WHERE op.order_id = $1
@@ -764,6 +905,7 @@ func (or *OrdersRepository) tryUpdateStatus(
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения товаров: %w", err)
 	}
+	order.TaxBreakdown = model.BuildTaxBreakdown(order.Products)
 
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
@@ -772,6 +914,326 @@ func (or *OrdersRepository) tryUpdateStatus(
 	return &order, nil
 }
 
+// ownedOrderQuery ограничивает выборку заказа по id заказами пользователя, если он не сотрудник.
+func ownedOrderQuery(query string, orderID, userID int, role model.UserRole) (string, []any) {
+	args := []any{orderID}
+	if role.IsClient() {
+		query += " AND user_id = $2"
+		args = append(args, userID)
+	}
+	return query, args
+}
+
+// toBaseQuantities переводит количества строк заказа в базовые единицы измерения товаров
+// и проверяет, что количество не дробнее, чем допускает единица измерения.
+func toBaseQuantities(ctx context.Context, q sqlx.QueryerContext, products []model.OrderProduct) error {
+	for i := range products {
+		product := &products[i]
+		if product.Quantity <= 0 {
+			return fmt.Errorf("количество товара %d должно быть положительным", product.ProductID)
+		}
+
+		var unit struct {
+			UnitCode     string          `db:"unit_code"`
+			PackUnitCode *string         `db:"pack_unit_code"`
+			PackSize     *model.Quantity `db:"pack_size"`
+			Precision    int             `db:"precision"`
+		}
+		err := sqlx.GetContext(ctx, q, &unit, `
+			SELECT p.unit_code, p.pack_unit_code, p.pack_size, u.precision
+			FROM products.products p
+			JOIN products.units u ON u.code = p.unit_code
+			WHERE p.id = $1
+		`, product.ProductID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("товар с ID %d не найден", product.ProductID)
+			}
+			return fmt.Errorf("ошибка получения единицы измерения товара %d: %w", product.ProductID, err)
+		}
+
+		switch {
+		case product.Unit == "" || product.Unit == unit.UnitCode:
+		case unit.PackUnitCode != nil && product.Unit == *unit.PackUnitCode:
+			if !product.Quantity.IsWhole() {
+				return fmt.Errorf("количество упаковок товара %d должно быть целым", product.ProductID)
+			}
+			quantity, err := product.Quantity.Mul(*unit.PackSize)
+			if err != nil {
+				return fmt.Errorf("количество товара %d: %w", product.ProductID, err)
+			}
+			product.Quantity = quantity
+		default:
+			return fmt.Errorf("единица измерения %s не применима к товару %d", product.Unit, product.ProductID)
+		}
+		product.Unit = unit.UnitCode
+
+		if !product.Quantity.FitsPrecision(unit.Precision) {
+			return fmt.Errorf("количество товара %d должно иметь не более %d знаков после запятой",
+				product.ProductID, unit.Precision)
+		}
+	}
+	return nil
+}
+
+// decrementProductStock списывает остаток обычного товара с проверкой версии (оптимистичная блокировка).
+func (or *OrdersRepository) decrementProductStock(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	product model.OrderProduct,
+	version int,
+) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE products.products 
+		SET quantity = quantity - $1, version = version + 1 
+		WHERE id = $2 AND version = $3
+	`, product.Quantity, product.ProductID, version)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления остатков: %w", err)
+	}
+
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("конфликт версий товара %d (параллельное изменение)", product.ProductID)
+	}
+	return nil
+}
+
+// changeStock изменяет остаток товара на delta (отрицательное значение — списание).
+// Для комплекта изменяются остатки компонентов.
+func changeStock(ctx context.Context, tx *sqlx.Tx, productID int, delta model.Quantity) error {
+	var isBundle bool
+	err := tx.GetContext(ctx, &isBundle, "SELECT is_bundle FROM products.products WHERE id = $1", productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("товар с ID %d не найден", productID)
+		}
+		return err
+	}
+	if isBundle {
+		return changeBundleStock(ctx, tx, productID, delta)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE products.products
+		SET quantity = quantity + $1
+		WHERE id = $2
+	`, delta, productID)
+	return err
+}
+
+// changeBundleStock изменяет остатки компонентов комплекта пропорционально их количеству в комплекте.
+func changeBundleStock(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	bundleID int,
+	delta model.Quantity,
+) error {
+	var shortage []int
+	err := tx.SelectContext(ctx, &shortage, `
+		SELECT bc.component_id
+		FROM products.bundle_components bc
+		JOIN products.products p ON p.id = bc.component_id
+		WHERE bc.bundle_id = $1 AND p.quantity + $2 * bc.quantity < 0
+	`, bundleID, delta)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки компонентов комплекта %d: %w", bundleID, err)
+	}
+	if len(shortage) > 0 {
+		return fmt.Errorf("недостаточно компонента %d для комплекта %d", shortage[0], bundleID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE products.products p
+		SET quantity = p.quantity + $2 * bc.quantity, version = p.version + 1
+		FROM products.bundle_components bc
+		WHERE bc.bundle_id = $1 AND p.id = bc.component_id
+	`, bundleID, delta)
+	if err != nil {
+		return fmt.Errorf("ошибка изменения остатков компонентов комплекта %d: %w", bundleID, err)
+	}
+	return nil
+}
+
+// applyPromoCode атомарно проверяет ограничения промокода, увеличивает счетчик использований
+// и устанавливает скидку на заказ.
+func (or *OrdersRepository) applyPromoCode(ctx context.Context, tx *sqlx.Tx, orderID, userID int, code string) error {
+	var promo model.PromoCode
+	err := tx.GetContext(ctx, &promo, `
+		UPDATE orders.promo_codes pc
+		SET used_count = used_count + 1
+		WHERE pc.code = $1
+		AND pc.active
+		AND (pc.valid_from IS NULL OR pc.valid_from <= NOW())
+		AND (pc.valid_to IS NULL OR pc.valid_to > NOW())
+		AND (pc.max_uses IS NULL OR pc.used_count < pc.max_uses)
+		AND (pc.max_uses_per_user IS NULL OR (
+			SELECT COUNT(*) FROM orders.promo_code_usages u
+			WHERE u.promo_code_id = pc.id AND u.user_id = $2
+		) < pc.max_uses_per_user)
+		RETURNING *
+	`, code, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("промокод %s недействителен или исчерпан", code)
+		}
+		return fmt.Errorf("ошибка применения промокода: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO orders.promo_code_usages (promo_code_id, order_id, user_id)
+		VALUES ($1, $2, $3)
+	`, promo.ID, orderID, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка записи использования промокода: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE orders.orders
+		SET discount_type = $1, discount_value = $2, promo_code_id = $3
+		WHERE id = $4
+	`, promo.DiscountType, promo.DiscountValue, promo.ID, orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка установки скидки по промокоду: %w", err)
+	}
+	return nil
+}
+
+// releasePromoCode возвращает использование промокода, примененного к заказу: удаляет запись
+// об использовании и уменьшает счетчик. Если промокод уже возвращен, ничего не делает.
+func (or *OrdersRepository) releasePromoCode(ctx context.Context, tx *sqlx.Tx, orderID int) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH released AS (
+			DELETE FROM orders.promo_code_usages WHERE order_id = $1
+			RETURNING promo_code_id
+		)
+		UPDATE orders.promo_codes pc
+		SET used_count = GREATEST(pc.used_count - 1, 0)
+		FROM released r
+		WHERE pc.id = r.promo_code_id
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка возврата использования промокода: %w", err)
+	}
+	return nil
+}
+
+// setOrderShipping назначает заказу адрес из адресной книги владельца и способ доставки.
+// Адрес сохраняется в заказе строкой, чтобы последующие правки адресной книги не меняли
+// уже оформленные заказы. При создании заказа без адреса используется адрес по умолчанию.
+func (or *OrdersRepository) setOrderShipping(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	orderID, ownerID int,
+	addressID, methodID *int,
+	useDefaultAddress bool,
+) error {
+	if addressID != nil || useDefaultAddress {
+		query := "SELECT * FROM users.addresses WHERE user_id = $1 AND is_default"
+		args := []any{ownerID}
+		if addressID != nil {
+			query = "SELECT * FROM users.addresses WHERE user_id = $1 AND id = $2"
+			args = append(args, *addressID)
+		}
+		var address model.Address
+		err := tx.GetContext(ctx, &address, query, args...)
+		switch {
+		case errors.Is(err, sql.ErrNoRows) && addressID == nil:
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("адрес доставки не найден")
+		case err != nil:
+			return fmt.Errorf("ошибка получения адреса доставки: %w", err)
+		default:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE orders.orders SET address_id = $1, shipping_address = $2 WHERE id = $3
+			`, address.ID, address.String(), orderID)
+			if err != nil {
+				return fmt.Errorf("ошибка установки адреса доставки: %w", err)
+			}
+		}
+	}
+
+	if methodID != nil {
+		var active bool
+		err := tx.GetContext(ctx, &active, "SELECT active FROM orders.shipping_methods WHERE id = $1", *methodID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && !active) {
+			return fmt.Errorf("способ доставки %d не найден или недоступен", *methodID)
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка проверки способа доставки: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE orders.orders SET shipping_method_id = $1 WHERE id = $2", *methodID, orderID)
+		if err != nil {
+			return fmt.Errorf("ошибка установки способа доставки: %w", err)
+		}
+	}
+	return nil
+}
+
+// setOrderCurrency определяет валюту заказа по ценам его строк. Товары в разных
+// валютах в одном заказе не допускаются, фиксированная скидка промокода и способ
+// доставки должны быть в той же валюте, что и заказ.
+func (or *OrdersRepository) setOrderCurrency(ctx context.Context, tx *sqlx.Tx, orderID, userID int) error {
+	var currencies []string
+	err := tx.SelectContext(ctx, &currencies, `
+		SELECT DISTINCT pricing.effective_currency(product_id, $2)
+		FROM orders.order_products
+		WHERE order_id = $1
+	`, orderID, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка определения валюты заказа: %w", err)
+	}
+	if len(currencies) == 0 {
+		return nil
+	}
+	if len(currencies) > 1 {
+		return fmt.Errorf("заказ не может содержать товары в разных валютах: %s", strings.Join(currencies, ", "))
+	}
+
+	var promoCurrency sql.NullString
+	err = tx.GetContext(ctx, &promoCurrency, `
+		SELECT pc.currency
+		FROM orders.orders o
+		LEFT JOIN orders.promo_codes pc ON pc.id = o.promo_code_id AND pc.discount_type = $2
+		WHERE o.id = $1
+	`, orderID, model.DiscountFixed)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки валюты промокода: %w", err)
+	}
+	if promoCurrency.Valid && promoCurrency.String != currencies[0] {
+		return fmt.Errorf("промокод со скидкой в %s нельзя применить к заказу в валюте %s",
+			promoCurrency.String, currencies[0])
+	}
+
+	var shippingCurrency sql.NullString
+	err = tx.GetContext(ctx, &shippingCurrency, `
+		SELECT sm.currency
+		FROM orders.orders o
+		LEFT JOIN orders.shipping_methods sm ON sm.id = o.shipping_method_id
+		WHERE o.id = $1
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки валюты доставки: %w", err)
+	}
+	if shippingCurrency.Valid && shippingCurrency.String != currencies[0] {
+		return fmt.Errorf("способ доставки в валюте %s нельзя применить к заказу в валюте %s",
+			shippingCurrency.String, currencies[0])
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE orders.orders SET currency = $1 WHERE id = $2", currencies[0], orderID)
+	if err != nil {
+		return fmt.Errorf("ошибка установки валюты заказа: %w", err)
+	}
+	return nil
+}
+
+func sameDiscount(a, b model.OrderProduct) bool {
+	sameType := (a.DiscountType == nil && b.DiscountType == nil) ||
+		(a.DiscountType != nil && b.DiscountType != nil && *a.DiscountType == *b.DiscountType)
+	sameValue := (a.DiscountValue == nil && b.DiscountValue == nil) ||
+		(a.DiscountValue != nil && b.DiscountValue != nil && *a.DiscountValue == *b.DiscountValue)
+	return sameType && sameValue
+}
+
 // Пока единственный допустимый переход active -> executed,
 // но так хотя бы можно расширить варианты переходов, если добавятся новые статусы.
 func isValidStatusTransition(oldStatus, newStatus model.OrderStatus) bool {
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+// releasePromoCodeQuery фрагмент запроса, возвращающего использование промокода заказа.
+var releasePromoCodeQuery = regexp.QuoteMeta("DELETE FROM orders.promo_code_usages WHERE order_id = $1")
+
+func newOrdersRepository(t *testing.T) (*OrdersRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Ошибка создания sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewOrdersRepository(sqlx.NewDb(db, "postgres"), nil, ""), mock
+}
+
+func TestOrdersRepository_releasePromoCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  error
+		wantErr bool
+	}{
+		{name: "usage released", wantErr: false},
+		{name: "database failure", result: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			or, mock := newOrdersRepository(t)
+			mock.ExpectBegin()
+			exec := mock.ExpectExec(releasePromoCodeQuery).WithArgs(7)
+			if tt.result != nil {
+				exec.WillReturnError(tt.result)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectRollback()
+
+			tx, err := or.db.BeginTxx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Ошибка начала транзакции: %v", err)
+			}
+			err = or.releasePromoCode(context.Background(), tx, 7)
+			_ = tx.Rollback()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("releasePromoCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrdersRepository_tryDeleteOrder(t *testing.T) {
+	orderRow := func(status string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(7, 1, status)
+	}
+	lockOrder := regexp.QuoteMeta("SELECT * FROM orders.orders WHERE id = $1 FOR UPDATE")
+
+	tests := []struct {
+		name       string
+		mock       func(mock sqlmock.Sqlmock)
+		wantStatus model.OrderStatus
+		wantErr    bool
+	}{
+		{
+			name: "promo code usage is released before the order is deleted",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOrder).WithArgs(7).WillReturnRows(orderRow("executed"))
+				mock.ExpectExec(releasePromoCodeQuery).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("SET status = 'deleted'")).WithArgs(7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM orders.orders")).WithArgs(7).
+					WillReturnRows(orderRow("deleted"))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM orders.order_products op")).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "quantity", "sell_price"}))
+				mock.ExpectCommit()
+			},
+			wantStatus: model.StatusDeleted,
+		},
+		{
+			name: "deleted order does not release the usage twice",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOrder).WithArgs(7).WillReturnRows(orderRow("deleted"))
+				mock.ExpectRollback()
+			},
+			wantStatus: model.StatusDeleted,
+		},
+		{
+			name: "order is not deleted if the usage is not released",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOrder).WithArgs(7).WillReturnRows(orderRow("executed"))
+				mock.ExpectExec(releasePromoCodeQuery).WithArgs(7).WillReturnError(errors.New("connection refused"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			or, mock := newOrdersRepository(t)
+			tt.mock(mock)
+
+			order, err := or.tryDeleteOrder(context.Background(), 7, 1, model.RoleEmployee)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tryDeleteOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && order.Status != tt.wantStatus {
+				t.Errorf("Статус заказа = %v, want %v", order.Status, tt.wantStatus)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrdersRepository_setOrderShipping(t *testing.T) {
+	defaultAddress := regexp.QuoteMeta("SELECT * FROM users.addresses WHERE user_id = $1 AND is_default")
+	chosenAddress := regexp.QuoteMeta("SELECT * FROM users.addresses WHERE user_id = $1 AND id = $2")
+	checkMethod := regexp.QuoteMeta("SELECT active FROM orders.shipping_methods WHERE id = $1")
+	addressRow := sqlmock.NewRows([]string{"id", "user_id", "recipient", "phone", "postal_code", "city", "street"}).
+		AddRow(3, 1, "Иван Петров", "+79990000000", "101000", "Москва", "ул. Мира, 1")
+	addressID, methodID := 3, 2
+
+	tests := []struct {
+		name              string
+		addressID         *int
+		methodID          *int
+		useDefaultAddress bool
+		mock              func(mock sqlmock.Sqlmock)
+		wantErr           string
+	}{
+		{
+			name:              "default address is copied into the order",
+			useDefaultAddress: true,
+			methodID:          &methodID,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(defaultAddress).WithArgs(1).WillReturnRows(addressRow)
+				mock.ExpectExec(regexp.QuoteMeta("SET address_id = $1, shipping_address = $2")).
+					WithArgs(3, "101000, Москва, ул. Мира, 1, Иван Петров, +79990000000", 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(checkMethod).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+				mock.ExpectExec(regexp.QuoteMeta("SET shipping_method_id = $1")).WithArgs(2, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:              "no default address",
+			useDefaultAddress: true,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(defaultAddress).WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:      "foreign address",
+			addressID: &addressID,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(chosenAddress).WithArgs(1, 3).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: "адрес доставки не найден",
+		},
+		{
+			name:     "inactive shipping method",
+			methodID: &methodID,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkMethod).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+			},
+			wantErr: "способ доставки 2 не найден или недоступен",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			or, mock := newOrdersRepository(t)
+			mock.ExpectBegin()
+			tt.mock(mock)
+			mock.ExpectRollback()
+
+			tx, err := or.db.BeginTxx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Ошибка начала транзакции: %v", err)
+			}
+			err = or.setOrderShipping(context.Background(), tx, 7, 1, tt.addressID, tt.methodID, tt.useDefaultAddress)
+			_ = tx.Rollback()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("setOrderShipping() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("setOrderShipping() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PasswordResetsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPasswordResetsRepository(db *sqlx.DB) *PasswordResetsRepository {
+	return &PasswordResetsRepository{db: db}
+}
+
+// Create сохраняет токен сброса пароля. Ранее выданные пользователю токены
+// погашаются, чтобы действовала только ссылка из последнего письма.
+func (pr *PasswordResetsRepository) Create(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM users.password_reset_tokens WHERE user_id = $1 AND expires_at < NOW()
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления истекших токенов сброса пароля: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.password_reset_tokens SET used_date = NOW()
+		WHERE user_id = $1 AND used_date IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка погашения токенов сброса пароля: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users.password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена сброса пароля: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// Reset погашает токен и устанавливает пользователю новый хеш пароля.
+// Возвращает ID пользователя, чей пароль изменен.
+func (pr *PasswordResetsRepository) Reset(ctx context.Context, tokenHash, passwordHash string) (int, error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var token struct {
+		ID        int        `db:"id"`
+		UserID    int        `db:"user_id"`
+		ExpiresAt time.Time  `db:"expires_at"`
+		UsedDate  *time.Time `db:"used_date"`
+	}
+	err = tx.GetContext(ctx, &token, `
+		SELECT id, user_id, expires_at, used_date
+		FROM users.password_reset_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("ссылка для сброса пароля недействительна")
+		}
+		return 0, fmt.Errorf("ошибка получения токена сброса пароля: %w", err)
+	}
+	if token.UsedDate != nil {
+		return 0, fmt.Errorf("ссылка для сброса пароля недействительна")
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return 0, fmt.Errorf("срок действия ссылки для сброса пароля истек")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.password_reset_tokens SET used_date = NOW() WHERE id = $1
+	`, token.ID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка погашения токена сброса пароля: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.users SET password_hash = $1 WHERE id = $2
+	`, passwordHash, token.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при изменении пароля: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return token.UserID, nil
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type PriceListsRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewPriceListsRepository(db *sqlx.DB, redis *redis.Client) *PriceListsRepository {
+	return &PriceListsRepository{db: db, redis: redis}
+}
+
+func (plr *PriceListsRepository) Create(
+	ctx context.Context,
+	priceListReq model.PriceListRequestBody,
+) (*model.PriceList, error) {
+	tx, err := plr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var priceList model.PriceList
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO pricing.price_lists (
+			name,
+			currency,
+			valid_from,
+			valid_to,
+			priority
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING *
+	`,
+		priceListReq.Name,
+		priceListReq.Currency,
+		priceListReq.ValidFrom,
+		priceListReq.ValidTo,
+		priceListReq.Priority,
+	).StructScan(&priceList)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания прайс-листа: %w", err)
+	}
+
+	if err := plr.insertItems(ctx, tx, priceList.ID, priceListReq.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return plr.GetByID(ctx, priceList.ID)
+}
+
+func (plr *PriceListsRepository) GetAll(ctx context.Context) ([]model.PriceList, error) {
+	priceLists := []model.PriceList{}
+	err := plr.db.SelectContext(ctx, &priceLists, "SELECT * FROM pricing.price_lists ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка прайс-листов: %w", err)
+	}
+	return priceLists, nil
+}
+
+func (plr *PriceListsRepository) GetByID(ctx context.Context, id int) (*model.PriceList, error) {
+	var priceList model.PriceList
+	err := plr.db.GetContext(ctx, &priceList, "SELECT * FROM pricing.price_lists WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("прайс-лист не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения прайс-листа: %w", err)
+	}
+
+	priceList.Items = []model.PriceListItem{}
+	err = plr.db.SelectContext(ctx, &priceList.Items, `
+		SELECT product_id, price
+		FROM pricing.price_list_items
+		WHERE price_list_id = $1
+		ORDER BY product_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения цен прайс-листа: %w", err)
+	}
+
+	priceList.Assignments = []model.PriceListAssignment{}
+	err = plr.db.SelectContext(ctx, &priceList.Assignments, `
+		SELECT *
+		FROM pricing.price_list_assignments
+		WHERE price_list_id = $1
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения назначений прайс-листа: %w", err)
+	}
+
+	return &priceList, nil
+}
+
+// Update обновляет параметры прайс-листа и полностью заменяет список цен.
+func (plr *PriceListsRepository) Update(
+	ctx context.Context,
+	id int,
+	priceListReq model.PriceListRequestBody,
+) (*model.PriceList, error) {
+	tx, err := plr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE pricing.price_lists SET
+			name = $1,
+			currency = $2,
+			valid_from = $3,
+			valid_to = $4,
+			priority = $5
+		WHERE id = $6
+	`,
+		priceListReq.Name,
+		priceListReq.Currency,
+		priceListReq.ValidFrom,
+		priceListReq.ValidTo,
+		priceListReq.Priority,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обновления прайс-листа: %w", err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("прайс-лист не найден")
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM pricing.price_list_items WHERE price_list_id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка удаления цен прайс-листа: %w", err)
+	}
+
+	if err := plr.insertItems(ctx, tx, id, priceListReq.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return plr.GetByID(ctx, id)
+}
+
+func (plr *PriceListsRepository) Delete(ctx context.Context, id int) (*model.PriceList, error) {
+	const query = `
+		WITH deleted AS (
+			DELETE FROM pricing.price_lists
+			WHERE id = $1
+			RETURNING *
+		)
+		SELECT * FROM deleted
+	`
+
+	deletedPriceList := model.PriceList{}
+	err := plr.db.QueryRowxContext(ctx, query, id).StructScan(&deletedPriceList)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("прайс-лист не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка удаления прайс-листа: %w", err)
+	}
+
+	return &deletedPriceList, nil
+}
+
+func (plr *PriceListsRepository) AddAssignment(
+	ctx context.Context,
+	priceListID int,
+	assignmentReq model.PriceListAssignmentBody,
+) (*model.PriceListAssignment, error) {
+	var assignment model.PriceListAssignment
+	err := plr.db.QueryRowxContext(ctx, `
+		INSERT INTO pricing.price_list_assignments (
+			price_list_id,
+			user_id,
+			customer_group_id
+		) VALUES ($1, $2, $3)
+		RETURNING *
+	`, priceListID, assignmentReq.UserID, assignmentReq.CustomerGroupID).StructScan(&assignment)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("прайс-лист уже назначен")
+		}
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("прайс-лист, пользователь или группа клиентов не найдены: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка назначения прайс-листа: %w", err)
+	}
+	return &assignment, nil
+}
+
+func (plr *PriceListsRepository) DeleteAssignment(ctx context.Context, priceListID, assignmentID int) error {
+	res, err := plr.db.ExecContext(ctx, `
+		DELETE FROM pricing.price_list_assignments
+		WHERE id = $1 AND price_list_id = $2
+	`, assignmentID, priceListID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления назначения прайс-листа: %w", err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("назначение прайс-листа не найдено")
+	}
+	return nil
+}
+
+func (plr *PriceListsRepository) CreateCustomerGroup(
+	ctx context.Context,
+	groupReq model.CustomerGroupRequestBody,
+) (*model.CustomerGroup, error) {
+	var group model.CustomerGroup
+	err := plr.db.QueryRowxContext(ctx, `
+		INSERT INTO users.customer_groups (name) VALUES ($1)
+		RETURNING *
+	`, groupReq.Name).StructScan(&group)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("группа клиентов %s уже существует", groupReq.Name)
+		}
+		return nil, fmt.Errorf("ошибка создания группы клиентов: %w", err)
+	}
+	return &group, nil
+}
+
+func (plr *PriceListsRepository) GetCustomerGroups(ctx context.Context) ([]model.CustomerGroup, error) {
+	groups := []model.CustomerGroup{}
+	err := plr.db.SelectContext(ctx, &groups, "SELECT * FROM users.customer_groups ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка групп клиентов: %w", err)
+	}
+	return groups, nil
+}
+
+func (plr *PriceListsRepository) DeleteCustomerGroup(ctx context.Context, id int) (*model.CustomerGroup, error) {
+	var group model.CustomerGroup
+	err := plr.db.QueryRowxContext(ctx, `
+		DELETE FROM users.customer_groups WHERE id = $1
+		RETURNING *
+	`, id).StructScan(&group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("группа клиентов не найдена: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка удаления группы клиентов: %w", err)
+	}
+	return &group, nil
+}
+
+func (plr *PriceListsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, plr.redis)
+}
+
+func (plr *PriceListsRepository) insertItems(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	priceListID int,
+	items []model.PriceListItem,
+) error {
+	for _, item := range items {
+		if item.Price < 0 {
+			return fmt.Errorf("цена товара %d не может быть отрицательной", item.ProductID)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO pricing.price_list_items (price_list_id, product_id, price)
+			VALUES ($1, $2, $3)
+		`, priceListID, item.ProductID, item.Price)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return fmt.Errorf("товар %d указан в прайс-листе несколько раз", item.ProductID)
+			}
+			if isForeignKeyViolationError(err) {
+				return fmt.Errorf("товар с ID %d не найден", item.ProductID)
+			}
+			return fmt.Errorf("ошибка добавления цены товара %d: %w", item.ProductID, err)
+		}
+	}
+	return nil
+}
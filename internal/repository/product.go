@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 
@@ -22,18 +23,43 @@ func NewProductsRepository(db *sqlx.DB, redis *redis.Client) *ProductsRepository
 	return &ProductsRepository{db: db, redis: redis}
 }
 
-func (pr *ProductsRepository) Create(ctx context.Context, product model.Product) (*model.Product, error) {
+func (pr *ProductsRepository) Create(ctx context.Context, product model.Product, userID int) (*model.Product, error) {
 	const query = `
 		INSERT INTO products.products (
 			code,
 			name,
 			quantity,
 			purchase_price,
-			sell_price
-		) VALUES ($1, $2, $3, $4, $5)
+			sell_price,
+			is_bundle,
+			bundle_pricing,
+			bundle_discount_type,
+			bundle_discount_value,
+			unit_code,
+			pack_unit_code,
+			pack_size,
+			currency,
+			vat_rate,
+			weight
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
-	err := pr.db.QueryRowContext(
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Остаток комплекта вычисляется триггером по остаткам компонентов.
+	if product.IsBundle {
+		product.Quantity = 0
+	}
+
+	if err := pr.checkUnits(ctx, tx, product); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		product.Code,
@@ -41,6 +67,16 @@ func (pr *ProductsRepository) Create(ctx context.Context, product model.Product)
 		product.Quantity,
 		product.PurchasePrice,
 		product.SellPrice,
+		product.IsBundle,
+		product.BundlePricing,
+		product.BundleDiscountType,
+		product.BundleDiscountValue,
+		product.UnitCode,
+		product.PackUnitCode,
+		product.PackSize,
+		product.Currency,
+		product.VATRate,
+		product.Weight,
 	).Scan(&product.ID)
 	if err != nil {
 		if isDuplicateKeyError(err) {
@@ -49,10 +85,34 @@ func (pr *ProductsRepository) Create(ctx context.Context, product model.Product)
 		return nil, fmt.Errorf("ошибка при создании продукта: %w", err)
 	}
 
+	if product.IsBundle {
+		if err := pr.insertBundleComponents(ctx, tx, int(product.ID), product.Components); err != nil {
+			return nil, err
+		}
+		err = tx.GetContext(ctx, &product.Quantity,
+			"SELECT quantity FROM products.products WHERE id = $1", product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения остатка комплекта: %w", err)
+		}
+	}
+
+	_, err = pr.insertAppliedPriceChange(ctx, tx, int(product.ID), product.PurchasePrice, product.SellPrice, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
 	return &product, nil
 }
 
-func (pr *ProductsRepository) GetAll(ctx context.Context, params model.ProductQueryParams) ([]model.Product, error) {
+func (pr *ProductsRepository) GetAll(
+	ctx context.Context,
+	params model.ProductQueryParams,
+	userID int,
+) ([]model.Product, error) {
 	baseQuery := `SELECT * FROM products.products WHERE 1=1`
 	// Строим запрос с фильрами.
 	query, args := pr.buildProductsQuery(baseQuery, params)
@@ -97,6 +157,10 @@ func (pr *ProductsRepository) GetAll(ctx context.Context, params model.ProductQu
 		return nil, fmt.Errorf("ошибка при получении списка продуктов: %w", err)
 	}
 
+	if err := pr.applyEffectivePrices(ctx, products, userID); err != nil {
+		return nil, err
+	}
+
 	return products, nil
 }
 
@@ -113,7 +177,7 @@ func (pr *ProductsRepository) GetTotalCount(ctx context.Context, params model.Pr
 	return total, nil
 }
 
-func (pr *ProductsRepository) GetByID(ctx context.Context, id int) (*model.Product, error) {
+func (pr *ProductsRepository) GetByID(ctx context.Context, id, userID int) (*model.Product, error) {
 	var product model.Product
 	err := pr.db.GetContext(ctx, &product,
 		"SELECT * FROM products.products WHERE id = $1", id)
@@ -123,7 +187,19 @@ func (pr *ProductsRepository) GetByID(ctx context.Context, id int) (*model.Produ
 		}
 		return nil, fmt.Errorf("ошибка при получении продукта: %w", err)
 	}
-	return &product, nil
+
+	if product.IsBundle {
+		product.Components, err = pr.getBundleComponents(ctx, pr.db, int(product.ID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	products := []model.Product{product}
+	if err := pr.applyEffectivePrices(ctx, products, userID); err != nil {
+		return nil, err
+	}
+	return &products[0], nil
 }
 
 func (pr *ProductsRepository) Delete(ctx context.Context, id int) (*model.Product, error) {
@@ -142,26 +218,79 @@ func (pr *ProductsRepository) Delete(ctx context.Context, id int) (*model.Produc
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("продукт не найден: %w", err)
 		}
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("продукт входит в состав комплекта: %w", err)
+		}
 		return nil, fmt.Errorf("ошибка удаления продукта: %w", err)
 	}
 
 	return &deletedProduct, nil
 }
 
-func (pr *ProductsRepository) Update(ctx context.Context, id int, product model.Product) (*model.Product, error) {
+func (pr *ProductsRepository) Update(
+	ctx context.Context,
+	id int,
+	product model.Product,
+	userID int,
+) (*model.Product, error) {
 	const query = `
 		UPDATE products.products SET
 			code = $1,
 			name = $2,
-			quantity = $3,
+			quantity = CASE WHEN $7 THEN quantity ELSE $3 END,
 			purchase_price = $4,
-			sell_price = $5
+			sell_price = $5,
+			is_bundle = $7,
+			bundle_pricing = $8,
+			bundle_discount_type = $9,
+			bundle_discount_value = $10,
+			unit_code = $11,
+			pack_unit_code = $12,
+			pack_size = $13,
+			currency = $14,
+			vat_rate = $15,
+			weight = $16
 		WHERE id = $6
 		RETURNING *
 	`
 
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldProduct model.Product
+	err = tx.GetContext(ctx, &oldProduct, `
+		SELECT * FROM products.products WHERE id = $1 FOR UPDATE
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("продукт не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения продукта: %w", err)
+	}
+
+	// Вложенные комплекты не поддерживаются: компонент другого комплекта не может сам стать комплектом.
+	if product.IsBundle && !oldProduct.IsBundle {
+		var isComponent bool
+		err = tx.GetContext(ctx, &isComponent, `
+			SELECT EXISTS (SELECT 1 FROM products.bundle_components WHERE component_id = $1)
+		`, id)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка проверки состава комплектов: %w", err)
+		}
+		if isComponent {
+			return nil, fmt.Errorf("продукт %d входит в состав комплекта и не может сам стать комплектом", id)
+		}
+	}
+
+	if err := pr.checkUnits(ctx, tx, product); err != nil {
+		return nil, err
+	}
+
 	updatedProduct := model.Product{}
-	err := pr.db.QueryRowxContext(
+	err = tx.QueryRowxContext(
 		ctx,
 		query,
 		product.Code,
@@ -170,6 +299,16 @@ func (pr *ProductsRepository) Update(ctx context.Context, id int, product model.
 		product.PurchasePrice,
 		product.SellPrice,
 		id,
+		product.IsBundle,
+		product.BundlePricing,
+		product.BundleDiscountType,
+		product.BundleDiscountValue,
+		product.UnitCode,
+		product.PackUnitCode,
+		product.PackSize,
+		product.Currency,
+		product.VATRate,
+		product.Weight,
 	).StructScan(&updatedProduct)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -181,9 +320,302 @@ func (pr *ProductsRepository) Update(ctx context.Context, id int, product model.
 		return nil, fmt.Errorf("ошибка обновления продукта: %w", err)
 	}
 
+	// Состав комплекта заменяется целиком.
+	if oldProduct.IsBundle || updatedProduct.IsBundle {
+		_, err = tx.ExecContext(ctx, "DELETE FROM products.bundle_components WHERE bundle_id = $1", id)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка удаления состава комплекта: %w", err)
+		}
+	}
+	if updatedProduct.IsBundle {
+		if err := pr.insertBundleComponents(ctx, tx, id, product.Components); err != nil {
+			return nil, err
+		}
+		updatedProduct.Components, err = pr.getBundleComponents(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.GetContext(ctx, &updatedProduct.Quantity, "SELECT quantity FROM products.products WHERE id = $1", id)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения остатка комплекта: %w", err)
+		}
+	}
+
+	// Фиксируем изменение цен в истории.
+	if oldProduct.PurchasePrice != updatedProduct.PurchasePrice || oldProduct.SellPrice != updatedProduct.SellPrice {
+		_, err = pr.insertAppliedPriceChange(
+			ctx, tx, id, updatedProduct.PurchasePrice, updatedProduct.SellPrice, userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
 	return &updatedProduct, nil
 }
 
+func (pr *ProductsRepository) GetPriceHistory(ctx context.Context, productID int) ([]model.PriceChange, error) {
+	var exists bool
+	err := pr.db.GetContext(ctx, &exists,
+		"SELECT EXISTS(SELECT 1 FROM products.products WHERE id = $1)", productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении продукта: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("продукт не найден")
+	}
+
+	history := []model.PriceChange{}
+	err = pr.db.SelectContext(ctx, &history, `
+		SELECT *
+		FROM products.price_history
+		WHERE product_id = $1
+		ORDER BY effective_from DESC, id DESC
+	`, productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении истории цен: %w", err)
+	}
+
+	return history, nil
+}
+
+func (pr *ProductsRepository) CreatePriceChange(
+	ctx context.Context,
+	productID int,
+	priceReq model.PriceChangeRequestBody,
+	userID int,
+) (*model.PriceChange, error) {
+	if *priceReq.PurchasePrice < 0 || *priceReq.SellPrice < 0 {
+		return nil, fmt.Errorf("цена не может быть отрицательной")
+	}
+
+	// Запланированное изменение только сохраняем, его применит планировщик.
+	if priceReq.EffectiveFrom != nil && priceReq.EffectiveFrom.After(time.Now()) {
+		var priceChange model.PriceChange
+		err := pr.db.QueryRowxContext(ctx, `
+			INSERT INTO products.price_history (
+				product_id,
+				purchase_price,
+				sell_price,
+				effective_from,
+				created_by,
+				applied
+			) VALUES ($1, $2, $3, $4, NULLIF($5, 0), FALSE)
+			RETURNING *
+		`, productID, *priceReq.PurchasePrice, *priceReq.SellPrice, *priceReq.EffectiveFrom, userID,
+		).StructScan(&priceChange)
+		if err != nil {
+			if isForeignKeyViolationError(err) {
+				return nil, fmt.Errorf("продукт не найден: %w", err)
+			}
+			return nil, fmt.Errorf("ошибка планирования изменения цены: %w", err)
+		}
+		return &priceChange, nil
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE products.products
+		SET purchase_price = $1, sell_price = $2, version = version + 1
+		WHERE id = $3
+	`, *priceReq.PurchasePrice, *priceReq.SellPrice, productID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обновления цены: %w", err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return nil, fmt.Errorf("продукт не найден")
+	}
+
+	priceChange, err := pr.insertAppliedPriceChange(
+		ctx, tx, productID, *priceReq.PurchasePrice, *priceReq.SellPrice, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return priceChange, nil
+}
+
+// ApplyScheduledPriceChanges применяет к товарам все наступившие запланированные изменения цен.
+func (pr *ProductsRepository) ApplyScheduledPriceChanges(ctx context.Context) ([]model.PriceChange, error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED позволяет нескольким экземплярам сервиса не мешать друг другу.
+	var pending []model.PriceChange
+	err = tx.SelectContext(ctx, &pending, `
+		SELECT *
+		FROM products.price_history
+		WHERE NOT applied AND effective_from <= NOW()
+		ORDER BY effective_from, id
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения запланированных цен: %w", err)
+	}
+
+	for i := range pending {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE products.products
+			SET purchase_price = $1, sell_price = $2, version = version + 1
+			WHERE id = $3
+		`, pending[i].PurchasePrice, pending[i].SellPrice, pending[i].ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка применения цены для товара %d: %w", pending[i].ProductID, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE products.price_history SET applied = TRUE WHERE id = $1
+		`, pending[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка отметки применения цены %d: %w", pending[i].ID, err)
+		}
+		pending[i].Applied = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (pr *ProductsRepository) insertAppliedPriceChange(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	productID int,
+	purchasePrice, sellPrice int64,
+	userID int,
+) (*model.PriceChange, error) {
+	var priceChange model.PriceChange
+	err := tx.QueryRowxContext(ctx, `
+		INSERT INTO products.price_history (
+			product_id,
+			purchase_price,
+			sell_price,
+			created_by,
+			applied
+		) VALUES ($1, $2, $3, NULLIF($4, 0), TRUE)
+		RETURNING *
+	`, productID, purchasePrice, sellPrice, userID).StructScan(&priceChange)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка записи истории цен: %w", err)
+	}
+	return &priceChange, nil
+}
+
+// checkUnits проверяет, что единицы измерения товара существуют, а остаток
+// не дробнее, чем допускает базовая единица.
+func (pr *ProductsRepository) checkUnits(ctx context.Context, tx *sqlx.Tx, product model.Product) error {
+	var precision int
+	err := tx.GetContext(ctx, &precision,
+		"SELECT precision FROM products.units WHERE code = $1", product.UnitCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("единица измерения %s не найдена", product.UnitCode)
+		}
+		return fmt.Errorf("ошибка получения единицы измерения: %w", err)
+	}
+	if !product.Quantity.FitsPrecision(precision) {
+		return fmt.Errorf("количество для единицы измерения %s должно иметь не более %d знаков после запятой",
+			product.UnitCode, precision)
+	}
+
+	if product.PackUnitCode != nil {
+		var exists bool
+		err = tx.GetContext(ctx, &exists,
+			"SELECT EXISTS(SELECT 1 FROM products.units WHERE code = $1)", *product.PackUnitCode)
+		if err != nil {
+			return fmt.Errorf("ошибка получения единицы измерения: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("единица измерения %s не найдена", *product.PackUnitCode)
+		}
+	}
+	return nil
+}
+
+// GetUnits возвращает справочник единиц измерения.
+func (pr *ProductsRepository) GetUnits(ctx context.Context) ([]model.Unit, error) {
+	units := []model.Unit{}
+	err := pr.db.SelectContext(ctx, &units, "SELECT * FROM products.units ORDER BY code")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения единиц измерения: %w", err)
+	}
+	return units, nil
+}
+
+// insertBundleComponents добавляет компоненты в состав комплекта.
+// Компонентом может быть только обычный товар, вложенные комплекты не поддерживаются.
+func (pr *ProductsRepository) insertBundleComponents(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	bundleID int,
+	components []model.BundleComponent,
+) error {
+	for _, component := range components {
+		var isBundle bool
+		err := tx.GetContext(ctx, &isBundle,
+			"SELECT is_bundle FROM products.products WHERE id = $1 FOR SHARE", component.ProductID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("товар с ID %d не найден", component.ProductID)
+			}
+			return fmt.Errorf("ошибка проверки компонента %d: %w", component.ProductID, err)
+		}
+		if isBundle {
+			return fmt.Errorf("комплект %d не может входить в состав другого комплекта", component.ProductID)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO products.bundle_components (bundle_id, component_id, quantity)
+			VALUES ($1, $2, $3)
+		`, bundleID, component.ProductID, component.Quantity)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return fmt.Errorf("товар %d указан в составе комплекта несколько раз", component.ProductID)
+			}
+			return fmt.Errorf("ошибка добавления компонента %d: %w", component.ProductID, err)
+		}
+	}
+	return nil
+}
+
+func (pr *ProductsRepository) getBundleComponents(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	bundleID int,
+) ([]model.BundleComponent, error) {
+	components := []model.BundleComponent{}
+	err := sqlx.SelectContext(ctx, q, &components, `
+		SELECT bc.component_id, p.name, bc.quantity
+		FROM products.bundle_components bc
+		JOIN products.products p ON p.id = bc.component_id
+		WHERE bc.bundle_id = $1
+		ORDER BY bc.component_id
+	`, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения состава комплекта: %w", err)
+	}
+	return components, nil
+}
+
 func (pr *ProductsRepository) buildProductsQuery(baseQuery string, params model.ProductQueryParams) (string, []any) {
 	query := baseQuery
 	args := []any{}
@@ -221,6 +653,46 @@ func (pr *ProductsRepository) buildProductsQuery(baseQuery string, params model.
 	return query, args
 }
 
+// applyEffectivePrices заменяет цену продажи на цену из прайс-листа пользователя, если она назначена.
+func (pr *ProductsRepository) applyEffectivePrices(ctx context.Context, products []model.Product, userID int) error {
+	if len(products) == 0 || userID == 0 {
+		return nil
+	}
+
+	ids := make([]int32, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+
+	var prices []struct {
+		ProductID int32  `db:"product_id"`
+		Price     int64  `db:"price"`
+		Currency  string `db:"currency"`
+	}
+	err := pr.db.SelectContext(ctx, &prices, `
+		SELECT id AS product_id,
+			pricing.effective_price(id, $1) AS price,
+			pricing.effective_currency(id, $1) AS currency
+		FROM products.products
+		WHERE id = ANY($2)
+	`, userID, ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения цен по прайс-листу: %w", err)
+	}
+
+	pricesMap := make(map[int32]model.Money, len(prices))
+	for _, p := range prices {
+		pricesMap[p.ProductID] = model.NewMoney(p.Price, p.Currency)
+	}
+	for i := range products {
+		if price, ok := pricesMap[products[i].ID]; ok {
+			products[i].SellPrice = price.Amount
+			products[i].Currency = price.Currency
+		}
+	}
+	return nil
+}
+
 func (pr *ProductsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
 	return WriteLog(result, operation, status, tableName, pr.redis)
 }
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func newProductsRepository(t *testing.T) (*ProductsRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Ошибка создания sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewProductsRepository(sqlx.NewDb(db, "postgres"), nil), mock
+}
+
+func TestProductsRepository_insertBundleComponents(t *testing.T) {
+	checkComponent := regexp.QuoteMeta("SELECT is_bundle FROM products.products WHERE id = $1 FOR SHARE")
+	insertComponent := regexp.QuoteMeta("INSERT INTO products.bundle_components")
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr string
+	}{
+		{
+			name: "regular products",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkComponent).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"is_bundle"}).AddRow(false))
+				mock.ExpectExec(insertComponent).WithArgs(1, 2, model.NewQuantity(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(checkComponent).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"is_bundle"}).AddRow(false))
+				mock.ExpectExec(insertComponent).WithArgs(1, 3, model.NewQuantity(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "nested bundle",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkComponent).WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"is_bundle"}).AddRow(true))
+			},
+			wantErr: "не может входить в состав другого комплекта",
+		},
+		{
+			name: "unknown component",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(checkComponent).WithArgs(2).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: "товар с ID 2 не найден",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr, mock := newProductsRepository(t)
+			mock.ExpectBegin()
+			tt.mock(mock)
+			mock.ExpectRollback()
+
+			tx, err := pr.db.BeginTxx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Ошибка начала транзакции: %v", err)
+			}
+			err = pr.insertBundleComponents(context.Background(), tx, 1, []model.BundleComponent{
+				{ProductID: 2, Quantity: model.NewQuantity(2)},
+				{ProductID: 3, Quantity: model.NewQuantity(1)},
+			})
+			_ = tx.Rollback()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("insertBundleComponents() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("insertBundleComponents() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
+
+func TestProductsRepository_Update_componentBecomesBundle(t *testing.T) {
+	pr, mock := newProductsRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM products.products WHERE id = $1 FOR UPDATE")).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "is_bundle"}).AddRow(2, false))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM products.bundle_components WHERE component_id = $1")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err := pr.Update(context.Background(), 2, model.Product{
+		IsBundle:   true,
+		Components: []model.BundleComponent{{ProductID: 3, Quantity: model.NewQuantity(1)}},
+	}, 1)
+	const wantErr = "входит в состав комплекта и не может сам стать комплектом"
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("Update() error = %v, want %q", err, wantErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+	}
+}
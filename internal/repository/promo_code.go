@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type PromoCodesRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewPromoCodesRepository(db *sqlx.DB, redis *redis.Client) *PromoCodesRepository {
+	return &PromoCodesRepository{db: db, redis: redis}
+}
+
+func (pr *PromoCodesRepository) Create(
+	ctx context.Context,
+	promoCodeReq model.PromoCodeRequestBody,
+) (*model.PromoCode, error) {
+	active := true
+	if promoCodeReq.Active != nil {
+		active = *promoCodeReq.Active
+	}
+
+	var promoCode model.PromoCode
+	err := pr.db.QueryRowxContext(ctx, `
+		INSERT INTO orders.promo_codes (
+			code,
+			discount_type,
+			discount_value,
+			max_uses,
+			max_uses_per_user,
+			valid_from,
+			valid_to,
+			active,
+			currency
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING *
+	`,
+		promoCodeReq.Code,
+		promoCodeReq.DiscountType,
+		promoCodeReq.DiscountValue,
+		promoCodeReq.MaxUses,
+		promoCodeReq.MaxUsesPerUser,
+		promoCodeReq.ValidFrom,
+		promoCodeReq.ValidTo,
+		active,
+		promoCodeReq.Currency,
+	).StructScan(&promoCode)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("промокод %s уже существует", promoCodeReq.Code)
+		}
+		return nil, fmt.Errorf("ошибка создания промокода: %w", err)
+	}
+	return &promoCode, nil
+}
+
+func (pr *PromoCodesRepository) GetAll(ctx context.Context) ([]model.PromoCode, error) {
+	promoCodes := []model.PromoCode{}
+	err := pr.db.SelectContext(ctx, &promoCodes, "SELECT * FROM orders.promo_codes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка промокодов: %w", err)
+	}
+	return promoCodes, nil
+}
+
+func (pr *PromoCodesRepository) GetByID(ctx context.Context, id int) (*model.PromoCode, error) {
+	var promoCode model.PromoCode
+	err := pr.db.GetContext(ctx, &promoCode, "SELECT * FROM orders.promo_codes WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("промокод не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения промокода: %w", err)
+	}
+	return &promoCode, nil
+}
+
+// Update изменяет параметры промокода. Счетчик использований не сбрасывается.
+func (pr *PromoCodesRepository) Update(
+	ctx context.Context,
+	id int,
+	promoCodeReq model.PromoCodeRequestBody,
+) (*model.PromoCode, error) {
+	var promoCode model.PromoCode
+	err := pr.db.QueryRowxContext(ctx, `
+		UPDATE orders.promo_codes SET
+			code = $1,
+			discount_type = $2,
+			discount_value = $3,
+			max_uses = $4,
+			max_uses_per_user = $5,
+			valid_from = $6,
+			valid_to = $7,
+			active = COALESCE($8, active),
+			currency = $10
+		WHERE id = $9
+		RETURNING *
+	`,
+		promoCodeReq.Code,
+		promoCodeReq.DiscountType,
+		promoCodeReq.DiscountValue,
+		promoCodeReq.MaxUses,
+		promoCodeReq.MaxUsesPerUser,
+		promoCodeReq.ValidFrom,
+		promoCodeReq.ValidTo,
+		promoCodeReq.Active,
+		id,
+		promoCodeReq.Currency,
+	).StructScan(&promoCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("промокод не найден: %w", err)
+		}
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("промокод %s уже существует", promoCodeReq.Code)
+		}
+		return nil, fmt.Errorf("ошибка обновления промокода: %w", err)
+	}
+	return &promoCode, nil
+}
+
+func (pr *PromoCodesRepository) Delete(ctx context.Context, id int) (*model.PromoCode, error) {
+	var promoCode model.PromoCode
+	err := pr.db.QueryRowxContext(ctx, `
+		DELETE FROM orders.promo_codes WHERE id = $1
+		RETURNING *
+	`, id).StructScan(&promoCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("промокод не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка удаления промокода: %w", err)
+	}
+	return &promoCode, nil
+}
+
+func (pr *PromoCodesRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, pr.redis)
+}
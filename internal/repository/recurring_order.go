@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+// recurringRunsLimit сколько последних запусков возвращается вместе с шаблоном.
+const recurringRunsLimit = 20
+
+type RecurringOrdersRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewRecurringOrdersRepository(db *sqlx.DB, redis *redis.Client) *RecurringOrdersRepository {
+	return &RecurringOrdersRepository{db: db, redis: redis}
+}
+
+// Create сохраняет шаблон регулярного заказа владельца ownerID. Количества переводятся
+// в базовые единицы по тем же правилам, что и при создании заказа.
+func (rr *RecurringOrdersRepository) Create(
+	ctx context.Context,
+	recurringReq model.RecurringOrderRequestBody,
+	ownerID int,
+	nextRun time.Time,
+) (*model.RecurringOrder, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := toBaseQuantities(ctx, tx, recurringReq.Products); err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = tx.GetContext(ctx, &id, `
+		INSERT INTO orders.recurring_orders (
+			user_id,
+			frequency,
+			interval_count,
+			start_date,
+			next_run_date,
+			address_id,
+			shipping_method_id,
+			tax_mode
+		) VALUES ($1, $2, $3, $4, $4, $5, $6, $7)
+		RETURNING id
+	`,
+		ownerID,
+		recurringReq.Frequency,
+		recurringReq.IntervalCount,
+		nextRun,
+		recurringReq.AddressID,
+		recurringReq.ShippingMethodID,
+		recurringReq.TaxMode,
+	)
+	if err != nil {
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("адрес или способ доставки не найден")
+		}
+		return nil, fmt.Errorf("ошибка создания регулярного заказа: %w", err)
+	}
+
+	for _, product := range recurringReq.Products {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO orders.recurring_order_products (recurring_order_id, product_id, quantity)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (recurring_order_id, product_id)
+			DO UPDATE SET quantity = orders.recurring_order_products.quantity + EXCLUDED.quantity
+		`, id, product.ProductID, product.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка добавления товара %d в регулярный заказ: %w", product.ProductID, err)
+		}
+	}
+
+	recurring, err := getRecurringOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return recurring, nil
+}
+
+func (rr *RecurringOrdersRepository) GetAll(
+	ctx context.Context,
+	userID int,
+	role model.UserRole,
+) ([]model.RecurringOrder, error) {
+	query := "SELECT * FROM orders.recurring_orders"
+	args := []any{}
+	if role.IsClient() {
+		query += " WHERE user_id = $1"
+		args = append(args, userID)
+	}
+	recurringOrders := []model.RecurringOrder{}
+	if err := rr.db.SelectContext(ctx, &recurringOrders, query+" ORDER BY id", args...); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка регулярных заказов: %w", err)
+	}
+	for i := range recurringOrders {
+		products, err := getRecurringOrderProducts(ctx, rr.db, recurringOrders[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		recurringOrders[i].Products = products
+	}
+	return recurringOrders, nil
+}
+
+// GetByID возвращает шаблон вместе с последними запусками. Клиент видит только свои шаблоны.
+func (rr *RecurringOrdersRepository) GetByID(
+	ctx context.Context,
+	id, userID int,
+	role model.UserRole,
+) (*model.RecurringOrder, error) {
+	if err := checkRecurringOrderAccess(ctx, rr.db, id, userID, role); err != nil {
+		return nil, err
+	}
+	recurring, err := getRecurringOrder(ctx, rr.db, id)
+	if err != nil {
+		return nil, err
+	}
+	recurring.Runs = []model.RecurringOrderRun{}
+	err = rr.db.SelectContext(ctx, &recurring.Runs, `
+		SELECT id, scheduled_date, status, order_id, error, created_date
+		FROM orders.recurring_order_runs
+		WHERE recurring_order_id = $1
+		ORDER BY scheduled_date DESC, id DESC
+		LIMIT $2
+	`, id, recurringRunsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения запусков регулярного заказа: %w", err)
+	}
+	return recurring, nil
+}
+
+func (rr *RecurringOrdersRepository) Delete(
+	ctx context.Context,
+	id, userID int,
+	role model.UserRole,
+) (*model.RecurringOrder, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkRecurringOrderAccess(ctx, tx, id, userID, role); err != nil {
+		return nil, err
+	}
+	recurring, err := getRecurringOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM orders.recurring_orders WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("ошибка удаления регулярного заказа: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return recurring, nil
+}
+
+// SetSchedule меняет состояние шаблона и дату следующего запуска.
+func (rr *RecurringOrdersRepository) SetSchedule(
+	ctx context.Context,
+	id int,
+	status model.RecurringStatus,
+	nextRun time.Time,
+) (*model.RecurringOrder, error) {
+	_, err := rr.db.ExecContext(ctx, `
+		UPDATE orders.recurring_orders SET status = $1, next_run_date = $2 WHERE id = $3
+	`, status, nextRun, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка изменения расписания регулярного заказа: %w", err)
+	}
+	return getRecurringOrder(ctx, rr.db, id)
+}
+
+// GetDue возвращает активные шаблоны, дата запуска которых наступила.
+func (rr *RecurringOrdersRepository) GetDue(ctx context.Context) ([]model.RecurringOrder, error) {
+	recurringOrders := []model.RecurringOrder{}
+	err := rr.db.SelectContext(ctx, &recurringOrders, `
+		SELECT * FROM orders.recurring_orders
+		WHERE status = $1 AND next_run_date <= NOW()
+		ORDER BY next_run_date, id
+	`, model.RecurringActive)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения регулярных заказов к запуску: %w", err)
+	}
+	for i := range recurringOrders {
+		products, err := getRecurringOrderProducts(ctx, rr.db, recurringOrders[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		recurringOrders[i].Products = products
+	}
+	return recurringOrders, nil
+}
+
+// Claim переносит дату запуска с scheduled на next, если ее еще не перенес другой экземпляр
+// приложения. Возвращает false, если запуск уже забран.
+func (rr *RecurringOrdersRepository) Claim(ctx context.Context, id int, scheduled, next time.Time) (bool, error) {
+	result, err := rr.db.ExecContext(ctx, `
+		UPDATE orders.recurring_orders
+		SET next_run_date = $1, last_run_date = $2
+		WHERE id = $3 AND next_run_date = $2 AND status = $4
+	`, next, scheduled, id, model.RecurringActive)
+	if err != nil {
+		return false, fmt.Errorf("ошибка переноса даты запуска регулярного заказа: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка переноса даты запуска регулярного заказа: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// AddRun записывает результат запуска регулярного заказа.
+func (rr *RecurringOrdersRepository) AddRun(ctx context.Context, id int, run model.RecurringOrderRun) error {
+	_, err := rr.db.ExecContext(ctx, `
+		INSERT INTO orders.recurring_order_runs (recurring_order_id, scheduled_date, status, order_id, error)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, run.ScheduledDate, run.Status, run.OrderID, run.Error)
+	if err != nil {
+		return fmt.Errorf("ошибка записи запуска регулярного заказа: %w", err)
+	}
+	return nil
+}
+
+func (rr *RecurringOrdersRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, rr.redis)
+}
+
+func checkRecurringOrderAccess(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	id, userID int,
+	role model.UserRole,
+) error {
+	query, args := ownedOrderQuery("SELECT id FROM orders.recurring_orders WHERE id = $1", id, userID, role)
+	var found int
+	if err := sqlx.GetContext(ctx, q, &found, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("регулярный заказ не найден")
+		}
+		return fmt.Errorf("ошибка получения регулярного заказа: %w", err)
+	}
+	return nil
+}
+
+func getRecurringOrder(ctx context.Context, q sqlx.QueryerContext, id int) (*model.RecurringOrder, error) {
+	var recurring model.RecurringOrder
+	if err := sqlx.GetContext(ctx, q, &recurring, "SELECT * FROM orders.recurring_orders WHERE id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("регулярный заказ не найден")
+		}
+		return nil, fmt.Errorf("ошибка получения регулярного заказа: %w", err)
+	}
+	products, err := getRecurringOrderProducts(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	recurring.Products = products
+	return &recurring, nil
+}
+
+func getRecurringOrderProducts(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	id int,
+) ([]model.RecurringOrderProduct, error) {
+	products := []model.RecurringOrderProduct{}
+	err := sqlx.SelectContext(ctx, q, &products, `
+		SELECT rp.product_id, COALESCE(p.name, '') AS name, rp.quantity
+		FROM orders.recurring_order_products rp
+		LEFT JOIN products.products p ON p.id = rp.product_id
+		WHERE rp.recurring_order_id = $1
+		ORDER BY rp.product_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения товаров регулярного заказа: %w", err)
+	}
+	return products, nil
+}
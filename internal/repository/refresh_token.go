@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type RefreshTokensRepository struct {
+	db *sqlx.DB
+}
+
+func NewRefreshTokensRepository(db *sqlx.DB) *RefreshTokensRepository {
+	return &RefreshTokensRepository{db: db}
+}
+
+// Create сохраняет первый токен нового семейства. Заодно удаляются истекшие токены пользователя.
+func (rr *RefreshTokensRepository) Create(
+	ctx context.Context,
+	userID int,
+	tokenHash string,
+	expiresAt time.Time,
+) (*model.RefreshToken, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM users.refresh_tokens WHERE user_id = $1 AND expires_at < NOW()
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка удаления истекших refresh-токенов: %w", err)
+	}
+
+	var token model.RefreshToken
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO users.refresh_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING *
+	`, userID, tokenHash, expiresAt).StructScan(&token)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения refresh-токена: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &token, nil
+}
+
+// Rotate погашает токен и выпускает вместо него новый в том же семействе.
+// Повторное предъявление уже погашенного или отозванного токена означает, что он
+// утек: все семейство отзывается, и владельцу придется войти заново.
+func (rr *RefreshTokensRepository) Rotate(
+	ctx context.Context,
+	tokenHash, newHash string,
+	expiresAt time.Time,
+) (*model.RefreshToken, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current model.RefreshToken
+	err = tx.GetContext(ctx, &current, `
+		SELECT * FROM users.refresh_tokens WHERE token_hash = $1 FOR UPDATE
+	`, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("refresh-токен недействителен")
+		}
+		return nil, fmt.Errorf("ошибка получения refresh-токена: %w", err)
+	}
+
+	if current.RevokedDate != nil && current.UsedDate == nil {
+		return nil, fmt.Errorf("refresh-токен отозван")
+	}
+	if !current.Active() {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE users.refresh_tokens SET revoked_date = NOW()
+			WHERE family_id = $1 AND revoked_date IS NULL
+		`, current.FamilyID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка отзыва семейства refresh-токенов: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+		}
+		return nil, fmt.Errorf("refresh-токен использован повторно, сессия отозвана")
+	}
+	if current.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("срок действия refresh-токена истек")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users.refresh_tokens SET used_date = NOW() WHERE id = $1
+	`, current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка погашения refresh-токена: %w", err)
+	}
+
+	var next model.RefreshToken
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO users.refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING *
+	`, current.UserID, current.FamilyID, newHash, expiresAt).StructScan(&next)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения refresh-токена: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &next, nil
+}
+
+// RevokeFamily отзывает семейство, к которому относится токен. Чужой токен не отзывается.
+func (rr *RefreshTokensRepository) RevokeFamily(ctx context.Context, tokenHash string, userID int) error {
+	_, err := rr.db.ExecContext(ctx, `
+		UPDATE users.refresh_tokens SET revoked_date = NOW()
+		WHERE revoked_date IS NULL AND family_id = (
+			SELECT family_id FROM users.refresh_tokens WHERE token_hash = $1 AND user_id = $2
+		)
+	`, tokenHash, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка отзыва семейства refresh-токенов: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll отзывает все refresh-токены пользователя.
+func (rr *RefreshTokensRepository) RevokeAll(ctx context.Context, userID int) error {
+	_, err := rr.db.ExecContext(ctx, `
+		UPDATE users.refresh_tokens SET revoked_date = NOW()
+		WHERE user_id = $1 AND revoked_date IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка отзыва refresh-токенов пользователя: %w", err)
+	}
+	return nil
+}
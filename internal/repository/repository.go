@@ -10,6 +10,7 @@ import (
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
@@ -26,36 +27,225 @@ type Order interface {
 	Create(ctx context.Context, order model.OrderRequestBody, userID int) (*model.Order, error)
 	GetAll(ctx context.Context, userID int, role model.UserRole) ([]model.Order, error)
 	GetByID(ctx context.Context, id, userID int, role model.UserRole) (*model.Order, error)
-	Delete(ctx context.Context, id, userID int) (*model.Order, error)
-	Update(ctx context.Context, id int, orderReq model.OrderRequestBody, userID int) (*model.Order, error)
+	Delete(ctx context.Context, id, userID int, role model.UserRole) (*model.Order, error)
+	Update(
+		ctx context.Context,
+		id int,
+		orderReq model.OrderRequestBody,
+		userID int,
+		role model.UserRole,
+	) (*model.Order, error)
 	UpdateStatus(
 		ctx context.Context,
 		id int,
 		orderStatusRequest model.OrderStatusRequest,
 		userID int,
+		role model.UserRole,
 	) (*model.Order, error)
+	Ship(ctx context.Context, id int, shipment model.ShipmentRequestBody) (*model.Order, error)
+	GetReorderLines(ctx context.Context, id int) ([]model.ReorderLine, error)
 	WriteLog(result any, operation, status, tableName string) (int64, error)
 }
 
 type Product interface {
-	Create(ctx context.Context, product model.Product) (*model.Product, error)
-	GetAll(ctx context.Context, params model.ProductQueryParams) ([]model.Product, error)
-	GetByID(ctx context.Context, id int) (*model.Product, error)
+	Create(ctx context.Context, product model.Product, userID int) (*model.Product, error)
+	GetAll(ctx context.Context, params model.ProductQueryParams, userID int) ([]model.Product, error)
+	GetByID(ctx context.Context, id, userID int) (*model.Product, error)
 	Delete(ctx context.Context, id int) (*model.Product, error)
-	Update(ctx context.Context, id int, product model.Product) (*model.Product, error)
+	Update(ctx context.Context, id int, product model.Product, userID int) (*model.Product, error)
 	WriteLog(result any, operation, status, tableName string) (int64, error)
 	GetTotalCount(ctx context.Context, params model.ProductQueryParams) (int, error)
+	GetUnits(ctx context.Context) ([]model.Unit, error)
+	GetPriceHistory(ctx context.Context, productID int) ([]model.PriceChange, error)
+	CreatePriceChange(
+		ctx context.Context,
+		productID int,
+		priceReq model.PriceChangeRequestBody,
+		userID int,
+	) (*model.PriceChange, error)
+	ApplyScheduledPriceChanges(ctx context.Context) ([]model.PriceChange, error)
 }
 
 type User interface {
 	Create(ctx context.Context, user model.User) (*model.User, error)
 	GetAll(ctx context.Context) ([]model.User, error)
 	GetByID(ctx context.Context, id int) (*model.User, error)
+	GetByEmail(ctx context.Context, email string) (*model.User, error)
 	Delete(ctx context.Context, id int) (*model.User, error)
 	Update(ctx context.Context, id int, user model.UserEditBody) (*model.User, error)
-	Login(ctx context.Context, user model.LoginRequest) (*model.TokenSuccess, error)
+	Login(ctx context.Context, user model.LoginRequest) (*model.User, error)
 	ChangeUserRole(ctx context.Context, id int, userRoleReq model.UserRoleBody) (*model.User, error)
 	ChangePassword(ctx context.Context, id int, changePassworReq model.UserChangePasswordBody) (*model.Success, error)
+	ChangeCustomerGroup(ctx context.Context, id int, groupReq model.UserCustomerGroupBody) (*model.User, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type PriceList interface {
+	Create(ctx context.Context, priceList model.PriceListRequestBody) (*model.PriceList, error)
+	GetAll(ctx context.Context) ([]model.PriceList, error)
+	GetByID(ctx context.Context, id int) (*model.PriceList, error)
+	Update(ctx context.Context, id int, priceList model.PriceListRequestBody) (*model.PriceList, error)
+	Delete(ctx context.Context, id int) (*model.PriceList, error)
+	AddAssignment(
+		ctx context.Context,
+		priceListID int,
+		assignment model.PriceListAssignmentBody,
+	) (*model.PriceListAssignment, error)
+	DeleteAssignment(ctx context.Context, priceListID, assignmentID int) error
+	CreateCustomerGroup(ctx context.Context, group model.CustomerGroupRequestBody) (*model.CustomerGroup, error)
+	GetCustomerGroups(ctx context.Context) ([]model.CustomerGroup, error)
+	DeleteCustomerGroup(ctx context.Context, id int) (*model.CustomerGroup, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type PromoCode interface {
+	Create(ctx context.Context, promoCode model.PromoCodeRequestBody) (*model.PromoCode, error)
+	GetAll(ctx context.Context) ([]model.PromoCode, error)
+	GetByID(ctx context.Context, id int) (*model.PromoCode, error)
+	Update(ctx context.Context, id int, promoCode model.PromoCodeRequestBody) (*model.PromoCode, error)
+	Delete(ctx context.Context, id int) (*model.PromoCode, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type Return interface {
+	Create(ctx context.Context, returnReq model.ReturnRequestBody, userID int, role model.UserRole) (*model.Return, error)
+	GetAll(ctx context.Context, userID int, role model.UserRole) ([]model.Return, error)
+	GetByID(ctx context.Context, id, userID int, role model.UserRole) (*model.Return, error)
+	Decide(ctx context.Context, id int, decision model.ReturnDecisionBody, employeeID int) (*model.Return, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type Cart interface {
+	GetItems(ctx context.Context, userID int) ([]model.CartItem, error)
+	AddItem(ctx context.Context, userID int, item model.CartItemRequest) error
+	UpdateItem(ctx context.Context, userID int, item model.CartItemRequest) error
+	RemoveItem(ctx context.Context, userID, productID int) error
+	Clear(ctx context.Context, userID int) error
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type Address interface {
+	Create(ctx context.Context, userID int, address model.AddressRequestBody) (*model.Address, error)
+	GetAll(ctx context.Context, userID int) ([]model.Address, error)
+	GetByID(ctx context.Context, id, userID int) (*model.Address, error)
+	Update(ctx context.Context, id, userID int, address model.AddressRequestBody) (*model.Address, error)
+	Delete(ctx context.Context, id, userID int) (*model.Address, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type ShippingMethod interface {
+	Create(ctx context.Context, method model.ShippingMethodRequestBody) (*model.ShippingMethod, error)
+	GetAll(ctx context.Context, onlyActive bool) ([]model.ShippingMethod, error)
+	GetByID(ctx context.Context, id int) (*model.ShippingMethod, error)
+	Update(ctx context.Context, id int, method model.ShippingMethodRequestBody) (*model.ShippingMethod, error)
+	Delete(ctx context.Context, id int) (*model.ShippingMethod, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type Comment interface {
+	Create(
+		ctx context.Context,
+		orderID int,
+		comment model.OrderCommentRequestBody,
+		userID int,
+		role model.UserRole,
+	) (*model.OrderComment, error)
+	GetAll(ctx context.Context, orderID, userID int, role model.UserRole) ([]model.OrderComment, error)
+	Delete(ctx context.Context, id, orderID, userID int, role model.UserRole) (*model.OrderComment, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type RecurringOrder interface {
+	Create(
+		ctx context.Context,
+		recurringReq model.RecurringOrderRequestBody,
+		ownerID int,
+		nextRun time.Time,
+	) (*model.RecurringOrder, error)
+	GetAll(ctx context.Context, userID int, role model.UserRole) ([]model.RecurringOrder, error)
+	GetByID(ctx context.Context, id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	Delete(ctx context.Context, id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	SetSchedule(
+		ctx context.Context,
+		id int,
+		status model.RecurringStatus,
+		nextRun time.Time,
+	) (*model.RecurringOrder, error)
+	GetDue(ctx context.Context) ([]model.RecurringOrder, error)
+	Claim(ctx context.Context, id int, scheduled, next time.Time) (bool, error)
+	AddRun(ctx context.Context, id int, run model.RecurringOrderRun) error
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type Notification interface {
+	Create(ctx context.Context, userID int, kind model.NotificationKind, message string) (*model.Notification, error)
+	GetAll(ctx context.Context, userID int, unreadOnly bool) ([]model.Notification, error)
+	MarkRead(ctx context.Context, id, userID int) (*model.Notification, error)
+}
+
+type RefreshToken interface {
+	Create(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) (*model.RefreshToken, error)
+	Rotate(ctx context.Context, tokenHash, newHash string, expiresAt time.Time) (*model.RefreshToken, error)
+	RevokeFamily(ctx context.Context, tokenHash string, userID int) error
+	RevokeAll(ctx context.Context, userID int) error
+}
+
+type PasswordReset interface {
+	Create(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error
+	Reset(ctx context.Context, tokenHash, passwordHash string) (int, error)
+}
+
+type EmailVerification interface {
+	Create(ctx context.Context, userID int, email, tokenHash string, expiresAt time.Time) error
+	CountSince(ctx context.Context, userID int, since time.Time) (int, error)
+	Verify(ctx context.Context, tokenHash string) (int, error)
+}
+
+type LoginAttempt interface {
+	LockedFor(ctx context.Context, subjects ...string) (time.Duration, error)
+	RegisterFailure(ctx context.Context, subject string, window time.Duration) (int, error)
+	Lock(ctx context.Context, subject string, duration time.Duration) error
+	Reset(ctx context.Context, subjects ...string) error
+}
+
+type TwoFactor interface {
+	Get(ctx context.Context, userID int) (*model.TwoFactor, error)
+	SaveSecret(ctx context.Context, userID int, secret string) error
+	Enable(ctx context.Context, userID int, recoveryHashes []string) error
+	Disable(ctx context.Context, userID int) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+}
+
+type TwoFactorChallenge interface {
+	Create(ctx context.Context, tokenHash string, userID int, ttl time.Duration) error
+	Get(ctx context.Context, tokenHash string) (int, error)
+	Attempt(ctx context.Context, tokenHash string) (int, int, error)
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+type TokenRevocation interface {
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID int) error
+	GetTokenVersion(ctx context.Context, userID int) (int, error)
+	IsRevoked(ctx context.Context, tokenID string, userID, version int) (bool, error)
+}
+
+type Role interface {
+	Create(ctx context.Context, role model.RoleRequestBody) (*model.Role, error)
+	GetAll(ctx context.Context) ([]model.Role, error)
+	GetByKey(ctx context.Context, key model.UserRole) (*model.Role, error)
+	Update(ctx context.Context, key model.UserRole, role model.RoleRequestBody) (*model.Role, error)
+	Delete(ctx context.Context, key model.UserRole) (*model.Role, error)
+	WriteLog(result any, operation, status, tableName string) (int64, error)
+}
+
+type APIKey interface {
+	Create(ctx context.Context, key model.APIKey) (*model.APIKey, error)
+	GetAll(ctx context.Context) ([]model.APIKey, error)
+	GetByID(ctx context.Context, id int) (*model.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	Touch(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int) (*model.APIKey, error)
 	WriteLog(result any, operation, status, tableName string) (int64, error)
 }
 
@@ -63,13 +253,49 @@ type Repository struct {
 	Order
 	Product
 	User
+	PriceList
+	PromoCode
+	Return
+	Cart
+	Address
+	ShippingMethod
+	Comment
+	RecurringOrder
+	Notification
+	RefreshToken
+	TokenRevocation
+	Role
+	PasswordReset
+	EmailVerification
+	LoginAttempt
+	TwoFactor
+	TwoFactorChallenge
+	APIKey
 }
 
 func NewRepository(db *sqlx.DB, redis *redis.Client) *Repository {
 	return &Repository{
-		Order:   NewOrdersRepository(db, redis, "ordersCollection"),
-		Product: NewProductsRepository(db, redis),
-		User:    NewUsersRepository(db, redis),
+		Order:              NewOrdersRepository(db, redis, "ordersCollection"),
+		Product:            NewProductsRepository(db, redis),
+		User:               NewUsersRepository(db, redis),
+		PriceList:          NewPriceListsRepository(db, redis),
+		PromoCode:          NewPromoCodesRepository(db, redis),
+		Return:             NewReturnsRepository(db, redis),
+		Cart:               NewCartsRepository(db, redis),
+		Address:            NewAddressesRepository(db, redis),
+		ShippingMethod:     NewShippingMethodsRepository(db, redis),
+		Comment:            NewCommentsRepository(db, redis),
+		RecurringOrder:     NewRecurringOrdersRepository(db, redis),
+		Notification:       NewNotificationsRepository(db),
+		RefreshToken:       NewRefreshTokensRepository(db),
+		TokenRevocation:    NewTokenRevocationsRepository(redis),
+		Role:               NewRolesRepository(db, redis),
+		PasswordReset:      NewPasswordResetsRepository(db),
+		EmailVerification:  NewEmailVerificationsRepository(db),
+		LoginAttempt:       NewLoginAttemptsRepository(redis),
+		TwoFactor:          NewTwoFactorRepository(db),
+		TwoFactorChallenge: NewTwoFactorChallengesRepository(redis),
+		APIKey:             NewAPIKeysRepository(db, redis),
 	}
 }
 
@@ -109,5 +335,17 @@ func isDuplicateKeyError(err error) bool {
 	if errors.As(err, &pqErr) {
 		return pqErr.Code == "23505" // Код ошибки уникальности (unique_violation)
 	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505"
+	}
+	return false
+}
+
+func isForeignKeyViolationError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23503" // Код ошибки внешнего ключа (foreign_key_violation)
+	}
 	return false
 }
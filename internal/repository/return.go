@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type ReturnsRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewReturnsRepository(db *sqlx.DB, redis *redis.Client) *ReturnsRepository {
+	return &ReturnsRepository{db: db, redis: redis}
+}
+
+// Create создает заявку на возврат. Сумма к возврату по строке пропорциональна
+// возвращаемому количеству от суммы строки заказа с учетом скидок и НДС.
+func (rr *ReturnsRepository) Create(
+	ctx context.Context,
+	returnReq model.ReturnRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.Return, error) {
+	tx, err := rr.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "SELECT * FROM orders.orders WHERE id = $1"
+	args := []interface{}{returnReq.OrderID}
+	if role.IsClient() {
+		query += " AND user_id = $2"
+		args = append(args, userID)
+	}
+	var order model.Order
+	err = tx.GetContext(ctx, &order, query+" FOR UPDATE", args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заказ не найден или не принадлежит пользователю")
+		}
+		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	if order.Status != model.StatusExecuted {
+		return nil, fmt.Errorf("вернуть можно только товары выполненного заказа")
+	}
+
+	lines := make([]model.ReturnLine, 0, len(returnReq.Lines))
+	var refundTotal int64
+	for _, lineReq := range returnReq.Lines {
+		var orderLine struct {
+			Quantity model.Quantity `db:"quantity"`
+			Returned model.Quantity `db:"returned"`
+			Refund   int64          `db:"refund"`
+		}
+		err = tx.GetContext(ctx, &orderLine, `
+			SELECT
+				op.quantity,
+				COALESCE((
+					SELECT SUM(rl.quantity)
+					FROM orders.return_lines rl
+					JOIN orders.returns r ON r.id = rl.return_id
+					WHERE r.order_id = op.order_id
+					AND rl.product_id = op.product_id
+					AND r.status <> 'rejected'
+				), 0) AS returned,
+				ROUND(
+					(op.tax_base + CASE WHEN o.tax_mode = 'exclusive' THEN op.tax_amount ELSE 0 END)
+					* $3::NUMERIC / op.quantity
+				)::BIGINT AS refund
+			FROM orders.order_products op
+			JOIN orders.orders o ON o.id = op.order_id
+			WHERE op.order_id = $1 AND op.product_id = $2
+		`, order.ID, lineReq.ProductID, lineReq.Quantity)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("товар %d отсутствует в заказе", lineReq.ProductID)
+			}
+			return nil, fmt.Errorf("ошибка получения товара %d заказа: %w", lineReq.ProductID, err)
+		}
+
+		available := orderLine.Quantity - orderLine.Returned
+		if lineReq.Quantity > available {
+			return nil, fmt.Errorf("нельзя вернуть больше, чем куплено: товар %d (доступно к возврату: %s)",
+				lineReq.ProductID, available)
+		}
+
+		lines = append(lines, model.ReturnLine{
+			ProductID:    lineReq.ProductID,
+			Quantity:     lineReq.Quantity,
+			Reason:       lineReq.Reason,
+			RefundAmount: orderLine.Refund,
+		})
+		refundTotal += orderLine.Refund
+	}
+
+	var ret model.Return
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO orders.returns (order_id, user_id, status, refund_amount, currency)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING *
+	`, order.ID, order.UserID, model.ReturnPending, refundTotal, order.Currency).StructScan(&ret)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания заявки на возврат: %w", err)
+	}
+
+	for _, line := range lines {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO orders.return_lines (return_id, product_id, quantity, reason, refund_amount)
+			VALUES ($1, $2, $3, $4, $5)
+		`, ret.ID, line.ProductID, line.Quantity, line.Reason, line.RefundAmount)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return nil, fmt.Errorf("товар %d указан в заявке несколько раз", line.ProductID)
+			}
+			return nil, fmt.Errorf("ошибка добавления товара %d в заявку на возврат: %w", line.ProductID, err)
+		}
+	}
+
+	ret.Lines, err = getReturnLines(ctx, tx, ret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &ret, nil
+}
+
+func (rr *ReturnsRepository) GetAll(ctx context.Context, userID int, role model.UserRole) ([]model.Return, error) {
+	query := "SELECT * FROM orders.returns"
+	args := []interface{}{}
+	if role.IsClient() {
+		query += " WHERE user_id = $1"
+		args = append(args, userID)
+	}
+
+	returns := []model.Return{}
+	err := rr.db.SelectContext(ctx, &returns, query+" ORDER BY id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка возвратов: %w", err)
+	}
+	for i := range returns {
+		returns[i].Lines, err = getReturnLines(ctx, rr.db, returns[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return returns, nil
+}
+
+func (rr *ReturnsRepository) GetByID(ctx context.Context, id, userID int, role model.UserRole) (*model.Return, error) {
+	query := "SELECT * FROM orders.returns WHERE id = $1"
+	args := []interface{}{id}
+	if role.IsClient() {
+		query += " AND user_id = $2"
+		args = append(args, userID)
+	}
+
+	var ret model.Return
+	err := rr.db.GetContext(ctx, &ret, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("возврат не найден")
+		}
+		return nil, fmt.Errorf("ошибка получения возврата: %w", err)
+	}
+	ret.Lines, err = getReturnLines(ctx, rr.db, ret.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// Decide фиксирует решение сотрудника. При одобрении товары возвращаются на склад,
+// а поврежденные списываются.
+func (rr *ReturnsRepository) Decide(
+	ctx context.Context,
+	id int,
+	decision model.ReturnDecisionBody,
+	employeeID int,
+) (*model.Return, error) {
+	tx, err := rr.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var ret model.Return
+	err = tx.GetContext(ctx, &ret, "SELECT * FROM orders.returns WHERE id = $1 FOR UPDATE", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("возврат не найден")
+		}
+		return nil, fmt.Errorf("ошибка получения возврата: %w", err)
+	}
+	if ret.Status != model.ReturnPending {
+		return nil, fmt.Errorf("заявка на возврат уже рассмотрена")
+	}
+
+	if decision.Status == model.ReturnApproved {
+		lines, err := getReturnLines(ctx, tx, ret.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := rr.processReturnedGoods(ctx, tx, ret.ID, lines, decision.DamagedProductIDs, employeeID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.GetContext(ctx, &ret, `
+		UPDATE orders.returns
+		SET status = $1, comment = $2, decided_by = $3, decided_date = NOW()
+		WHERE id = $4
+		RETURNING *
+	`, decision.Status, decision.Comment, employeeID, ret.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения по возврату: %w", err)
+	}
+
+	ret.Lines, err = getReturnLines(ctx, tx, ret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &ret, nil
+}
+
+func (rr *ReturnsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, rr.redis)
+}
+
+// processReturnedGoods возвращает товары на склад, а поврежденные списывает с записью в журнал списаний.
+func (rr *ReturnsRepository) processReturnedGoods(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	returnID int,
+	lines []model.ReturnLine,
+	damagedProductIDs []int,
+	employeeID int,
+) error {
+	damaged := make(map[int]bool, len(damagedProductIDs))
+	for _, productID := range damagedProductIDs {
+		damaged[productID] = true
+	}
+
+	for _, line := range lines {
+		if !damaged[line.ProductID] {
+			if err := changeStock(ctx, tx, line.ProductID, line.Quantity); err != nil {
+				return fmt.Errorf("ошибка возврата товара %d на склад: %w", line.ProductID, err)
+			}
+			continue
+		}
+
+		delete(damaged, line.ProductID)
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO products.write_offs (product_id, quantity, reason, return_id, created_by)
+			VALUES ($1, $2, $3, $4, $5)
+		`, line.ProductID, line.Quantity, line.Reason, returnID, employeeID)
+		if err != nil {
+			return fmt.Errorf("ошибка списания товара %d: %w", line.ProductID, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE orders.return_lines SET damaged = TRUE
+			WHERE return_id = $1 AND product_id = $2
+		`, returnID, line.ProductID)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления строки возврата %d: %w", line.ProductID, err)
+		}
+	}
+
+	if len(damaged) > 0 {
+		ids := make([]string, 0, len(damaged))
+		for productID := range damaged {
+			ids = append(ids, fmt.Sprint(productID))
+		}
+		return fmt.Errorf("товары %s отсутствуют в заявке на возврат", strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+func getReturnLines(ctx context.Context, q sqlx.QueryerContext, returnID int) ([]model.ReturnLine, error) {
+	lines := []model.ReturnLine{}
+	err := sqlx.SelectContext(ctx, q, &lines, `
+		SELECT rl.product_id, p.name, rl.quantity, rl.reason, rl.refund_amount, rl.damaged
+		FROM orders.return_lines rl
+		JOIN products.products p ON p.id = rl.product_id
+		WHERE rl.return_id = $1
+		ORDER BY rl.product_id
+	`, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения товаров возврата: %w", err)
+	}
+	return lines, nil
+}
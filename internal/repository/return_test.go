@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func newReturnsRepository(t *testing.T) (*ReturnsRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Ошибка создания sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewReturnsRepository(sqlx.NewDb(db, "postgres"), nil), mock
+}
+
+func TestReturnsRepository_Create(t *testing.T) {
+	lockOrder := regexp.QuoteMeta("SELECT * FROM orders.orders WHERE id = $1 AND user_id = $2 FOR UPDATE")
+	orderLine := regexp.QuoteMeta("FROM orders.order_products op")
+	orderRow := func(status string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "user_id", "status", "currency"}).AddRow(7, 1, status, "RUB")
+	}
+	lineRow := func(quantity, returned string, refund int64) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"quantity", "returned", "refund"}).AddRow(quantity, returned, refund)
+	}
+	request := model.ReturnRequestBody{
+		OrderID: 7,
+		Lines: []model.ReturnLineRequest{
+			{ProductID: 2, Quantity: model.NewQuantity(1), Reason: "Брак"},
+			{ProductID: 3, Quantity: model.NewQuantity(2), Reason: "Не подошел"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mock       func(mock sqlmock.Sqlmock)
+		wantRefund int64
+		wantErr    string
+	}{
+		{
+			name: "refund is the sum of the lines",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockOrder).WithArgs(7, 1).WillReturnRows(orderRow("executed"))
+				mock.ExpectQuery(orderLine).WithArgs(7, 2, model.NewQuantity(1)).
+					WillReturnRows(lineRow("3.000", "0", 24000))
+				mock.ExpectQuery(orderLine).WithArgs(7, 3, model.NewQuantity(2)).
+					WillReturnRows(lineRow("5.000", "2.000", 11000))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO orders.returns")).
+					WithArgs(7, 1, model.ReturnPending, int64(35000), "RUB").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount"}).
+						AddRow(4, 7, 1, "pending", 35000))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders.return_lines")).
+					WithArgs(4, 2, model.NewQuantity(1), "Брак", int64(24000)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders.return_lines")).
+					WithArgs(4, 3, model.NewQuantity(2), "Не подошел", int64(11000)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM orders.return_lines rl")).WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity", "refund_amount"}))
+				mock.ExpectCommit()
+			},
+			wantRefund: 35000,
+		},
+		{
+			name: "order is not executed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockOrder).WithArgs(7, 1).WillReturnRows(orderRow("active"))
+				mock.ExpectRollback()
+			},
+			wantErr: "только товары выполненного заказа",
+		},
+		{
+			name: "quantity includes earlier returns",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockOrder).WithArgs(7, 1).WillReturnRows(orderRow("executed"))
+				mock.ExpectQuery(orderLine).WithArgs(7, 2, model.NewQuantity(1)).
+					WillReturnRows(lineRow("3.000", "0", 24000))
+				mock.ExpectQuery(orderLine).WithArgs(7, 3, model.NewQuantity(2)).
+					WillReturnRows(lineRow("3.000", "2.000", 11000))
+				mock.ExpectRollback()
+			},
+			wantErr: "нельзя вернуть больше, чем куплено: товар 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, mock := newReturnsRepository(t)
+			mock.ExpectBegin()
+			tt.mock(mock)
+
+			ret, err := rr.Create(context.Background(), request, 1, model.RoleClient)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Create() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Create() error = %v", err)
+			} else if ret.RefundAmount != tt.wantRefund {
+				t.Errorf("Сумма к возврату = %d, want %d", ret.RefundAmount, tt.wantRefund)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
+
+func TestReturnsRepository_processReturnedGoods(t *testing.T) {
+	lines := []model.ReturnLine{
+		{ProductID: 2, Quantity: model.NewQuantity(1), Reason: "Не подошел"},
+		{ProductID: 3, Quantity: model.NewQuantity(2), Reason: "Разбита упаковка"},
+	}
+	restock := func(mock sqlmock.Sqlmock, productID int, quantity model.Quantity) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT is_bundle FROM products.products WHERE id = $1")).
+			WithArgs(productID).
+			WillReturnRows(sqlmock.NewRows([]string{"is_bundle"}).AddRow(false))
+		mock.ExpectExec(regexp.QuoteMeta("SET quantity = quantity + $1")).WithArgs(quantity, productID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	tests := []struct {
+		name    string
+		damaged []int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr string
+	}{
+		{
+			name: "all goods go back to stock",
+			mock: func(mock sqlmock.Sqlmock) {
+				restock(mock, 2, model.NewQuantity(1))
+				restock(mock, 3, model.NewQuantity(2))
+			},
+		},
+		{
+			name:    "damaged goods are written off",
+			damaged: []int{3},
+			mock: func(mock sqlmock.Sqlmock) {
+				restock(mock, 2, model.NewQuantity(1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO products.write_offs")).
+					WithArgs(3, model.NewQuantity(2), "Разбита упаковка", 4, 9).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE orders.return_lines SET damaged = TRUE")).WithArgs(4, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "damaged product is not in the return",
+			damaged: []int{5},
+			mock: func(mock sqlmock.Sqlmock) {
+				restock(mock, 2, model.NewQuantity(1))
+				restock(mock, 3, model.NewQuantity(2))
+			},
+			wantErr: "товары 5 отсутствуют в заявке на возврат",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, mock := newReturnsRepository(t)
+			mock.ExpectBegin()
+			tt.mock(mock)
+			mock.ExpectRollback()
+
+			tx, err := rr.db.BeginTxx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Ошибка начала транзакции: %v", err)
+			}
+			err = rr.processReturnedGoods(context.Background(), tx, 4, lines, tt.damaged, 9)
+			_ = tx.Rollback()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("processReturnedGoods() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("processReturnedGoods() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Не выполнены ожидаемые запросы: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type RolesRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewRolesRepository(db *sqlx.DB, redis *redis.Client) *RolesRepository {
+	return &RolesRepository{db: db, redis: redis}
+}
+
+func (rr *RolesRepository) Create(ctx context.Context, roleReq model.RoleRequestBody) (*model.Role, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var role model.Role
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO users.roles (key, name) VALUES ($1, $2)
+		RETURNING *
+	`, roleReq.Key, roleReq.Name).StructScan(&role)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("роль %s уже существует", roleReq.Key)
+		}
+		return nil, fmt.Errorf("ошибка создания роли: %w", err)
+	}
+	if err := setRolePermissions(ctx, tx, role.Key, roleReq.Permissions); err != nil {
+		return nil, err
+	}
+	role.Permissions = roleReq.Permissions
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &role, nil
+}
+
+func (rr *RolesRepository) GetAll(ctx context.Context) ([]model.Role, error) {
+	roles := []model.Role{}
+	if err := rr.db.SelectContext(ctx, &roles, "SELECT * FROM users.roles ORDER BY system DESC, key"); err != nil {
+		return nil, fmt.Errorf("ошибка получения списка ролей: %w", err)
+	}
+
+	permissions := []struct {
+		RoleKey    model.UserRole   `db:"role_key"`
+		Permission model.Permission `db:"permission"`
+	}{}
+	err := rr.db.SelectContext(ctx, &permissions, `
+		SELECT role_key, permission FROM users.role_permissions ORDER BY role_key, permission
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения разрешений ролей: %w", err)
+	}
+	index := make(map[model.UserRole]int, len(roles))
+	for i := range roles {
+		index[roles[i].Key] = i
+		roles[i].Permissions = []model.Permission{}
+	}
+	for _, permission := range permissions {
+		if i, ok := index[permission.RoleKey]; ok {
+			roles[i].Permissions = append(roles[i].Permissions, permission.Permission)
+		}
+	}
+	return roles, nil
+}
+
+func (rr *RolesRepository) GetByKey(ctx context.Context, key model.UserRole) (*model.Role, error) {
+	var role model.Role
+	if err := rr.db.GetContext(ctx, &role, "SELECT * FROM users.roles WHERE key = $1", key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("роль не найдена: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения роли: %w", err)
+	}
+	role.Permissions = []model.Permission{}
+	err := rr.db.SelectContext(ctx, &role.Permissions, `
+		SELECT permission FROM users.role_permissions WHERE role_key = $1 ORDER BY permission
+	`, key)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения разрешений роли: %w", err)
+	}
+	return &role, nil
+}
+
+// Update изменяет название роли и заменяет ее разрешения.
+func (rr *RolesRepository) Update(
+	ctx context.Context,
+	key model.UserRole,
+	roleReq model.RoleRequestBody,
+) (*model.Role, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var role model.Role
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE users.roles SET name = $1 WHERE key = $2
+		RETURNING *
+	`, roleReq.Name, key).StructScan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("роль не найдена: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка обновления роли: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM users.role_permissions WHERE role_key = $1", key); err != nil {
+		return nil, fmt.Errorf("ошибка обновления разрешений роли: %w", err)
+	}
+	if err := setRolePermissions(ctx, tx, key, roleReq.Permissions); err != nil {
+		return nil, err
+	}
+	role.Permissions = roleReq.Permissions
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return &role, nil
+}
+
+// Delete удаляет несистемную роль, если она не назначена ни одному пользователю.
+func (rr *RolesRepository) Delete(ctx context.Context, key model.UserRole) (*model.Role, error) {
+	var role model.Role
+	err := rr.db.QueryRowxContext(ctx, `
+		DELETE FROM users.roles WHERE key = $1 AND NOT system
+		RETURNING *
+	`, key).StructScan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("роль не найдена или является системной: %w", err)
+		}
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("роль %s назначена пользователям", key)
+		}
+		return nil, fmt.Errorf("ошибка удаления роли: %w", err)
+	}
+	return &role, nil
+}
+
+func (rr *RolesRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, rr.redis)
+}
+
+func setRolePermissions(ctx context.Context, tx *sqlx.Tx, key model.UserRole, permissions []model.Permission) error {
+	for _, permission := range permissions {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO users.role_permissions (role_key, permission) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, key, permission)
+		if err != nil {
+			return fmt.Errorf("ошибка добавления разрешения %s роли: %w", permission, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+type ShippingMethodsRepository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+}
+
+func NewShippingMethodsRepository(db *sqlx.DB, redis *redis.Client) *ShippingMethodsRepository {
+	return &ShippingMethodsRepository{db: db, redis: redis}
+}
+
+func (sr *ShippingMethodsRepository) Create(
+	ctx context.Context,
+	methodReq model.ShippingMethodRequestBody,
+) (*model.ShippingMethod, error) {
+	active := true
+	if methodReq.Active != nil {
+		active = *methodReq.Active
+	}
+
+	var method model.ShippingMethod
+	err := sr.db.QueryRowxContext(ctx, `
+		INSERT INTO orders.shipping_methods (
+			name,
+			rule,
+			base_cost,
+			cost_per_kg,
+			free_threshold,
+			currency,
+			active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING *
+	`,
+		methodReq.Name,
+		methodReq.Rule,
+		methodReq.BaseCost,
+		methodReq.CostPerKg,
+		methodReq.FreeThreshold,
+		methodReq.Currency,
+		active,
+	).StructScan(&method)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания способа доставки: %w", err)
+	}
+	return &method, nil
+}
+
+// GetAll возвращает способы доставки, onlyActive оставляет только доступные для выбора в заказе.
+func (sr *ShippingMethodsRepository) GetAll(ctx context.Context, onlyActive bool) ([]model.ShippingMethod, error) {
+	methods := []model.ShippingMethod{}
+	err := sr.db.SelectContext(ctx, &methods, `
+		SELECT * FROM orders.shipping_methods WHERE active OR NOT $1 ORDER BY id
+	`, onlyActive)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка способов доставки: %w", err)
+	}
+	return methods, nil
+}
+
+func (sr *ShippingMethodsRepository) GetByID(ctx context.Context, id int) (*model.ShippingMethod, error) {
+	var method model.ShippingMethod
+	err := sr.db.GetContext(ctx, &method, "SELECT * FROM orders.shipping_methods WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("способ доставки не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка получения способа доставки: %w", err)
+	}
+	return &method, nil
+}
+
+// Update изменяет правило расчета. Стоимость доставки уже оформленных заказов
+// пересчитывается только при их следующем изменении.
+func (sr *ShippingMethodsRepository) Update(
+	ctx context.Context,
+	id int,
+	methodReq model.ShippingMethodRequestBody,
+) (*model.ShippingMethod, error) {
+	var method model.ShippingMethod
+	err := sr.db.QueryRowxContext(ctx, `
+		UPDATE orders.shipping_methods SET
+			name = $1,
+			rule = $2,
+			base_cost = $3,
+			cost_per_kg = $4,
+			free_threshold = $5,
+			currency = $6,
+			active = COALESCE($7, active)
+		WHERE id = $8
+		RETURNING *
+	`,
+		methodReq.Name,
+		methodReq.Rule,
+		methodReq.BaseCost,
+		methodReq.CostPerKg,
+		methodReq.FreeThreshold,
+		methodReq.Currency,
+		methodReq.Active,
+		id,
+	).StructScan(&method)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("способ доставки не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка обновления способа доставки: %w", err)
+	}
+	return &method, nil
+}
+
+// Delete удаляет способ доставки. В заказах с этим способом остается рассчитанная стоимость.
+func (sr *ShippingMethodsRepository) Delete(ctx context.Context, id int) (*model.ShippingMethod, error) {
+	var method model.ShippingMethod
+	err := sr.db.QueryRowxContext(ctx, `
+		DELETE FROM orders.shipping_methods WHERE id = $1
+		RETURNING *
+	`, id).StructScan(&method)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("способ доставки не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка удаления способа доставки: %w", err)
+	}
+	return &method, nil
+}
+
+func (sr *ShippingMethodsRepository) WriteLog(result any, operation, status, tableName string) (int64, error) {
+	return WriteLog(result, operation, status, tableName, sr.redis)
+}
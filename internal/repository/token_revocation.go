@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	revokedTokenKeyPrefix = "revokedToken"
+	tokenVersionKeyPrefix = "tokenVersion"
+)
+
+// TokenRevocationsRepository хранит в Redis отозванные access-токены.
+// Отдельный токен попадает в список по jti до истечения своего срока, а все токены
+// пользователя отзываются увеличением его версии токенов.
+type TokenRevocationsRepository struct {
+	redis *redis.Client
+}
+
+func NewTokenRevocationsRepository(redis *redis.Client) *TokenRevocationsRepository {
+	return &TokenRevocationsRepository{redis: redis}
+}
+
+// RevokeToken добавляет токен в список отозванных. Запись удаляется, когда токен истекает сам.
+func (tr *TokenRevocationsRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s:%s", revokedTokenKeyPrefix, tokenID)
+	if err := tr.redis.Set(ctx, key, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("ошибка отзыва токена: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens отзывает все выданные пользователю access-токены.
+func (tr *TokenRevocationsRepository) RevokeUserTokens(ctx context.Context, userID int) error {
+	key := fmt.Sprintf("%s:%d", tokenVersionKeyPrefix, userID)
+	if err := tr.redis.Incr(ctx, key).Err(); err != nil {
+		return fmt.Errorf("ошибка отзыва токенов пользователя: %w", err)
+	}
+	return nil
+}
+
+// GetTokenVersion возвращает версию, с которой выпускаются новые токены пользователя.
+func (tr *TokenRevocationsRepository) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	key := fmt.Sprintf("%s:%d", tokenVersionKeyPrefix, userID)
+	version, err := tr.redis.Get(ctx, key).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("ошибка получения версии токенов: %w", err)
+	}
+	return version, nil
+}
+
+// IsRevoked проверяет токен по jti и версии токенов пользователя за один запрос к Redis.
+func (tr *TokenRevocationsRepository) IsRevoked(
+	ctx context.Context,
+	tokenID string,
+	userID, version int,
+) (bool, error) {
+	pipe := tr.redis.Pipeline()
+	revoked := pipe.Exists(ctx, fmt.Sprintf("%s:%s", revokedTokenKeyPrefix, tokenID))
+	current := pipe.Get(ctx, fmt.Sprintf("%s:%d", tokenVersionKeyPrefix, userID))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return false, fmt.Errorf("ошибка проверки отзыва токена: %w", err)
+	}
+	if revoked.Val() > 0 {
+		return true, nil
+	}
+	currentVersion := 0
+	if value := current.Val(); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return false, fmt.Errorf("некорректная версия токенов пользователя %d: %w", userID, err)
+		}
+		currentVersion = parsed
+	}
+	return version != currentVersion, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TwoFactorRepository struct {
+	db *sqlx.DB
+}
+
+func NewTwoFactorRepository(db *sqlx.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+func (tr *TwoFactorRepository) Get(ctx context.Context, userID int) (*model.TwoFactor, error) {
+	var twoFactor model.TwoFactor
+	err := tr.db.GetContext(ctx, &twoFactor, `
+		SELECT * FROM users.two_factor WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("двухфакторная аутентификация не настроена")
+		}
+		return nil, fmt.Errorf("ошибка получения настройки двухфакторной аутентификации: %w", err)
+	}
+	return &twoFactor, nil
+}
+
+// SaveSecret сохраняет новый, еще не подтвержденный секрет. Секрет включенной
+// двухфакторной аутентификации не перезаписывается.
+func (tr *TwoFactorRepository) SaveSecret(ctx context.Context, userID int, secret string) error {
+	result, err := tr.db.ExecContext(ctx, `
+		INSERT INTO users.two_factor (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_date = NOW()
+		WHERE users.two_factor.enabled_at IS NULL
+	`, userID, secret)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения секрета двухфакторной аутентификации: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения секрета двухфакторной аутентификации: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("двухфакторная аутентификация уже включена")
+	}
+	return nil
+}
+
+// Enable включает двухфакторную аутентификацию и заменяет коды восстановления.
+func (tr *TwoFactorRepository) Enable(ctx context.Context, userID int, recoveryHashes []string) error {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users.two_factor SET enabled_at = NOW()
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка включения двухфакторной аутентификации: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка включения двухфакторной аутентификации: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("двухфакторная аутентификация уже включена")
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users.recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления кодов восстановления: %w", err)
+	}
+	for _, hash := range recoveryHashes {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users.recovery_codes (user_id, code_hash) VALUES ($1, $2)
+		`, userID, hash)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения кода восстановления: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// Disable удаляет секрет и коды восстановления пользователя.
+func (tr *TwoFactorRepository) Disable(ctx context.Context, userID int) error {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users.recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления кодов восстановления: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM users.two_factor WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка отключения двухфакторной аутентификации: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode погашает код восстановления. Возвращает false, если кода нет или он уже использован.
+func (tr *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	result, err := tr.db.ExecContext(ctx, `
+		UPDATE users.recovery_codes SET used_date = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_date IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки кода восстановления: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки кода восстановления: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
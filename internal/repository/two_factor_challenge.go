@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const twoFactorChallengeKeyPrefix = "twoFactorChallenge"
+
+// TwoFactorChallengesRepository хранит в Redis незавершенные входы: пароль проверен,
+// но код второго фактора еще не введен. Вход ищется по хешу выданного клиенту токена.
+type TwoFactorChallengesRepository struct {
+	redis *redis.Client
+}
+
+func NewTwoFactorChallengesRepository(redis *redis.Client) *TwoFactorChallengesRepository {
+	return &TwoFactorChallengesRepository{redis: redis}
+}
+
+func (cr *TwoFactorChallengesRepository) Create(
+	ctx context.Context,
+	tokenHash string,
+	userID int,
+	ttl time.Duration,
+) error {
+	key := fmt.Sprintf("%s:%s", twoFactorChallengeKeyPrefix, tokenHash)
+	pipe := cr.redis.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", userID, "attempts", 0)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("ошибка сохранения входа: %w", err)
+	}
+	return nil
+}
+
+// Get возвращает ID пользователя, начавшего вход.
+func (cr *TwoFactorChallengesRepository) Get(ctx context.Context, tokenHash string) (int, error) {
+	key := fmt.Sprintf("%s:%s", twoFactorChallengeKeyPrefix, tokenHash)
+	userID, err := cr.redis.HGet(ctx, key, "user_id").Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("сессия входа истекла")
+		}
+		return 0, fmt.Errorf("ошибка получения входа: %w", err)
+	}
+	return userID, nil
+}
+
+// Attempt учитывает попытку ввода кода и возвращает ID пользователя и число попыток вместе с текущей.
+func (cr *TwoFactorChallengesRepository) Attempt(ctx context.Context, tokenHash string) (int, int, error) {
+	userID, err := cr.Get(ctx, tokenHash)
+	if err != nil {
+		return 0, 0, err
+	}
+	key := fmt.Sprintf("%s:%s", twoFactorChallengeKeyPrefix, tokenHash)
+	attempts, err := cr.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("ошибка учета попытки входа: %w", err)
+	}
+	return userID, int(attempts), nil
+}
+
+func (cr *TwoFactorChallengesRepository) Delete(ctx context.Context, tokenHash string) error {
+	key := fmt.Sprintf("%s:%s", twoFactorChallengeKeyPrefix, tokenHash)
+	if err := cr.redis.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("ошибка удаления входа: %w", err)
+	}
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 
 	"github.com/mikhailshtv/stockLkBack/internal/model"
-	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
@@ -90,7 +89,8 @@ func (ur *UsersRepository) GetByID(ctx context.Context, id int) (*model.User, er
 			first_name,
 			last_name,
 			email,
-			role
+			role,
+			email_verified_at
 		FROM users.users
 		WHERE id = $1
 		LIMIT 1
@@ -108,6 +108,34 @@ func (ur *UsersRepository) GetByID(ctx context.Context, id int) (*model.User, er
 	return &user, nil
 }
 
+// GetByEmail ищет пользователя по email без учета регистра.
+func (ur *UsersRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	const query = `
+		SELECT 
+			id,
+			login,
+			first_name,
+			last_name,
+			email,
+			role,
+			email_verified_at
+		FROM users.users
+		WHERE LOWER(email) = LOWER($1)
+		LIMIT 1
+	`
+
+	var user model.User
+	err := ur.db.QueryRowxContext(ctx, query, email).StructScan(&user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("пользователь не найден: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка при получении пользователя: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (ur *UsersRepository) Delete(ctx context.Context, id int) (*model.User, error) {
 	const query = `
 		WITH deleted AS (
@@ -150,7 +178,9 @@ func (ur *UsersRepository) Update(ctx context.Context, id int, userReq model.Use
 		UPDATE users.users SET
 			first_name = $1,
 			last_name = $2,
-			email = $3
+			email = $3,
+			-- смена адреса требует его повторного подтверждения
+			email_verified_at = CASE WHEN email = $3 THEN email_verified_at END
 		WHERE id = $4
 		RETURNING *
 	`
@@ -177,7 +207,8 @@ func (ur *UsersRepository) Update(ctx context.Context, id int, userReq model.Use
 	return &updatedUser, nil
 }
 
-func (ur *UsersRepository) Login(ctx context.Context, userReq model.LoginRequest) (*model.TokenSuccess, error) {
+// Login проверяет учетные данные и возвращает пользователя. Токены выпускает сервис.
+func (ur *UsersRepository) Login(ctx context.Context, userReq model.LoginRequest) (*model.User, error) {
 	const query = `
 		SELECT
 			id,
@@ -198,17 +229,10 @@ func (ur *UsersRepository) Login(ctx context.Context, userReq model.LoginRequest
 		}
 		return nil, fmt.Errorf("ошибка запроса к базе: %w", err)
 	}
-	if user.CheckUserPassword(userReq.Password) {
-		token, err := jwtgen.GenerateToken(user.ID, user.Login, user.Role)
-		if err != nil {
-			return nil, errors.New("ошибка генерации токена")
-		}
-		return &model.TokenSuccess{
-			Message: "аутентификация успешна",
-			Token:   token,
-		}, nil
+	if !user.CheckUserPassword(userReq.Password) {
+		return nil, errors.New("логин или пароль пользователя недействителен")
 	}
-	return nil, errors.New("логин или пароль пользователя недействителен")
+	return &user, nil
 }
 
 func (ur *UsersRepository) ChangeUserRole(
@@ -231,11 +255,39 @@ func (ur *UsersRepository) ChangeUserRole(
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("пользователь не найден: %w", err)
 		}
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("недопустимая роль: %s", userRoleReq.Role)
+		}
 		return nil, fmt.Errorf("ошибка при обновлении роли: %w", err)
 	}
 	return &updatedUser, nil
 }
 
+func (ur *UsersRepository) ChangeCustomerGroup(
+	ctx context.Context,
+	id int,
+	groupReq model.UserCustomerGroupBody,
+) (*model.User, error) {
+	const query = `
+		UPDATE users.users SET
+			customer_group_id = $1
+		WHERE id = $2
+		RETURNING *
+	`
+	var updatedUser model.User
+	err := ur.db.QueryRowxContext(ctx, query, groupReq.CustomerGroupID, id).StructScan(&updatedUser)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("пользователь не найден: %w", err)
+		}
+		if isForeignKeyViolationError(err) {
+			return nil, fmt.Errorf("группа клиентов не найдена: %w", err)
+		}
+		return nil, fmt.Errorf("ошибка при изменении группы клиентов: %w", err)
+	}
+	return &updatedUser, nil
+}
+
 func (ur *UsersRepository) ChangePassword(
 	ctx context.Context,
 	id int,
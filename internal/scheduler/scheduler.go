@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Job периодическая фоновая задача.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+type Scheduler struct {
+	jobs []Job
+}
+
+func NewScheduler(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+// Start запускает все задачи и блокируется до отмены контекста.
+func (s *Scheduler) Start(ctx context.Context) {
+	done := make(chan struct{}, len(s.jobs))
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			logger.GetLogger().Warn("scheduler job disabled: interval is not set",
+				zap.String("job", job.Name),
+			)
+			done <- struct{}{}
+			continue
+		}
+		go func() {
+			defer func() { done <- struct{}{} }()
+			runJob(ctx, job)
+		}()
+	}
+	for range s.jobs {
+		<-done
+	}
+}
+
+func runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	logger.GetLogger().Info("scheduler job started",
+		zap.String("job", job.Name),
+		zap.Duration("interval", job.Interval),
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			logger.GetLogger().Info("scheduler job stopped", zap.String("job", job.Name))
+			return
+		case <-ticker.C:
+			if err := job.Run(); err != nil {
+				logger.GetLogger().Error("scheduler job failed",
+					zap.String("job", job.Name),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
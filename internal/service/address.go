@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logAddressesTableName = "logAddress"
+)
+
+type AddressesService struct {
+	repo repository.Address
+	ctx  context.Context
+}
+
+func NewAddressesService(ctx context.Context, repo repository.Address) *AddressesService {
+	return &AddressesService{repo: repo, ctx: ctx}
+}
+
+func (s *AddressesService) Create(userID int, addressReq model.AddressRequestBody) (*model.Address, error) {
+	if err := normalizeAddressRequest(&addressReq); err != nil {
+		return nil, err
+	}
+	createdAddress, err := s.repo.Create(s.ctx, userID, addressReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create address in repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("address created successfully",
+			zap.Int("address_id", createdAddress.ID),
+			zap.Int("user_id", userID),
+		)
+		result = createdAddress
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logAddressesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for address creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdAddress, err
+}
+
+func (s *AddressesService) GetAll(userID int) ([]model.Address, error) {
+	addresses, err := s.repo.GetAll(s.ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get addresses from repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка адресов", err)
+	}
+	return addresses, nil
+}
+
+func (s *AddressesService) GetByID(id, userID int) (*model.Address, error) {
+	address, err := s.repo.GetByID(s.ctx, id, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get address by ID from repository",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "адрес не найден") {
+			return nil, errors.NewNotFoundError("адрес", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения адреса", err)
+	}
+	return address, nil
+}
+
+func (s *AddressesService) Update(id, userID int, addressReq model.AddressRequestBody) (*model.Address, error) {
+	if err := normalizeAddressRequest(&addressReq); err != nil {
+		return nil, err
+	}
+	updatedAddress, err := s.repo.Update(s.ctx, id, userID, addressReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to update address in repository",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("address updated successfully",
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = updatedAddress
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Update", status, logAddressesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for address update",
+			zap.Error(logErr),
+		)
+	}
+	return updatedAddress, err
+}
+
+func (s *AddressesService) Delete(id, userID int) error {
+	deletedAddress, err := s.repo.Delete(s.ctx, id, userID)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to delete address from repository",
+			zap.Error(err),
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("address deleted successfully",
+			zap.Int("address_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = deletedAddress
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Delete", status, logAddressesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for address deletion",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
+func normalizeAddressRequest(addressReq *model.AddressRequestBody) error {
+	addressReq.Recipient = strings.TrimSpace(addressReq.Recipient)
+	addressReq.Phone = strings.TrimSpace(addressReq.Phone)
+	addressReq.PostalCode = strings.TrimSpace(addressReq.PostalCode)
+	addressReq.City = strings.TrimSpace(addressReq.City)
+	addressReq.Street = strings.TrimSpace(addressReq.Street)
+	if addressReq.Recipient == "" || addressReq.Phone == "" || addressReq.City == "" || addressReq.Street == "" {
+		return errors.NewValidationError("получатель, телефон, город и улица обязательны", nil)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logAPIKeysTableName = "logAPIKey"
+
+	// apiKeyTouchInterval как часто обновляется время последнего использования ключа:
+	// интеграции вызывают API часто, и запись в базу на каждый запрос не нужна.
+	apiKeyTouchInterval = time.Minute
+)
+
+// APIKeysService выпускает ключи API для интеграций и проверяет предъявленные ключи.
+type APIKeysService struct {
+	repo  repository.APIKey
+	users repository.User
+	roles Role
+	ctx   context.Context
+}
+
+func NewAPIKeysService(ctx context.Context, repo repository.APIKey, users repository.User, roles Role) *APIKeysService {
+	return &APIKeysService{repo: repo, users: users, roles: roles, ctx: ctx}
+}
+
+// Create выпускает ключ от имени пользователя keyReq.UserID. Ключу можно выдать только
+// разрешения, которые есть у роли этого пользователя.
+func (s *APIKeysService) Create(createdBy int, keyReq model.APIKeyRequestBody) (*model.APIKeyCreated, error) {
+	keyReq.Name = strings.TrimSpace(keyReq.Name)
+	if keyReq.Name == "" {
+		return nil, errors.NewValidationError("название ключа не может быть пустым", nil)
+	}
+	if len(keyReq.Scopes) == 0 {
+		return nil, errors.NewValidationError("укажите разрешения ключа", nil)
+	}
+	scopes := make(model.PermissionSet, len(keyReq.Scopes))
+	scopeList := make([]model.Permission, 0, len(keyReq.Scopes))
+	for _, scope := range keyReq.Scopes {
+		if !scope.Valid() {
+			return nil, errors.NewValidationError("неизвестное разрешение: "+string(scope), nil)
+		}
+		if !scopes.Has(scope) {
+			scopes[scope] = struct{}{}
+			scopeList = append(scopeList, scope)
+		}
+	}
+	if keyReq.ExpiresAt != nil && !keyReq.ExpiresAt.After(time.Now()) {
+		return nil, errors.NewValidationError("срок действия ключа должен быть в будущем", nil)
+	}
+
+	user, err := s.users.GetByID(s.ctx, keyReq.UserID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for api key",
+			zap.Error(err),
+			zap.Int("user_id", keyReq.UserID),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return nil, errors.NewNotFoundError("пользователь", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	permissions, err := s.roles.Permissions(user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.Contains(scopes) {
+		return nil, errors.NewValidationError("у роли пользователя нет разрешений, запрошенных для ключа", nil)
+	}
+
+	key, prefix, hash, err := jwtgen.NewAPIKey()
+	if err != nil {
+		return nil, errors.NewInternalError("ошибка генерации ключа API", err)
+	}
+	apiKey := model.APIKey{
+		Name:      keyReq.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		UserID:    user.ID,
+		Scopes:    scopeList,
+		ExpiresAt: keyReq.ExpiresAt,
+		CreatedBy: &createdBy,
+	}
+	createdKey, err := s.repo.Create(s.ctx, apiKey)
+	s.writeLog(createdKey, err, "Create", prefix)
+	if err != nil {
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return nil, errors.NewNotFoundError("пользователь", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка создания ключа API", err)
+	}
+	return &model.APIKeyCreated{APIKey: *createdKey, Key: key}, nil
+}
+
+func (s *APIKeysService) GetAll() ([]model.APIKey, error) {
+	keys, err := s.repo.GetAll(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get api keys from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка ключей API", err)
+	}
+	return keys, nil
+}
+
+func (s *APIKeysService) GetByID(id int) (*model.APIKey, error) {
+	key, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get api key from repository",
+			zap.Error(err),
+			zap.Int("api_key_id", id),
+		)
+		if strings.Contains(err.Error(), "ключ API не найден") {
+			return nil, errors.NewNotFoundError("ключ API", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения ключа API", err)
+	}
+	return key, nil
+}
+
+// Delete отзывает ключ: запросы с ним сразу перестают приниматься.
+func (s *APIKeysService) Delete(id int) error {
+	deletedKey, err := s.repo.Delete(s.ctx, id)
+	var prefix string
+	if deletedKey != nil {
+		prefix = deletedKey.Prefix
+	}
+	s.writeLog(deletedKey, err, "Delete", prefix)
+	if err != nil && strings.Contains(err.Error(), "ключ API не найден") {
+		return errors.NewNotFoundError("ключ API", err)
+	}
+	return err
+}
+
+// Authenticate проверяет предъявленный ключ, вычисляет его действующие разрешения
+// и отмечает время его использования.
+func (s *APIKeysService) Authenticate(key string) (*model.APIKey, error) {
+	if !strings.HasPrefix(key, jwtgen.APIKeyPrefix) {
+		return nil, errors.NewUnauthorizedError("ключ API недействителен", nil)
+	}
+	apiKey, err := s.repo.GetByHash(s.ctx, jwtgen.HashToken(key))
+	if err != nil {
+		if strings.Contains(err.Error(), "ключ API не найден") {
+			return nil, errors.NewUnauthorizedError("ключ API недействителен", err)
+		}
+		logger.GetLogger().Error("failed to get api key by hash",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка проверки ключа API", err)
+	}
+	now := time.Now()
+	if apiKey.Expired(now) {
+		return nil, errors.NewUnauthorizedError("срок действия ключа API истек", nil)
+	}
+	// Ключ не дает больше, чем сейчас есть у роли владельца: права, отнятые у роли
+	// после выпуска ключа, ему тоже недоступны.
+	permissions, err := s.roles.Permissions(apiKey.Role)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.Permissions = permissions.Intersect(model.NewPermissionSet(apiKey.Scopes))
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.repo.Touch(s.ctx, apiKey.ID); err != nil {
+			logger.GetLogger().Error("failed to update api key last use",
+				zap.Error(err),
+				zap.String("prefix", apiKey.Prefix),
+			)
+		}
+	}
+	return apiKey, nil
+}
+
+func (s *APIKeysService) writeLog(key *model.APIKey, err error, operation, prefix string) {
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to change api key in repository",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("prefix", prefix),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("api key changed successfully",
+			zap.String("operation", operation),
+			zap.String("prefix", prefix),
+		)
+		result = key
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, operation, status, logAPIKeysTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for api key change",
+			zap.Error(logErr),
+		)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	mocks "github.com/mikhailshtv/stockLkBack/internal/service/mocks"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAPIKeysService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockAPIKey(ctrl)
+	rolesMock := mocks.NewMockRole(ctrl)
+
+	const key = "slk_0a1b2c3d_secret"
+	hash := jwtgen.HashToken(key)
+	recently := time.Now().Add(-time.Second)
+	expired := time.Now().Add(-time.Hour)
+	warehouse := model.NewPermissionSet([]model.Permission{
+		model.PermOrdersRead,
+		model.PermOrdersExecute,
+		model.PermProductsWrite,
+	})
+
+	tests := []struct {
+		name     string
+		key      string
+		mock     func()
+		want     model.PermissionSet
+		wantType apperrors.ErrorType
+	}{
+		{
+			name: "scopes are limited by the owner role",
+			key:  key,
+			mock: func() {
+				repoMock.EXPECT().GetByHash(gomock.Any(), hash).Return(&model.APIKey{
+					ID:         1,
+					Role:       model.RoleWarehouse,
+					Scopes:     []model.Permission{model.PermOrdersRead, model.PermPricingManage},
+					LastUsedAt: &recently,
+				}, nil)
+				rolesMock.EXPECT().Permissions(model.RoleWarehouse).Return(warehouse, nil)
+			},
+			want: model.NewPermissionSet([]model.Permission{model.PermOrdersRead}),
+		},
+		{
+			name: "first use is recorded",
+			key:  key,
+			mock: func() {
+				repoMock.EXPECT().GetByHash(gomock.Any(), hash).Return(&model.APIKey{
+					ID:     2,
+					Role:   model.RoleWarehouse,
+					Scopes: []model.Permission{model.PermProductsWrite},
+				}, nil)
+				rolesMock.EXPECT().Permissions(model.RoleWarehouse).Return(warehouse, nil)
+				repoMock.EXPECT().Touch(gomock.Any(), 2).Return(nil)
+			},
+			want: model.NewPermissionSet([]model.Permission{model.PermProductsWrite}),
+		},
+		{
+			name:     "foreign prefix",
+			key:      "Bearer secret",
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name: "unknown key",
+			key:  key,
+			mock: func() {
+				repoMock.EXPECT().GetByHash(gomock.Any(), hash).Return(nil, errors.New("ключ API не найден"))
+			},
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name: "expired key",
+			key:  key,
+			mock: func() {
+				repoMock.EXPECT().GetByHash(gomock.Any(), hash).Return(&model.APIKey{
+					ID:        3,
+					Role:      model.RoleWarehouse,
+					ExpiresAt: &expired,
+				}, nil)
+			},
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name: "role permissions unavailable",
+			key:  key,
+			mock: func() {
+				repoMock.EXPECT().GetByHash(gomock.Any(), hash).Return(&model.APIKey{
+					ID:   4,
+					Role: model.RoleWarehouse,
+				}, nil)
+				rolesMock.EXPECT().Permissions(model.RoleWarehouse).Return(
+					nil, apperrors.NewDatabaseError("ошибка получения разрешений ролей", nil),
+				)
+			},
+			wantType: apperrors.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAPIKeysService(context.Background(), repoMock, nil, rolesMock)
+
+			tt.mock()
+
+			got, err := s.Authenticate(tt.key)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка проверки ключа error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка проверки ключа error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got.Permissions, tt.want) {
+				t.Errorf("Разрешения ключа = %v, want %v", got.Permissions, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logCartsTableName = "logCart"
+)
+
+type CartsService struct {
+	repo   repository.Cart
+	orders Order
+	ctx    context.Context
+}
+
+// NewCartsService создает сервис корзины. Оформление корзины выполняется через сервис заказов,
+// поэтому к заказу из корзины применяются те же проверки, что и к заказу, созданному напрямую.
+func NewCartsService(ctx context.Context, repo repository.Cart, orders Order) *CartsService {
+	return &CartsService{repo: repo, orders: orders, ctx: ctx}
+}
+
+// Get возвращает корзину с актуальными ценами, остатками и списком проблем, мешающих оформлению.
+func (s *CartsService) Get(userID int) (*model.Cart, error) {
+	items, err := s.repo.GetItems(s.ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get cart from repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения корзины", err)
+	}
+	cart := &model.Cart{Items: items}
+	cart.Check()
+	return cart, nil
+}
+
+func (s *CartsService) AddItem(userID int, item model.CartItemRequest) (*model.Cart, error) {
+	if item.ProductID <= 0 {
+		return nil, errors.NewValidationError("не указан товар", nil)
+	}
+	if item.Quantity <= 0 {
+		return nil, errors.NewValidationError("количество товара должно быть положительным", nil)
+	}
+	if err := s.repo.AddItem(s.ctx, userID, item); err != nil {
+		logger.GetLogger().Error("failed to add item to cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", item.ProductID),
+		)
+		return nil, err
+	}
+	return s.Get(userID)
+}
+
+func (s *CartsService) UpdateItem(userID int, item model.CartItemRequest) (*model.Cart, error) {
+	if item.Quantity <= 0 {
+		return nil, errors.NewValidationError("количество товара должно быть положительным", nil)
+	}
+	if err := s.repo.UpdateItem(s.ctx, userID, item); err != nil {
+		logger.GetLogger().Error("failed to update cart item",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", item.ProductID),
+		)
+		return nil, err
+	}
+	return s.Get(userID)
+}
+
+func (s *CartsService) RemoveItem(userID, productID int) (*model.Cart, error) {
+	if err := s.repo.RemoveItem(s.ctx, userID, productID); err != nil {
+		logger.GetLogger().Error("failed to remove cart item",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+			zap.Int("product_id", productID),
+		)
+		return nil, err
+	}
+	return s.Get(userID)
+}
+
+func (s *CartsService) Clear(userID int) error {
+	if err := s.repo.Clear(s.ctx, userID); err != nil {
+		logger.GetLogger().Error("failed to clear cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return errors.NewDatabaseError("ошибка очистки корзины", err)
+	}
+	return nil
+}
+
+// Checkout оформляет корзину в заказ и очищает ее. Если часть строк стала недоступна,
+// заказ не создается, а ошибка перечисляет проблемные строки.
+func (s *CartsService) Checkout(userID int, checkout model.CartCheckoutBody) (*model.Order, error) {
+	cart, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, errors.NewValidationError("корзина пуста", nil)
+	}
+	if !cart.Valid() {
+		messages := make([]string, 0, len(cart.Issues))
+		for _, issue := range cart.Issues {
+			messages = append(messages, issue.Message)
+		}
+		return nil, errors.NewValidationError("корзину нельзя оформить: "+strings.Join(messages, "; "), nil)
+	}
+
+	// Корзина всегда оформляется на ее владельца, поэтому роль не дает дополнительных прав.
+	order, err := s.orders.Create(cart.OrderRequest(checkout), userID, model.RoleClient)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to checkout cart",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("cart checked out successfully",
+			zap.Int("order_id", order.ID),
+			zap.Int("user_id", userID),
+		)
+		result = order
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Checkout", status, logCartsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for cart checkout",
+			zap.Error(logErr),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Заказ уже создан, поэтому ошибка очистки корзины не должна превращать оформление в неуспешное.
+	if err := s.repo.Clear(s.ctx, userID); err != nil {
+		logger.GetLogger().Error("failed to clear cart after checkout",
+			zap.Error(err),
+			zap.Int("order_id", order.ID),
+			zap.Int("user_id", userID),
+		)
+	}
+	return order, nil
+}
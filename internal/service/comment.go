@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logCommentsTableName = "logOrderComment"
+)
+
+type CommentsService struct {
+	repo repository.Comment
+	ctx  context.Context
+}
+
+func NewCommentsService(ctx context.Context, repo repository.Comment) *CommentsService {
+	return &CommentsService{repo: repo, ctx: ctx}
+}
+
+func (s *CommentsService) Create(
+	orderID int,
+	commentReq model.OrderCommentRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.OrderComment, error) {
+	if commentReq.Internal && role.IsClient() {
+		return nil, errors.NewForbiddenError("Внутренние заметки может оставлять только сотрудник", nil)
+	}
+	if err := normalizeCommentRequest(&commentReq); err != nil {
+		return nil, err
+	}
+	createdComment, err := s.repo.Create(s.ctx, orderID, commentReq, userID, role)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create order comment in repository",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("order comment created successfully",
+			zap.Int("comment_id", createdComment.ID),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		result = createdComment
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logCommentsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for order comment creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdComment, err
+}
+
+// GetAll возвращает комментарии заказа деревом ответов.
+func (s *CommentsService) GetAll(orderID, userID int, role model.UserRole) ([]model.OrderComment, error) {
+	comments, err := s.repo.GetAll(s.ctx, orderID, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order comments from repository",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "заказ не найден") {
+			return nil, errors.NewNotFoundError("заказ", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения комментариев заказа", err)
+	}
+	return model.BuildCommentThreads(comments), nil
+}
+
+func (s *CommentsService) Delete(id, orderID, userID int, role model.UserRole) error {
+	deletedComment, err := s.repo.Delete(s.ctx, id, orderID, userID, role)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to delete order comment from repository",
+			zap.Error(err),
+			zap.Int("comment_id", id),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("order comment deleted successfully",
+			zap.Int("comment_id", id),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		result = deletedComment
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Delete", status, logCommentsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for order comment deletion",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
+func normalizeCommentRequest(commentReq *model.OrderCommentRequestBody) error {
+	commentReq.Body = strings.TrimSpace(commentReq.Body)
+	if commentReq.Body == "" {
+		return errors.NewValidationError("текст комментария не может быть пустым", nil)
+	}
+	for i := range commentReq.Attachments {
+		attachment := &commentReq.Attachments[i]
+		attachment.FileName = strings.TrimSpace(attachment.FileName)
+		attachment.URL = strings.TrimSpace(attachment.URL)
+		if attachment.FileName == "" || attachment.URL == "" {
+			return errors.NewValidationError("у вложения должны быть указаны имя файла и ссылка", nil)
+		}
+		if attachment.Size < 0 {
+			return errors.NewValidationError("размер вложения не может быть отрицательным", nil)
+		}
+		if attachment.ContentType == "" {
+			attachment.ContentType = "application/octet-stream"
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/document"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type DocumentsService struct {
+	orders   repository.Order
+	users    repository.User
+	products repository.Product
+	seller   config.Seller
+	ctx      context.Context
+}
+
+func NewDocumentsService(
+	ctx context.Context,
+	orders repository.Order,
+	users repository.User,
+	products repository.Product,
+	seller config.Seller,
+) *DocumentsService {
+	return &DocumentsService{orders: orders, users: users, products: products, seller: seller, ctx: ctx}
+}
+
+func (s *DocumentsService) Invoice(orderID, userID int, role model.UserRole) ([]byte, error) {
+	data, err := s.documentData(orderID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if data.Order.Status == model.StatusDeleted {
+		return nil, errors.NewValidationError("нельзя выставить счет по удаленному заказу", nil)
+	}
+
+	pdf, err := document.Invoice(*data)
+	if err != nil {
+		logger.GetLogger().Error("failed to render invoice",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+		)
+		return nil, err
+	}
+	return pdf, nil
+}
+
+func (s *DocumentsService) DeliveryNote(orderID, userID int, role model.UserRole) ([]byte, error) {
+	data, err := s.documentData(orderID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if data.Order.Status != model.StatusExecuted {
+		return nil, errors.NewValidationError("накладную можно сформировать только по выполненному заказу", nil)
+	}
+
+	pdf, err := document.DeliveryNote(*data)
+	if err != nil {
+		logger.GetLogger().Error("failed to render delivery note",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+		)
+		return nil, err
+	}
+	return pdf, nil
+}
+
+// documentData собирает заказ, профиль клиента и справочник единиц измерения для печатной формы.
+func (s *DocumentsService) documentData(orderID, userID int, role model.UserRole) (*document.Data, error) {
+	order, err := s.orders.GetByID(s.ctx, orderID, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order for document",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("user_id", userID),
+		)
+		return nil, err
+	}
+
+	client, err := s.users.GetByID(s.ctx, order.UserID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order client for document",
+			zap.Error(err),
+			zap.Int("order_id", orderID),
+			zap.Int("client_id", order.UserID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения покупателя", err)
+	}
+
+	units, err := s.products.GetUnits(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get units for document", zap.Error(err))
+		return nil, errors.NewDatabaseError("ошибка получения единиц измерения", err)
+	}
+	unitNames := make(map[string]string, len(units))
+	for _, unit := range units {
+		unitNames[unit.Code] = unit.Name
+	}
+
+	return &document.Data{
+		Seller: s.seller,
+		Order:  *order,
+		Client: *client,
+		Units:  unitNames,
+	}, nil
+}
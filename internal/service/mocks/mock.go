@@ -35,32 +35,32 @@ func (m *MockOrder) EXPECT() *MockOrderMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockOrder) Create(order model.OrderRequestBody, userID int) (*model.Order, error) {
+func (m *MockOrder) Create(order model.OrderRequestBody, userID int, role model.UserRole) (*model.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", order, userID)
+	ret := m.ctrl.Call(m, "Create", order, userID, role)
 	ret0, _ := ret[0].(*model.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockOrderMockRecorder) Create(order, userID interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) Create(order, userID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), order, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), order, userID, role)
 }
 
 // Delete mocks base method.
-func (m *MockOrder) Delete(id, userID int) error {
+func (m *MockOrder) Delete(id, userID int, role model.UserRole) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret := m.ctrl.Call(m, "Delete", id, userID, role)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockOrderMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) Delete(id, userID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrder)(nil).Delete), id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrder)(nil).Delete), id, userID, role)
 }
 
 // GetAll mocks base method.
@@ -93,34 +93,64 @@ func (mr *MockOrderMockRecorder) GetByID(id, userID, role interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrder)(nil).GetByID), id, userID, role)
 }
 
+// Reorder mocks base method.
+func (m *MockOrder) Reorder(id, userID int, role model.UserRole) (*model.ReorderResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", id, userID, role)
+	ret0, _ := ret[0].(*model.ReorderResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockOrderMockRecorder) Reorder(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockOrder)(nil).Reorder), id, userID, role)
+}
+
+// Ship mocks base method.
+func (m *MockOrder) Ship(id int, shipment model.ShipmentRequestBody, userID int) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ship", id, shipment, userID)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ship indicates an expected call of Ship.
+func (mr *MockOrderMockRecorder) Ship(id, shipment, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ship", reflect.TypeOf((*MockOrder)(nil).Ship), id, shipment, userID)
+}
+
 // Update mocks base method.
-func (m *MockOrder) Update(id int, order model.OrderRequestBody, userID int) (*model.Order, error) {
+func (m *MockOrder) Update(id int, order model.OrderRequestBody, userID int, role model.UserRole) (*model.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, order, userID)
+	ret := m.ctrl.Call(m, "Update", id, order, userID, role)
 	ret0, _ := ret[0].(*model.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockOrderMockRecorder) Update(id, order, userID interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) Update(id, order, userID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrder)(nil).Update), id, order, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrder)(nil).Update), id, order, userID, role)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockOrder) UpdateStatus(id int, orderStatusRequest model.OrderStatusRequest, userID int) (*model.Order, error) {
+func (m *MockOrder) UpdateStatus(id int, orderStatusRequest model.OrderStatusRequest, userID int, role model.UserRole) (*model.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", id, orderStatusRequest, userID)
+	ret := m.ctrl.Call(m, "UpdateStatus", id, orderStatusRequest, userID, role)
 	ret0, _ := ret[0].(*model.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockOrderMockRecorder) UpdateStatus(id, orderStatusRequest, userID interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) UpdateStatus(id, orderStatusRequest, userID, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrder)(nil).UpdateStatus), id, orderStatusRequest, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrder)(nil).UpdateStatus), id, orderStatusRequest, userID, role)
 }
 
 // MockProduct is a mock of Product interface.
@@ -146,19 +176,49 @@ func (m *MockProduct) EXPECT() *MockProductMockRecorder {
 	return m.recorder
 }
 
+// ApplyScheduledPriceChanges mocks base method.
+func (m *MockProduct) ApplyScheduledPriceChanges() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyScheduledPriceChanges")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyScheduledPriceChanges indicates an expected call of ApplyScheduledPriceChanges.
+func (mr *MockProductMockRecorder) ApplyScheduledPriceChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledPriceChanges", reflect.TypeOf((*MockProduct)(nil).ApplyScheduledPriceChanges))
+}
+
 // Create mocks base method.
-func (m *MockProduct) Create(product model.Product) (*model.Product, error) {
+func (m *MockProduct) Create(product model.Product, userID int) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", product)
+	ret := m.ctrl.Call(m, "Create", product, userID)
 	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockProductMockRecorder) Create(product interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) Create(product, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProduct)(nil).Create), product, userID)
+}
+
+// CreatePriceChange mocks base method.
+func (m *MockProduct) CreatePriceChange(id int, priceReq model.PriceChangeRequestBody, userID int) (*model.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceChange", id, priceReq, userID)
+	ret0, _ := ret[0].(*model.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceChange indicates an expected call of CreatePriceChange.
+func (mr *MockProductMockRecorder) CreatePriceChange(id, priceReq, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProduct)(nil).Create), product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceChange", reflect.TypeOf((*MockProduct)(nil).CreatePriceChange), id, priceReq, userID)
 }
 
 // Delete mocks base method.
@@ -176,48 +236,93 @@ func (mr *MockProductMockRecorder) Delete(id interface{}) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockProduct) GetAll() ([]model.Product, error) {
+func (m *MockProduct) GetAll(params model.ProductQueryParams, userID int) ([]model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", params, userID)
 	ret0, _ := ret[0].([]model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockProductMockRecorder) GetAll() *gomock.Call {
+func (mr *MockProductMockRecorder) GetAll(params, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll), params, userID)
 }
 
 // GetByID mocks base method.
-func (m *MockProduct) GetByID(id int) (*model.Product, error) {
+func (m *MockProduct) GetByID(id, userID int) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id)
+	ret := m.ctrl.Call(m, "GetByID", id, userID)
 	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockProductMockRecorder) GetByID(id interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) GetByID(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProduct)(nil).GetByID), id, userID)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockProduct) GetPriceHistory(id int) ([]model.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", id)
+	ret0, _ := ret[0].([]model.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockProductMockRecorder) GetPriceHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockProduct)(nil).GetPriceHistory), id)
+}
+
+// GetTotalCount mocks base method.
+func (m *MockProduct) GetTotalCount(params model.ProductQueryParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalCount", params)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalCount indicates an expected call of GetTotalCount.
+func (mr *MockProductMockRecorder) GetTotalCount(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalCount", reflect.TypeOf((*MockProduct)(nil).GetTotalCount), params)
+}
+
+// GetUnits mocks base method.
+func (m *MockProduct) GetUnits() ([]model.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnits")
+	ret0, _ := ret[0].([]model.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnits indicates an expected call of GetUnits.
+func (mr *MockProductMockRecorder) GetUnits() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProduct)(nil).GetByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnits", reflect.TypeOf((*MockProduct)(nil).GetUnits))
 }
 
 // Update mocks base method.
-func (m *MockProduct) Update(id int, product model.Product) (*model.Product, error) {
+func (m *MockProduct) Update(id int, product model.Product, userID int) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, product)
+	ret := m.ctrl.Call(m, "Update", id, product, userID)
 	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockProductMockRecorder) Update(id, product interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) Update(id, product, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProduct)(nil).Update), id, product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProduct)(nil).Update), id, product, userID)
 }
 
 // MockUser is a mock of User interface.
@@ -243,6 +348,21 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 	return m.recorder
 }
 
+// ChangeCustomerGroup mocks base method.
+func (m *MockUser) ChangeCustomerGroup(id int, groupReq model.UserCustomerGroupBody) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeCustomerGroup", id, groupReq)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeCustomerGroup indicates an expected call of ChangeCustomerGroup.
+func (mr *MockUserMockRecorder) ChangeCustomerGroup(id, groupReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeCustomerGroup", reflect.TypeOf((*MockUser)(nil).ChangeCustomerGroup), id, groupReq)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUser) ChangePassword(id int, changePassworReq model.UserChangePasswordBody) (*model.Success, error) {
 	m.ctrl.T.Helper()
@@ -273,6 +393,21 @@ func (mr *MockUserMockRecorder) ChangeUserRole(id, userRoleReq interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserRole", reflect.TypeOf((*MockUser)(nil).ChangeUserRole), id, userRoleReq)
 }
 
+// ConfirmTwoFactor mocks base method.
+func (m *MockUser) ConfirmTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) (*model.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", userID, codeReq)
+	ret0, _ := ret[0].(*model.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockUserMockRecorder) ConfirmTwoFactor(userID, codeReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUser)(nil).ConfirmTwoFactor), userID, codeReq)
+}
+
 // Create mocks base method.
 func (m *MockUser) Create(user model.UserCreateBody) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +437,49 @@ func (mr *MockUserMockRecorder) Delete(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), id)
 }
 
+// DisableTwoFactor mocks base method.
+func (m *MockUser) DisableTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", userID, codeReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockUserMockRecorder) DisableTwoFactor(userID, codeReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockUser)(nil).DisableTwoFactor), userID, codeReq)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockUser) EnrollTwoFactor(userID int) (*model.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", userID)
+	ret0, _ := ret[0].(*model.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockUserMockRecorder) EnrollTwoFactor(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUser)(nil).EnrollTwoFactor), userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockUser) ForgotPassword(forgotReq model.ForgotPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", forgotReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUserMockRecorder) ForgotPassword(forgotReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUser)(nil).ForgotPassword), forgotReq)
+}
+
 // GetAll mocks base method.
 func (m *MockUser) GetAll() ([]model.User, error) {
 	m.ctrl.T.Helper()
@@ -332,19 +510,164 @@ func (mr *MockUserMockRecorder) GetByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUser)(nil).GetByID), id)
 }
 
+// IsTokenRevoked mocks base method.
+func (m *MockUser) IsTokenRevoked(claims *model.Claims) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", claims)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockUserMockRecorder) IsTokenRevoked(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockUser)(nil).IsTokenRevoked), claims)
+}
+
 // Login mocks base method.
-func (m *MockUser) Login(user model.LoginRequest) (*model.TokenSuccess, error) {
+func (m *MockUser) Login(user model.LoginRequest, ip string) (*model.TokenSuccess, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", user)
+	ret := m.ctrl.Call(m, "Login", user, ip)
 	ret0, _ := ret[0].(*model.TokenSuccess)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserMockRecorder) Login(user interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Login(user, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUser)(nil).Login), user, ip)
+}
+
+// LoginTwoFactor mocks base method.
+func (m *MockUser) LoginTwoFactor(loginReq model.TwoFactorLoginRequest) (*model.TokenSuccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginTwoFactor", loginReq)
+	ret0, _ := ret[0].(*model.TokenSuccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginTwoFactor indicates an expected call of LoginTwoFactor.
+func (mr *MockUserMockRecorder) LoginTwoFactor(loginReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginTwoFactor", reflect.TypeOf((*MockUser)(nil).LoginTwoFactor), loginReq)
+}
+
+// Logout mocks base method.
+func (m *MockUser) Logout(claims *model.Claims, logoutReq model.LogoutRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", claims, logoutReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserMockRecorder) Logout(claims, logoutReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUser)(nil).Logout), claims, logoutReq)
+}
+
+// LogoutAll mocks base method.
+func (m *MockUser) LogoutAll(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockUserMockRecorder) LogoutAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockUser)(nil).LogoutAll), userID)
+}
+
+// Refresh mocks base method.
+func (m *MockUser) Refresh(refreshReq model.RefreshTokenRequest) (*model.TokenSuccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshReq)
+	ret0, _ := ret[0].(*model.TokenSuccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockUserMockRecorder) Refresh(refreshReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUser)(nil).Refresh), refreshReq)
+}
+
+// ResendEmailVerification mocks base method.
+func (m *MockUser) ResendEmailVerification(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockUserMockRecorder) ResendEmailVerification(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockUser)(nil).ResendEmailVerification), userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUser) ResetPassword(resetReq model.ResetPasswordRequest) (*model.Success, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", resetReq)
+	ret0, _ := ret[0].(*model.Success)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserMockRecorder) ResetPassword(resetReq interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUser)(nil).Login), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUser)(nil).ResetPassword), resetReq)
+}
+
+// ResetTwoFactor mocks base method.
+func (m *MockUser) ResetTwoFactor(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTwoFactor", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTwoFactor indicates an expected call of ResetTwoFactor.
+func (mr *MockUserMockRecorder) ResetTwoFactor(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTwoFactor", reflect.TypeOf((*MockUser)(nil).ResetTwoFactor), id)
+}
+
+// SetupTwoFactorChallenge mocks base method.
+func (m *MockUser) SetupTwoFactorChallenge(challengeReq model.TwoFactorChallengeRequest) (*model.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupTwoFactorChallenge", challengeReq)
+	ret0, _ := ret[0].(*model.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupTwoFactorChallenge indicates an expected call of SetupTwoFactorChallenge.
+func (mr *MockUserMockRecorder) SetupTwoFactorChallenge(challengeReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupTwoFactorChallenge", reflect.TypeOf((*MockUser)(nil).SetupTwoFactorChallenge), challengeReq)
+}
+
+// Unlock mocks base method.
+func (m *MockUser) Unlock(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockUserMockRecorder) Unlock(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockUser)(nil).Unlock), id)
 }
 
 // Update mocks base method.
@@ -361,3 +684,1198 @@ func (mr *MockUserMockRecorder) Update(id, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), id, user)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockUser) VerifyEmail(verifyReq model.VerifyEmailRequest) (*model.Success, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", verifyReq)
+	ret0, _ := ret[0].(*model.Success)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserMockRecorder) VerifyEmail(verifyReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUser)(nil).VerifyEmail), verifyReq)
+}
+
+// MockPriceList is a mock of PriceList interface.
+type MockPriceList struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListMockRecorder
+}
+
+// MockPriceListMockRecorder is the mock recorder for MockPriceList.
+type MockPriceListMockRecorder struct {
+	mock *MockPriceList
+}
+
+// NewMockPriceList creates a new mock instance.
+func NewMockPriceList(ctrl *gomock.Controller) *MockPriceList {
+	mock := &MockPriceList{ctrl: ctrl}
+	mock.recorder = &MockPriceListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceList) EXPECT() *MockPriceListMockRecorder {
+	return m.recorder
+}
+
+// AddAssignment mocks base method.
+func (m *MockPriceList) AddAssignment(priceListID int, assignment model.PriceListAssignmentBody) (*model.PriceListAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignment", priceListID, assignment)
+	ret0, _ := ret[0].(*model.PriceListAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAssignment indicates an expected call of AddAssignment.
+func (mr *MockPriceListMockRecorder) AddAssignment(priceListID, assignment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignment", reflect.TypeOf((*MockPriceList)(nil).AddAssignment), priceListID, assignment)
+}
+
+// Create mocks base method.
+func (m *MockPriceList) Create(priceList model.PriceListRequestBody) (*model.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", priceList)
+	ret0, _ := ret[0].(*model.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceListMockRecorder) Create(priceList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceList)(nil).Create), priceList)
+}
+
+// CreateCustomerGroup mocks base method.
+func (m *MockPriceList) CreateCustomerGroup(group model.CustomerGroupRequestBody) (*model.CustomerGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomerGroup", group)
+	ret0, _ := ret[0].(*model.CustomerGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomerGroup indicates an expected call of CreateCustomerGroup.
+func (mr *MockPriceListMockRecorder) CreateCustomerGroup(group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomerGroup", reflect.TypeOf((*MockPriceList)(nil).CreateCustomerGroup), group)
+}
+
+// Delete mocks base method.
+func (m *MockPriceList) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPriceListMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPriceList)(nil).Delete), id)
+}
+
+// DeleteAssignment mocks base method.
+func (m *MockPriceList) DeleteAssignment(priceListID, assignmentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignment", priceListID, assignmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignment indicates an expected call of DeleteAssignment.
+func (mr *MockPriceListMockRecorder) DeleteAssignment(priceListID, assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignment", reflect.TypeOf((*MockPriceList)(nil).DeleteAssignment), priceListID, assignmentID)
+}
+
+// DeleteCustomerGroup mocks base method.
+func (m *MockPriceList) DeleteCustomerGroup(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomerGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomerGroup indicates an expected call of DeleteCustomerGroup.
+func (mr *MockPriceListMockRecorder) DeleteCustomerGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomerGroup", reflect.TypeOf((*MockPriceList)(nil).DeleteCustomerGroup), id)
+}
+
+// GetAll mocks base method.
+func (m *MockPriceList) GetAll() ([]model.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPriceListMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPriceList)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockPriceList) GetByID(id int) (*model.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPriceListMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPriceList)(nil).GetByID), id)
+}
+
+// GetCustomerGroups mocks base method.
+func (m *MockPriceList) GetCustomerGroups() ([]model.CustomerGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerGroups")
+	ret0, _ := ret[0].([]model.CustomerGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerGroups indicates an expected call of GetCustomerGroups.
+func (mr *MockPriceListMockRecorder) GetCustomerGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerGroups", reflect.TypeOf((*MockPriceList)(nil).GetCustomerGroups))
+}
+
+// Update mocks base method.
+func (m *MockPriceList) Update(id int, priceList model.PriceListRequestBody) (*model.PriceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, priceList)
+	ret0, _ := ret[0].(*model.PriceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceListMockRecorder) Update(id, priceList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceList)(nil).Update), id, priceList)
+}
+
+// MockPromoCode is a mock of PromoCode interface.
+type MockPromoCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoCodeMockRecorder
+}
+
+// MockPromoCodeMockRecorder is the mock recorder for MockPromoCode.
+type MockPromoCodeMockRecorder struct {
+	mock *MockPromoCode
+}
+
+// NewMockPromoCode creates a new mock instance.
+func NewMockPromoCode(ctrl *gomock.Controller) *MockPromoCode {
+	mock := &MockPromoCode{ctrl: ctrl}
+	mock.recorder = &MockPromoCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoCode) EXPECT() *MockPromoCodeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromoCode) Create(promoCode model.PromoCodeRequestBody) (*model.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", promoCode)
+	ret0, _ := ret[0].(*model.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromoCodeMockRecorder) Create(promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromoCode)(nil).Create), promoCode)
+}
+
+// Delete mocks base method.
+func (m *MockPromoCode) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromoCodeMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromoCode)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockPromoCode) GetAll() ([]model.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromoCodeMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromoCode)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockPromoCode) GetByID(id int) (*model.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPromoCodeMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPromoCode)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockPromoCode) Update(id int, promoCode model.PromoCodeRequestBody) (*model.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, promoCode)
+	ret0, _ := ret[0].(*model.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromoCodeMockRecorder) Update(id, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromoCode)(nil).Update), id, promoCode)
+}
+
+// MockReturn is a mock of Return interface.
+type MockReturn struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnMockRecorder
+}
+
+// MockReturnMockRecorder is the mock recorder for MockReturn.
+type MockReturnMockRecorder struct {
+	mock *MockReturn
+}
+
+// NewMockReturn creates a new mock instance.
+func NewMockReturn(ctrl *gomock.Controller) *MockReturn {
+	mock := &MockReturn{ctrl: ctrl}
+	mock.recorder = &MockReturnMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturn) EXPECT() *MockReturnMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturn) Create(returnReq model.ReturnRequestBody, userID int, role model.UserRole) (*model.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", returnReq, userID, role)
+	ret0, _ := ret[0].(*model.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnMockRecorder) Create(returnReq, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturn)(nil).Create), returnReq, userID, role)
+}
+
+// Decide mocks base method.
+func (m *MockReturn) Decide(id int, decision model.ReturnDecisionBody, employeeID int) (*model.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", id, decision, employeeID)
+	ret0, _ := ret[0].(*model.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReturnMockRecorder) Decide(id, decision, employeeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReturn)(nil).Decide), id, decision, employeeID)
+}
+
+// GetAll mocks base method.
+func (m *MockReturn) GetAll(userID int, role model.UserRole) ([]model.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID, role)
+	ret0, _ := ret[0].([]model.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReturnMockRecorder) GetAll(userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReturn)(nil).GetAll), userID, role)
+}
+
+// GetByID mocks base method.
+func (m *MockReturn) GetByID(id, userID int, role model.UserRole) (*model.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id, userID, role)
+	ret0, _ := ret[0].(*model.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReturnMockRecorder) GetByID(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReturn)(nil).GetByID), id, userID, role)
+}
+
+// MockCart is a mock of Cart interface.
+type MockCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartMockRecorder
+}
+
+// MockCartMockRecorder is the mock recorder for MockCart.
+type MockCartMockRecorder struct {
+	mock *MockCart
+}
+
+// NewMockCart creates a new mock instance.
+func NewMockCart(ctrl *gomock.Controller) *MockCart {
+	mock := &MockCart{ctrl: ctrl}
+	mock.recorder = &MockCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCart) EXPECT() *MockCartMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockCart) AddItem(userID int, item model.CartItemRequest) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", userID, item)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockCartMockRecorder) AddItem(userID, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockCart)(nil).AddItem), userID, item)
+}
+
+// Checkout mocks base method.
+func (m *MockCart) Checkout(userID int, checkout model.CartCheckoutBody) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", userID, checkout)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartMockRecorder) Checkout(userID, checkout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCart)(nil).Checkout), userID, checkout)
+}
+
+// Clear mocks base method.
+func (m *MockCart) Clear(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCartMockRecorder) Clear(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCart)(nil).Clear), userID)
+}
+
+// Get mocks base method.
+func (m *MockCart) Get(userID int) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCartMockRecorder) Get(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCart)(nil).Get), userID)
+}
+
+// RemoveItem mocks base method.
+func (m *MockCart) RemoveItem(userID, productID int) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", userID, productID)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockCartMockRecorder) RemoveItem(userID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockCart)(nil).RemoveItem), userID, productID)
+}
+
+// UpdateItem mocks base method.
+func (m *MockCart) UpdateItem(userID int, item model.CartItemRequest) (*model.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", userID, item)
+	ret0, _ := ret[0].(*model.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockCartMockRecorder) UpdateItem(userID, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockCart)(nil).UpdateItem), userID, item)
+}
+
+// MockDocument is a mock of Document interface.
+type MockDocument struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentMockRecorder
+}
+
+// MockDocumentMockRecorder is the mock recorder for MockDocument.
+type MockDocumentMockRecorder struct {
+	mock *MockDocument
+}
+
+// NewMockDocument creates a new mock instance.
+func NewMockDocument(ctrl *gomock.Controller) *MockDocument {
+	mock := &MockDocument{ctrl: ctrl}
+	mock.recorder = &MockDocumentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocument) EXPECT() *MockDocumentMockRecorder {
+	return m.recorder
+}
+
+// DeliveryNote mocks base method.
+func (m *MockDocument) DeliveryNote(orderID, userID int, role model.UserRole) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryNote", orderID, userID, role)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryNote indicates an expected call of DeliveryNote.
+func (mr *MockDocumentMockRecorder) DeliveryNote(orderID, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryNote", reflect.TypeOf((*MockDocument)(nil).DeliveryNote), orderID, userID, role)
+}
+
+// Invoice mocks base method.
+func (m *MockDocument) Invoice(orderID, userID int, role model.UserRole) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invoice", orderID, userID, role)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invoice indicates an expected call of Invoice.
+func (mr *MockDocumentMockRecorder) Invoice(orderID, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invoice", reflect.TypeOf((*MockDocument)(nil).Invoice), orderID, userID, role)
+}
+
+// MockAddress is a mock of Address interface.
+type MockAddress struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressMockRecorder
+}
+
+// MockAddressMockRecorder is the mock recorder for MockAddress.
+type MockAddressMockRecorder struct {
+	mock *MockAddress
+}
+
+// NewMockAddress creates a new mock instance.
+func NewMockAddress(ctrl *gomock.Controller) *MockAddress {
+	mock := &MockAddress{ctrl: ctrl}
+	mock.recorder = &MockAddressMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddress) EXPECT() *MockAddressMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddress) Create(userID int, address model.AddressRequestBody) (*model.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, address)
+	ret0, _ := ret[0].(*model.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressMockRecorder) Create(userID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddress)(nil).Create), userID, address)
+}
+
+// Delete mocks base method.
+func (m *MockAddress) Delete(id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAddressMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddress)(nil).Delete), id, userID)
+}
+
+// GetAll mocks base method.
+func (m *MockAddress) GetAll(userID int) ([]model.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID)
+	ret0, _ := ret[0].([]model.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAddressMockRecorder) GetAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAddress)(nil).GetAll), userID)
+}
+
+// GetByID mocks base method.
+func (m *MockAddress) GetByID(id, userID int) (*model.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id, userID)
+	ret0, _ := ret[0].(*model.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAddressMockRecorder) GetByID(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAddress)(nil).GetByID), id, userID)
+}
+
+// Update mocks base method.
+func (m *MockAddress) Update(id, userID int, address model.AddressRequestBody) (*model.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, userID, address)
+	ret0, _ := ret[0].(*model.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddressMockRecorder) Update(id, userID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddress)(nil).Update), id, userID, address)
+}
+
+// MockShippingMethod is a mock of ShippingMethod interface.
+type MockShippingMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingMethodMockRecorder
+}
+
+// MockShippingMethodMockRecorder is the mock recorder for MockShippingMethod.
+type MockShippingMethodMockRecorder struct {
+	mock *MockShippingMethod
+}
+
+// NewMockShippingMethod creates a new mock instance.
+func NewMockShippingMethod(ctrl *gomock.Controller) *MockShippingMethod {
+	mock := &MockShippingMethod{ctrl: ctrl}
+	mock.recorder = &MockShippingMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingMethod) EXPECT() *MockShippingMethodMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShippingMethod) Create(method model.ShippingMethodRequestBody) (*model.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", method)
+	ret0, _ := ret[0].(*model.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShippingMethodMockRecorder) Create(method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShippingMethod)(nil).Create), method)
+}
+
+// Delete mocks base method.
+func (m *MockShippingMethod) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShippingMethodMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShippingMethod)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockShippingMethod) GetAll(role model.UserRole) ([]model.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", role)
+	ret0, _ := ret[0].([]model.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockShippingMethodMockRecorder) GetAll(role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockShippingMethod)(nil).GetAll), role)
+}
+
+// GetByID mocks base method.
+func (m *MockShippingMethod) GetByID(id int) (*model.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockShippingMethodMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockShippingMethod)(nil).GetByID), id)
+}
+
+// Update mocks base method.
+func (m *MockShippingMethod) Update(id int, method model.ShippingMethodRequestBody) (*model.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, method)
+	ret0, _ := ret[0].(*model.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShippingMethodMockRecorder) Update(id, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShippingMethod)(nil).Update), id, method)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockComment) Create(orderID int, comment model.OrderCommentRequestBody, userID int, role model.UserRole) (*model.OrderComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", orderID, comment, userID, role)
+	ret0, _ := ret[0].(*model.OrderComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentMockRecorder) Create(orderID, comment, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComment)(nil).Create), orderID, comment, userID, role)
+}
+
+// Delete mocks base method.
+func (m *MockComment) Delete(id, orderID, userID int, role model.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, orderID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentMockRecorder) Delete(id, orderID, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComment)(nil).Delete), id, orderID, userID, role)
+}
+
+// GetAll mocks base method.
+func (m *MockComment) GetAll(orderID, userID int, role model.UserRole) ([]model.OrderComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", orderID, userID, role)
+	ret0, _ := ret[0].([]model.OrderComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCommentMockRecorder) GetAll(orderID, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockComment)(nil).GetAll), orderID, userID, role)
+}
+
+// MockRecurringOrder is a mock of RecurringOrder interface.
+type MockRecurringOrder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringOrderMockRecorder
+}
+
+// MockRecurringOrderMockRecorder is the mock recorder for MockRecurringOrder.
+type MockRecurringOrderMockRecorder struct {
+	mock *MockRecurringOrder
+}
+
+// NewMockRecurringOrder creates a new mock instance.
+func NewMockRecurringOrder(ctrl *gomock.Controller) *MockRecurringOrder {
+	mock := &MockRecurringOrder{ctrl: ctrl}
+	mock.recorder = &MockRecurringOrderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringOrder) EXPECT() *MockRecurringOrderMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRecurringOrder) Create(recurringReq model.RecurringOrderRequestBody, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", recurringReq, userID, role)
+	ret0, _ := ret[0].(*model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRecurringOrderMockRecorder) Create(recurringReq, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRecurringOrder)(nil).Create), recurringReq, userID, role)
+}
+
+// Delete mocks base method.
+func (m *MockRecurringOrder) Delete(id, userID int, role model.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecurringOrderMockRecorder) Delete(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecurringOrder)(nil).Delete), id, userID, role)
+}
+
+// GetAll mocks base method.
+func (m *MockRecurringOrder) GetAll(userID int, role model.UserRole) ([]model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID, role)
+	ret0, _ := ret[0].([]model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRecurringOrderMockRecorder) GetAll(userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRecurringOrder)(nil).GetAll), userID, role)
+}
+
+// GetByID mocks base method.
+func (m *MockRecurringOrder) GetByID(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id, userID, role)
+	ret0, _ := ret[0].(*model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRecurringOrderMockRecorder) GetByID(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRecurringOrder)(nil).GetByID), id, userID, role)
+}
+
+// Pause mocks base method.
+func (m *MockRecurringOrder) Pause(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", id, userID, role)
+	ret0, _ := ret[0].(*model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockRecurringOrderMockRecorder) Pause(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockRecurringOrder)(nil).Pause), id, userID, role)
+}
+
+// ProcessDue mocks base method.
+func (m *MockRecurringOrder) ProcessDue() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDue")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessDue indicates an expected call of ProcessDue.
+func (mr *MockRecurringOrderMockRecorder) ProcessDue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDue", reflect.TypeOf((*MockRecurringOrder)(nil).ProcessDue))
+}
+
+// Resume mocks base method.
+func (m *MockRecurringOrder) Resume(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", id, userID, role)
+	ret0, _ := ret[0].(*model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockRecurringOrderMockRecorder) Resume(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockRecurringOrder)(nil).Resume), id, userID, role)
+}
+
+// Skip mocks base method.
+func (m *MockRecurringOrder) Skip(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Skip", id, userID, role)
+	ret0, _ := ret[0].(*model.RecurringOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Skip indicates an expected call of Skip.
+func (mr *MockRecurringOrderMockRecorder) Skip(id, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Skip", reflect.TypeOf((*MockRecurringOrder)(nil).Skip), id, userID, role)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockNotification) GetAll(userID int, unreadOnly bool) ([]model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userID, unreadOnly)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationMockRecorder) GetAll(userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotification)(nil).GetAll), userID, unreadOnly)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(id, userID int) (*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", id, userID)
+	ret0, _ := ret[0].(*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), id, userID)
+}
+
+// MockRole is a mock of Role interface.
+type MockRole struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleMockRecorder
+}
+
+// MockRoleMockRecorder is the mock recorder for MockRole.
+type MockRoleMockRecorder struct {
+	mock *MockRole
+}
+
+// NewMockRole creates a new mock instance.
+func NewMockRole(ctrl *gomock.Controller) *MockRole {
+	mock := &MockRole{ctrl: ctrl}
+	mock.recorder = &MockRoleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRole) EXPECT() *MockRoleMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRole) Create(role model.RoleRequestBody) (*model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", role)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRoleMockRecorder) Create(role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRole)(nil).Create), role)
+}
+
+// Delete mocks base method.
+func (m *MockRole) Delete(key model.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoleMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRole)(nil).Delete), key)
+}
+
+// GetAll mocks base method.
+func (m *MockRole) GetAll() ([]model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRoleMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRole)(nil).GetAll))
+}
+
+// GetByKey mocks base method.
+func (m *MockRole) GetByKey(key model.UserRole) (*model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", key)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockRoleMockRecorder) GetByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockRole)(nil).GetByKey), key)
+}
+
+// Permissions mocks base method.
+func (m *MockRole) Permissions(role model.UserRole) (model.PermissionSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permissions", role)
+	ret0, _ := ret[0].(model.PermissionSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Permissions indicates an expected call of Permissions.
+func (mr *MockRoleMockRecorder) Permissions(role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permissions", reflect.TypeOf((*MockRole)(nil).Permissions), role)
+}
+
+// Update mocks base method.
+func (m *MockRole) Update(key model.UserRole, role model.RoleRequestBody) (*model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", key, role)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRoleMockRecorder) Update(key, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRole)(nil).Update), key, role)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKey) Authenticate(key string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", key)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyMockRecorder) Authenticate(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKey)(nil).Authenticate), key)
+}
+
+// Create mocks base method.
+func (m *MockAPIKey) Create(createdBy int, keyReq model.APIKeyRequestBody) (*model.APIKeyCreated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", createdBy, keyReq)
+	ret0, _ := ret[0].(*model.APIKeyCreated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyMockRecorder) Create(createdBy, keyReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKey)(nil).Create), createdBy, keyReq)
+}
+
+// Delete mocks base method.
+func (m *MockAPIKey) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPIKeyMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIKey)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKey) GetAll() ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKey)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockAPIKey) GetByID(id int) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAPIKeyMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAPIKey)(nil).GetByID), id)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type NotificationsService struct {
+	repo repository.Notification
+	ctx  context.Context
+}
+
+func NewNotificationsService(ctx context.Context, repo repository.Notification) *NotificationsService {
+	return &NotificationsService{repo: repo, ctx: ctx}
+}
+
+func (s *NotificationsService) GetAll(userID int, unreadOnly bool) ([]model.Notification, error) {
+	notifications, err := s.repo.GetAll(s.ctx, userID, unreadOnly)
+	if err != nil {
+		logger.GetLogger().Error("failed to get notifications from repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения уведомлений", err)
+	}
+	return notifications, nil
+}
+
+func (s *NotificationsService) MarkRead(id, userID int) (*model.Notification, error) {
+	notification, err := s.repo.MarkRead(s.ctx, id, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to mark notification as read",
+			zap.Error(err),
+			zap.Int("notification_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "уведомление не найдено") {
+			return nil, errors.NewNotFoundError("уведомление", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка изменения уведомления", err)
+	}
+	return notification, nil
+}
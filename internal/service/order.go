@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
@@ -18,15 +19,33 @@ const (
 )
 
 type OrdersService struct {
-	repo repository.Order
-	ctx  context.Context
+	repo  repository.Order
+	users repository.User
+	ctx   context.Context
 }
 
-func NewOrdersService(ctx context.Context, repo repository.Order) *OrdersService {
-	return &OrdersService{repo: repo, ctx: ctx}
+func NewOrdersService(ctx context.Context, repo repository.Order, users repository.User) *OrdersService {
+	return &OrdersService{repo: repo, users: users, ctx: ctx}
 }
 
-func (s *OrdersService) Create(order model.OrderRequestBody, userID int) (*model.Order, error) {
+// Create создает заказ. Сотрудник может оформить заказ на клиента, указав его в UserID,
+// тогда цены, валюта и промокод определяются по этому клиенту.
+func (s *OrdersService) Create(order model.OrderRequestBody, userID int, role model.UserRole) (*model.Order, error) {
+	if order.UserID != nil {
+		if err := checkOrderClient(s.ctx, s.users, *order.UserID, role); err != nil {
+			return nil, err
+		}
+		userID = *order.UserID
+	} else if err := checkEmailVerified(s.ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	order.PromoCode = strings.ToUpper(strings.TrimSpace(order.PromoCode))
+	if err := validateOrderDiscounts(order); err != nil {
+		return nil, err
+	}
+	if order.TaxMode != nil && !order.TaxMode.Valid() {
+		return nil, errors.NewValidationError("неизвестный режим НДС: "+string(*order.TaxMode), nil)
+	}
 	createdOrder, err := s.repo.Create(s.ctx, order, userID)
 	var result any
 	var status string
@@ -83,8 +102,8 @@ func (s *OrdersService) GetByID(id, userID int, role model.UserRole) (*model.Ord
 	return order, nil
 }
 
-func (s *OrdersService) Delete(id, userID int) error {
-	deletedOrder, err := s.repo.Delete(s.ctx, id, userID)
+func (s *OrdersService) Delete(id, userID int, role model.UserRole) error {
+	deletedOrder, err := s.repo.Delete(s.ctx, id, userID, role)
 	var result any
 	var status string
 	if err != nil {
@@ -113,8 +132,25 @@ func (s *OrdersService) Delete(id, userID int) error {
 	return err
 }
 
-func (s *OrdersService) Update(id int, order model.OrderRequestBody, userID int) (*model.Order, error) {
-	updatedOrder, err := s.repo.Update(s.ctx, id, order, userID)
+func (s *OrdersService) Update(
+	id int,
+	order model.OrderRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.Order, error) {
+	if order.UserID != nil {
+		return nil, errors.NewValidationError("владельца заказа нельзя изменить", nil)
+	}
+	if order.PromoCode != "" {
+		return nil, errors.NewValidationError("промокод можно применить только при создании заказа", nil)
+	}
+	if err := validateOrderDiscounts(order); err != nil {
+		return nil, err
+	}
+	if order.TaxMode != nil && !order.TaxMode.Valid() {
+		return nil, errors.NewValidationError("неизвестный режим НДС: "+string(*order.TaxMode), nil)
+	}
+	updatedOrder, err := s.repo.Update(s.ctx, id, order, userID, role)
 	var result any
 	var status string
 	if err != nil {
@@ -147,8 +183,9 @@ func (s *OrdersService) UpdateStatus(
 	id int,
 	orderStatusRequest model.OrderStatusRequest,
 	userID int,
+	role model.UserRole,
 ) (*model.Order, error) {
-	updatedOrder, err := s.repo.UpdateStatus(s.ctx, id, orderStatusRequest, userID)
+	updatedOrder, err := s.repo.UpdateStatus(s.ctx, id, orderStatusRequest, userID, role)
 	var result any
 	var status string
 	if err != nil {
@@ -177,3 +214,159 @@ func (s *OrdersService) UpdateStatus(
 	}
 	return updatedOrder, err
 }
+
+// Reorder создает новый заказ по строкам существующего с текущими ценами и остатками.
+// Строки, которых не хватает на складе, переносятся в доступном количестве, недоступные товары
+// пропускаются. Скидки и промокод исходного заказа не переносятся, адрес, способ доставки
+// и режим НДС сохраняются. Сотрудник оформляет повторный заказ на владельца исходного.
+func (s *OrdersService) Reorder(id, userID int, role model.UserRole) (*model.ReorderResult, error) {
+	source, err := s.repo.GetByID(s.ctx, id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get reorder source from repository",
+			zap.Error(err),
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "заказ не найден") {
+			return nil, errors.NewNotFoundError("заказ", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения заказа", err)
+	}
+	if source.Status == model.StatusDeleted {
+		return nil, errors.NewValidationError("удаленный заказ нельзя повторить", nil)
+	}
+
+	lines, err := s.repo.GetReorderLines(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get reorder lines from repository",
+			zap.Error(err),
+			zap.Int("order_id", id),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения товаров заказа", err)
+	}
+
+	orderReq := model.OrderRequestBody{
+		Products:         make([]model.OrderProduct, 0, len(lines)),
+		TaxMode:          &source.TaxMode,
+		AddressID:        source.AddressID,
+		ShippingMethodID: source.ShippingMethodID,
+	}
+	for i := range lines {
+		lines[i].Resolve()
+		if lines[i].Status == model.ReorderLineUnavailable {
+			continue
+		}
+		orderReq.Products = append(orderReq.Products, model.OrderProduct{
+			ProductID: lines[i].ProductID,
+			Quantity:  lines[i].Quantity,
+		})
+	}
+	if len(orderReq.Products) == 0 {
+		return nil, errors.NewValidationError("ни один товар заказа сейчас недоступен", nil)
+	}
+	if source.UserID != userID {
+		orderReq.UserID = &source.UserID
+	}
+
+	order, err := s.Create(orderReq, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ReorderResult{Order: order, Lines: lines}, nil
+}
+
+// Ship сохраняет перевозчика и трек-номер отправленного заказа.
+func (s *OrdersService) Ship(id int, shipment model.ShipmentRequestBody, userID int) (*model.Order, error) {
+	shipment.Carrier = strings.TrimSpace(shipment.Carrier)
+	shipment.TrackingNumber = strings.TrimSpace(shipment.TrackingNumber)
+	if shipment.Carrier == "" || shipment.TrackingNumber == "" {
+		return nil, errors.NewValidationError("перевозчик и трек-номер обязательны", nil)
+	}
+	shippedOrder, err := s.repo.Ship(s.ctx, id, shipment)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to ship order in repository",
+			zap.Error(err),
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("order shipped successfully",
+			zap.Int("order_id", id),
+			zap.Int("user_id", userID),
+			zap.String("carrier", shipment.Carrier),
+		)
+		result = shippedOrder
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Ship", status, logOrdersTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for order shipment",
+			zap.Error(logErr),
+		)
+	}
+	return shippedOrder, err
+}
+
+// checkOrderClient проверяет, что заказ на другого пользователя оформляет сотрудник и что этот пользователь — клиент.
+func checkOrderClient(ctx context.Context, users repository.User, clientID int, role model.UserRole) error {
+	if role.IsClient() {
+		return errors.NewForbiddenError("Оформить заказ на другого пользователя может только сотрудник", nil)
+	}
+	client, err := users.GetByID(ctx, clientID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order client",
+			zap.Error(err),
+			zap.Int("client_id", clientID),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return errors.NewNotFoundError("клиент", err)
+		}
+		return errors.NewDatabaseError("ошибка получения клиента", err)
+	}
+	if client.Role != model.RoleClient {
+		return errors.NewValidationError("заказ можно оформить только на клиента", nil)
+	}
+	return nil
+}
+
+// checkEmailVerified не дает оформлять заказы пользователю, не подтвердившему email.
+// Сотрудник может оформить заказ на клиента и без подтверждения: адрес клиента он знает.
+func checkEmailVerified(ctx context.Context, users repository.User, userID int) error {
+	user, err := users.GetByID(ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get order owner",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return errors.NewNotFoundError("пользователь", err)
+		}
+		return errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	if !user.EmailVerified() {
+		return errors.NewForbiddenError("Подтвердите email, чтобы оформлять заказы", nil)
+	}
+	return nil
+}
+
+// validateOrderDiscounts проверяет скидки на заказ и на строки заказа.
+// Промокод и ручная скидка на заказ взаимоисключающие.
+func validateOrderDiscounts(order model.OrderRequestBody) error {
+	if order.PromoCode != "" && (order.DiscountType != nil || order.DiscountValue != nil) {
+		return errors.NewValidationError("нельзя одновременно применить промокод и скидку на заказ", nil)
+	}
+	if err := model.ValidateDiscount(order.DiscountType, order.DiscountValue); err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	for _, product := range order.Products {
+		if err := model.ValidateDiscount(product.DiscountType, product.DiscountValue); err != nil {
+			return errors.NewValidationError(err.Error(), err)
+		}
+	}
+	return nil
+}
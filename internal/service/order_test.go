@@ -34,16 +34,18 @@ func TestOrderService_Create(t *testing.T) {
 						Products: []model.OrderProduct{
 							{
 								ProductID: 1,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								SellPrice: 74000,
 							},
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						Status:           model.StatusActive,
@@ -51,7 +53,7 @@ func TestOrderService_Create(t *testing.T) {
 							{
 								ID:            1,
 								Code:          14823,
-								Quantity:      215,
+								Quantity:      model.NewQuantity(215),
 								Name:          "Cheese",
 								PurchasePrice: 24000,
 								SellPrice:     74000,
@@ -64,7 +66,7 @@ func TestOrderService_Create(t *testing.T) {
 				Products: []model.OrderProduct{
 					{
 						ProductID: 1,
-						Quantity:  1,
+						Quantity:  model.NewQuantity(1),
 						SellPrice: 74000,
 					},
 				},
@@ -72,7 +74,9 @@ func TestOrderService_Create(t *testing.T) {
 			want: &model.Order{
 				ID:               1,
 				Number:           1,
+				Subtotal:         74000,
 				TotalCost:        74000,
+				Currency:         "RUB",
 				CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 				LastModifiedDate: time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 				Status:           model.StatusActive,
@@ -80,7 +84,7 @@ func TestOrderService_Create(t *testing.T) {
 					{
 						ID:            1,
 						Code:          14823,
-						Quantity:      215,
+						Quantity:      model.NewQuantity(215),
 						Name:          "Cheese",
 						PurchasePrice: 24000,
 						SellPrice:     74000,
@@ -97,11 +101,11 @@ func TestOrderService_Create(t *testing.T) {
 						Products: []model.OrderProduct{
 							{
 								ProductID: 1,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								SellPrice: 74000,
 							},
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					nil,
 					errors.New("ошибка сохранения в файл"),
@@ -111,7 +115,7 @@ func TestOrderService_Create(t *testing.T) {
 				Products: []model.OrderProduct{
 					{
 						ProductID: 1,
-						Quantity:  1,
+						Quantity:  model.NewQuantity(1),
 						SellPrice: 74000,
 					},
 				},
@@ -127,7 +131,7 @@ func TestOrderService_Create(t *testing.T) {
 
 			tt.mock()
 
-			got, err := os.Order.Create(tt.args, 1)
+			got, err := os.Order.Create(tt.args, 1, model.RoleClient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Ошибка создания заказа error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -166,16 +170,18 @@ func TestOrderService_Update(t *testing.T) {
 						Products: []model.OrderProduct{
 							{
 								ProductID: 1,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								SellPrice: 74000,
 							},
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 						Status:           model.StatusActive,
@@ -183,7 +189,7 @@ func TestOrderService_Update(t *testing.T) {
 							{
 								ID:            1,
 								Code:          14823,
-								Quantity:      215,
+								Quantity:      model.NewQuantity(215),
 								Name:          "Pizza",
 								PurchasePrice: 24000,
 								SellPrice:     74000,
@@ -198,7 +204,7 @@ func TestOrderService_Update(t *testing.T) {
 					Products: []model.OrderProduct{
 						{
 							ProductID: 1,
-							Quantity:  1,
+							Quantity:  model.NewQuantity(1),
 							SellPrice: 74000,
 						},
 					},
@@ -207,7 +213,9 @@ func TestOrderService_Update(t *testing.T) {
 			want: &model.Order{
 				ID:               1,
 				Number:           1,
+				Subtotal:         74000,
 				TotalCost:        74000,
+				Currency:         "RUB",
 				CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 				LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 				Status:           model.StatusActive,
@@ -215,7 +223,7 @@ func TestOrderService_Update(t *testing.T) {
 					{
 						ID:            1,
 						Code:          14823,
-						Quantity:      215,
+						Quantity:      model.NewQuantity(215),
 						Name:          "Pizza",
 						PurchasePrice: 24000,
 						SellPrice:     74000,
@@ -233,11 +241,11 @@ func TestOrderService_Update(t *testing.T) {
 						Products: []model.OrderProduct{
 							{
 								ProductID: 1,
-								Quantity:  1,
+								Quantity:  model.NewQuantity(1),
 								SellPrice: 74000,
 							},
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					nil,
 					errors.New(repository.NotFoundErrorMessage),
@@ -249,7 +257,7 @@ func TestOrderService_Update(t *testing.T) {
 					Products: []model.OrderProduct{
 						{
 							ProductID: 1,
-							Quantity:  1,
+							Quantity:  model.NewQuantity(1),
 							SellPrice: 74000,
 						},
 					},
@@ -264,7 +272,7 @@ func TestOrderService_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			os := &Service{Order: dbMock}
 			tt.mock()
-			got, err := os.Order.Update(tt.args.id, tt.args.body, 1)
+			got, err := os.Order.Update(tt.args.id, tt.args.body, 1, model.RoleClient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Ошибка обноления заказа error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -296,7 +304,9 @@ func TestOrderService_GetAll(t *testing.T) {
 						{
 							ID:               1,
 							Number:           1,
+							Subtotal:         74000,
 							TotalCost:        74000,
+							Currency:         "RUB",
 							CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 							LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 							Status:           model.StatusActive,
@@ -304,7 +314,7 @@ func TestOrderService_GetAll(t *testing.T) {
 								{
 									ID:            1,
 									Code:          14823,
-									Quantity:      215,
+									Quantity:      model.NewQuantity(215),
 									Name:          "Pizza",
 									PurchasePrice: 24000,
 									SellPrice:     74000,
@@ -318,7 +328,9 @@ func TestOrderService_GetAll(t *testing.T) {
 				{
 					ID:               1,
 					Number:           1,
+					Subtotal:         74000,
 					TotalCost:        74000,
+					Currency:         "RUB",
 					CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 					LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 					Status:           model.StatusActive,
@@ -326,7 +338,7 @@ func TestOrderService_GetAll(t *testing.T) {
 						{
 							ID:            1,
 							Code:          14823,
-							Quantity:      215,
+							Quantity:      model.NewQuantity(215),
 							Name:          "Pizza",
 							PurchasePrice: 24000,
 							SellPrice:     74000,
@@ -377,7 +389,9 @@ func TestOrderService_GetById(t *testing.T) {
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 						Status:           model.StatusActive,
@@ -385,7 +399,7 @@ func TestOrderService_GetById(t *testing.T) {
 							{
 								ID:            1,
 								Code:          14823,
-								Quantity:      215,
+								Quantity:      model.NewQuantity(215),
 								Name:          "Pizza",
 								PurchasePrice: 24000,
 								SellPrice:     74000,
@@ -398,7 +412,9 @@ func TestOrderService_GetById(t *testing.T) {
 			want: &model.Order{
 				ID:               1,
 				Number:           1,
+				Subtotal:         74000,
 				TotalCost:        74000,
+				Currency:         "RUB",
 				CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 				LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 				Status:           model.StatusActive,
@@ -406,7 +422,7 @@ func TestOrderService_GetById(t *testing.T) {
 					{
 						ID:            1,
 						Code:          14823,
-						Quantity:      215,
+						Quantity:      model.NewQuantity(215),
 						Name:          "Pizza",
 						PurchasePrice: 24000,
 						SellPrice:     74000,
@@ -464,7 +480,7 @@ func TestOrderService_Delete(t *testing.T) {
 		{
 			name: "success",
 			mock: func() {
-				dbMock.EXPECT().Delete(1, 1).Return(nil)
+				dbMock.EXPECT().Delete(1, 1, model.RoleClient).Return(nil)
 			},
 			args:    1,
 			want:    nil,
@@ -473,7 +489,7 @@ func TestOrderService_Delete(t *testing.T) {
 		{
 			name: "error",
 			mock: func() {
-				dbMock.EXPECT().Delete(1, 1).Return(
+				dbMock.EXPECT().Delete(1, 1, model.RoleClient).Return(
 					errors.New("ошибка сохранения в файл"),
 				)
 			},
@@ -489,7 +505,7 @@ func TestOrderService_Delete(t *testing.T) {
 
 			tt.mock()
 
-			err := os.Order.Delete(tt.args, 1)
+			err := os.Order.Delete(tt.args, 1, model.RoleClient)
 			if !errors.Is(err, tt.want) && err.Error() != tt.want.Error() {
 				t.Errorf("Ошибка удаления заказа по id error = %v, want %v", err, tt.want)
 				return
@@ -525,12 +541,14 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 							Key:         "executed",
 							DisplayName: "Выполнен",
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					&model.Order{
 						ID:               1,
 						Number:           1,
+						Subtotal:         74000,
 						TotalCost:        74000,
+						Currency:         "RUB",
 						CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 						LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 						Status:           model.StatusActive,
@@ -538,7 +556,7 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 							{
 								ID:            1,
 								Code:          14823,
-								Quantity:      215,
+								Quantity:      model.NewQuantity(215),
 								Name:          "Pizza",
 								PurchasePrice: 24000,
 								SellPrice:     74000,
@@ -559,7 +577,9 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 			want: &model.Order{
 				ID:               1,
 				Number:           1,
+				Subtotal:         74000,
 				TotalCost:        74000,
+				Currency:         "RUB",
 				CreatedDate:      time.Date(2025, time.May, 25, 12, 17, 16, 550631000, time.UTC),
 				LastModifiedDate: time.Date(2025, time.June, 15, 12, 0o0, 0o0, 0, time.UTC),
 				Status:           model.StatusActive,
@@ -567,7 +587,7 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 					{
 						ID:            1,
 						Code:          14823,
-						Quantity:      215,
+						Quantity:      model.NewQuantity(215),
 						Name:          "Pizza",
 						PurchasePrice: 24000,
 						SellPrice:     74000,
@@ -586,7 +606,7 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 							Key:         "executed",
 							DisplayName: "Выполнен",
 						},
-					}, 1,
+					}, 1, model.RoleClient,
 				).Return(
 					nil,
 					errors.New(repository.NotFoundErrorMessage),
@@ -610,7 +630,7 @@ func TestOrderService_UpdateStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			os := &Service{Order: dbMock}
 			tt.mock()
-			got, err := os.Order.UpdateStatus(tt.args.id, tt.args.body, 1)
+			got, err := os.Order.UpdateStatus(tt.args.id, tt.args.body, 1, model.RoleClient)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Ошибка обноления заказа error = %v, wantErr %v", err, tt.wantErr)
 				return
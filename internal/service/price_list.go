@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logPriceListsTableName = "logPriceList"
+)
+
+type PriceListsService struct {
+	repo repository.PriceList
+	ctx  context.Context
+}
+
+func NewPriceListsService(ctx context.Context, repo repository.PriceList) *PriceListsService {
+	return &PriceListsService{repo: repo, ctx: ctx}
+}
+
+func (s *PriceListsService) Create(priceListReq model.PriceListRequestBody) (*model.PriceList, error) {
+	if err := normalizePriceListRequest(&priceListReq); err != nil {
+		return nil, err
+	}
+	createdPriceList, err := s.repo.Create(s.ctx, priceListReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create price list in repository",
+			zap.Error(err),
+			zap.String("price_list_name", priceListReq.Name),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("price list created successfully",
+			zap.Int("price_list_id", createdPriceList.ID),
+			zap.String("price_list_name", createdPriceList.Name),
+		)
+		result = createdPriceList
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logPriceListsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for price list creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdPriceList, err
+}
+
+func (s *PriceListsService) GetAll() ([]model.PriceList, error) {
+	priceLists, err := s.repo.GetAll(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get price lists from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка прайс-листов", err)
+	}
+	return priceLists, nil
+}
+
+func (s *PriceListsService) GetByID(id int) (*model.PriceList, error) {
+	priceList, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get price list by ID from repository",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		if strings.Contains(err.Error(), "прайс-лист не найден") {
+			return nil, errors.NewNotFoundError("прайс-лист", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения прайс-листа", err)
+	}
+	return priceList, nil
+}
+
+func (s *PriceListsService) Update(id int, priceListReq model.PriceListRequestBody) (*model.PriceList, error) {
+	if err := normalizePriceListRequest(&priceListReq); err != nil {
+		return nil, err
+	}
+	updatedPriceList, err := s.repo.Update(s.ctx, id, priceListReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to update price list in repository",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("price list updated successfully",
+			zap.Int("price_list_id", id),
+		)
+		result = updatedPriceList
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Update", status, logPriceListsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for price list update",
+			zap.Error(logErr),
+		)
+	}
+	return updatedPriceList, err
+}
+
+func (s *PriceListsService) Delete(id int) error {
+	deletedPriceList, err := s.repo.Delete(s.ctx, id)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to delete price list from repository",
+			zap.Error(err),
+			zap.Int("price_list_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("price list deleted successfully",
+			zap.Int("price_list_id", id),
+		)
+		result = deletedPriceList
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Delete", status, logPriceListsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for price list deletion",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
+func (s *PriceListsService) AddAssignment(
+	priceListID int,
+	assignmentReq model.PriceListAssignmentBody,
+) (*model.PriceListAssignment, error) {
+	if (assignmentReq.UserID == nil) == (assignmentReq.CustomerGroupID == nil) {
+		return nil, errors.NewValidationError("необходимо указать либо пользователя, либо группу клиентов", nil)
+	}
+	assignment, err := s.repo.AddAssignment(s.ctx, priceListID, assignmentReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to assign price list",
+			zap.Error(err),
+			zap.Int("price_list_id", priceListID),
+		)
+		return nil, err
+	}
+	logger.GetLogger().Info("price list assigned successfully",
+		zap.Int("price_list_id", priceListID),
+		zap.Int("assignment_id", assignment.ID),
+	)
+	return assignment, nil
+}
+
+func (s *PriceListsService) DeleteAssignment(priceListID, assignmentID int) error {
+	err := s.repo.DeleteAssignment(s.ctx, priceListID, assignmentID)
+	if err != nil {
+		logger.GetLogger().Error("failed to delete price list assignment",
+			zap.Error(err),
+			zap.Int("price_list_id", priceListID),
+			zap.Int("assignment_id", assignmentID),
+		)
+		return err
+	}
+	logger.GetLogger().Info("price list assignment deleted successfully",
+		zap.Int("price_list_id", priceListID),
+		zap.Int("assignment_id", assignmentID),
+	)
+	return nil
+}
+
+func (s *PriceListsService) CreateCustomerGroup(groupReq model.CustomerGroupRequestBody) (*model.CustomerGroup, error) {
+	group, err := s.repo.CreateCustomerGroup(s.ctx, groupReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to create customer group",
+			zap.Error(err),
+			zap.String("group_name", groupReq.Name),
+		)
+		return nil, err
+	}
+	logger.GetLogger().Info("customer group created successfully",
+		zap.Int("group_id", group.ID),
+	)
+	return group, nil
+}
+
+func (s *PriceListsService) GetCustomerGroups() ([]model.CustomerGroup, error) {
+	groups, err := s.repo.GetCustomerGroups(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get customer groups from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка групп клиентов", err)
+	}
+	return groups, nil
+}
+
+func (s *PriceListsService) DeleteCustomerGroup(id int) error {
+	_, err := s.repo.DeleteCustomerGroup(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to delete customer group",
+			zap.Error(err),
+			zap.Int("group_id", id),
+		)
+		return err
+	}
+	logger.GetLogger().Info("customer group deleted successfully",
+		zap.Int("group_id", id),
+	)
+	return nil
+}
+
+func normalizePriceListRequest(priceListReq *model.PriceListRequestBody) error {
+	currency, err := model.NormalizeCurrency(priceListReq.Currency)
+	if err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	priceListReq.Currency = currency
+	if priceListReq.ValidFrom != nil && priceListReq.ValidTo != nil &&
+		!priceListReq.ValidTo.After(*priceListReq.ValidFrom) {
+		return errors.NewValidationError("дата окончания действия должна быть позже даты начала", nil)
+	}
+	return nil
+}
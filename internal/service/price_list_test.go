@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPriceListsService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockPriceList(ctrl)
+
+	from := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		args     model.PriceListRequestBody
+		mock     func()
+		wantType apperrors.ErrorType
+	}{
+		{
+			name: "currency is normalized",
+			args: model.PriceListRequestBody{Name: "Опт", Currency: " usd ", ValidFrom: &from, ValidTo: &to},
+			mock: func() {
+				repoMock.EXPECT().Create(gomock.Any(), model.PriceListRequestBody{
+					Name: "Опт", Currency: "USD", ValidFrom: &from, ValidTo: &to,
+				}).Return(&model.PriceList{ID: 1, Name: "Опт", Currency: "USD"}, nil)
+				repoMock.EXPECT().WriteLog(gomock.Any(), "Create", logSuccessStatus, logPriceListsTableName)
+			},
+		},
+		{
+			name: "default currency",
+			args: model.PriceListRequestBody{Name: "Розница"},
+			mock: func() {
+				repoMock.EXPECT().Create(gomock.Any(), model.PriceListRequestBody{
+					Name: "Розница", Currency: model.DefaultCurrency,
+				}).Return(&model.PriceList{ID: 2, Name: "Розница", Currency: model.DefaultCurrency}, nil)
+				repoMock.EXPECT().WriteLog(gomock.Any(), "Create", logSuccessStatus, logPriceListsTableName)
+			},
+		},
+		{
+			name:     "invalid currency",
+			args:     model.PriceListRequestBody{Name: "Опт", Currency: "рубль"},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "period ends before it starts",
+			args:     model.PriceListRequestBody{Name: "Опт", ValidFrom: &to, ValidTo: &from},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "empty period",
+			args:     model.PriceListRequestBody{Name: "Опт", ValidFrom: &from, ValidTo: &from},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPriceListsService(context.Background(), repoMock)
+
+			tt.mock()
+
+			_, err := s.Create(tt.args)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка создания прайс-листа error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка создания прайс-листа error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPriceListsService_AddAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockPriceList(ctrl)
+
+	userID, groupID := 5, 3
+
+	tests := []struct {
+		name    string
+		args    model.PriceListAssignmentBody
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "user",
+			args: model.PriceListAssignmentBody{UserID: &userID},
+			mock: func() {
+				repoMock.EXPECT().AddAssignment(gomock.Any(), 1, model.PriceListAssignmentBody{UserID: &userID}).
+					Return(&model.PriceListAssignment{ID: 1, PriceListID: 1, UserID: &userID}, nil)
+			},
+		},
+		{
+			name: "customer group",
+			args: model.PriceListAssignmentBody{CustomerGroupID: &groupID},
+			mock: func() {
+				repoMock.EXPECT().
+					AddAssignment(gomock.Any(), 1, model.PriceListAssignmentBody{CustomerGroupID: &groupID}).
+					Return(&model.PriceListAssignment{ID: 2, PriceListID: 1, CustomerGroupID: &groupID}, nil)
+			},
+		},
+		{
+			name:    "both user and group",
+			args:    model.PriceListAssignmentBody{UserID: &userID, CustomerGroupID: &groupID},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name:    "nobody",
+			args:    model.PriceListAssignmentBody{},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPriceListsService(context.Background(), repoMock)
+
+			tt.mock()
+
+			_, err := s.AddAssignment(1, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ошибка назначения прайс-листа error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
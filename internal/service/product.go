@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
@@ -24,8 +25,22 @@ func NewProductsService(ctx context.Context, repo repository.Product) *ProductsS
 	return &ProductsService{repo: repo, ctx: ctx}
 }
 
-func (s *ProductsService) Create(product model.Product) (*model.Product, error) {
-	createdProduct, err := s.repo.Create(s.ctx, product)
+func (s *ProductsService) Create(product model.Product, userID int) (*model.Product, error) {
+	if err := normalizeUnits(&product); err != nil {
+		return nil, err
+	}
+	if err := normalizeBundle(&product); err != nil {
+		return nil, err
+	}
+	currency, err := model.NormalizeCurrency(product.Currency)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+	product.Currency = currency
+	if err := normalizeVATRate(&product); err != nil {
+		return nil, err
+	}
+	createdProduct, err := s.repo.Create(s.ctx, product, userID)
 	var result any
 	var status string
 	if err != nil {
@@ -53,8 +68,8 @@ func (s *ProductsService) Create(product model.Product) (*model.Product, error)
 	return createdProduct, err
 }
 
-func (s *ProductsService) GetAll(params model.ProductQueryParams) ([]model.Product, error) {
-	products, err := s.repo.GetAll(s.ctx, params)
+func (s *ProductsService) GetAll(params model.ProductQueryParams, userID int) ([]model.Product, error) {
+	products, err := s.repo.GetAll(s.ctx, params, userID)
 	if err != nil {
 		logger.GetLogger().Error("failed to get products from repository",
 			zap.Error(err),
@@ -75,8 +90,19 @@ func (s *ProductsService) GetTotalCount(params model.ProductQueryParams) (int, e
 	return count, nil
 }
 
-func (s *ProductsService) GetByID(id int) (*model.Product, error) {
-	product, err := s.repo.GetByID(s.ctx, id)
+func (s *ProductsService) GetUnits() ([]model.Unit, error) {
+	units, err := s.repo.GetUnits(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get units from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения единиц измерения", err)
+	}
+	return units, nil
+}
+
+func (s *ProductsService) GetByID(id, userID int) (*model.Product, error) {
+	product, err := s.repo.GetByID(s.ctx, id, userID)
 	if err != nil {
 		logger.GetLogger().Error("failed to get product by ID from repository",
 			zap.Error(err),
@@ -119,8 +145,22 @@ func (s *ProductsService) Delete(id int) error {
 }
 
 //nolint:dupl
-func (s *ProductsService) Update(id int, product model.Product) (*model.Product, error) {
-	updatedProduct, err := s.repo.Update(s.ctx, id, product)
+func (s *ProductsService) Update(id int, product model.Product, userID int) (*model.Product, error) {
+	if err := normalizeUnits(&product); err != nil {
+		return nil, err
+	}
+	if err := normalizeBundle(&product); err != nil {
+		return nil, err
+	}
+	currency, err := model.NormalizeCurrency(product.Currency)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+	product.Currency = currency
+	if err := normalizeVATRate(&product); err != nil {
+		return nil, err
+	}
+	updatedProduct, err := s.repo.Update(s.ctx, id, product, userID)
 	var result any
 	var status string
 	if err != nil {
@@ -147,3 +187,149 @@ func (s *ProductsService) Update(id int, product model.Product) (*model.Product,
 	}
 	return updatedProduct, err
 }
+
+func (s *ProductsService) GetPriceHistory(id int) ([]model.PriceChange, error) {
+	history, err := s.repo.GetPriceHistory(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get price history from repository",
+			zap.Error(err),
+			zap.Int("product_id", id),
+		)
+		if strings.Contains(err.Error(), "продукт не найден") {
+			return nil, errors.NewNotFoundError("продукт", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения истории цен", err)
+	}
+	return history, nil
+}
+
+func (s *ProductsService) CreatePriceChange(
+	id int,
+	priceReq model.PriceChangeRequestBody,
+	userID int,
+) (*model.PriceChange, error) {
+	priceChange, err := s.repo.CreatePriceChange(s.ctx, id, priceReq, userID)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create price change in repository",
+			zap.Error(err),
+			zap.Int("product_id", id),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("price change created successfully",
+			zap.Int("product_id", id),
+			zap.Int("user_id", userID),
+			zap.Time("effective_from", priceChange.EffectiveFrom),
+			zap.Bool("applied", priceChange.Applied),
+		)
+		result = priceChange
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "CreatePriceChange", status, logProductsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for price change",
+			zap.Error(logErr),
+		)
+	}
+	return priceChange, err
+}
+
+// ApplyScheduledPriceChanges применяет наступившие запланированные цены, возвращает число примененных изменений.
+func (s *ProductsService) ApplyScheduledPriceChanges() (int, error) {
+	applied, err := s.repo.ApplyScheduledPriceChanges(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to apply scheduled price changes",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+
+	logger.GetLogger().Info("scheduled price changes applied",
+		zap.Int("count", len(applied)),
+	)
+	_, logErr := s.repo.WriteLog(applied, "ApplyScheduledPriceChanges", logSuccessStatus, logProductsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for scheduled price changes",
+			zap.Error(logErr),
+		)
+	}
+	return len(applied), nil
+}
+
+// normalizeBundle проверяет состав и способ расчета цены комплекта.
+// Для обычного товара параметры комплекта сбрасываются.
+func normalizeBundle(product *model.Product) error {
+	if !product.IsBundle {
+		product.BundlePricing = nil
+		product.BundleDiscountType = nil
+		product.BundleDiscountValue = nil
+		product.Components = nil
+		return nil
+	}
+	if len(product.Components) == 0 {
+		return errors.NewValidationError("комплект должен содержать хотя бы один товар", nil)
+	}
+	for _, component := range product.Components {
+		if component.Quantity <= 0 {
+			return errors.NewValidationError("количество товара в комплекте должно быть положительным", nil)
+		}
+	}
+	if product.BundlePricing == nil {
+		pricing := model.BundlePricingFixed
+		product.BundlePricing = &pricing
+	}
+	switch *product.BundlePricing {
+	case model.BundlePricingFixed:
+		if product.BundleDiscountType != nil || product.BundleDiscountValue != nil {
+			return errors.NewValidationError("скидка комплекта применяется только при расчете цены по компонентам", nil)
+		}
+	case model.BundlePricingComponents:
+		if err := model.ValidateDiscount(product.BundleDiscountType, product.BundleDiscountValue); err != nil {
+			return errors.NewValidationError(err.Error(), err)
+		}
+	default:
+		return errors.NewValidationError("неизвестный способ расчета цены комплекта", nil)
+	}
+	return nil
+}
+
+// normalizeVATRate устанавливает ставку НДС по умолчанию и проверяет допустимость ставки.
+func normalizeVATRate(product *model.Product) error {
+	if product.VATRate == nil {
+		rate := model.DefaultVATRate
+		product.VATRate = &rate
+	}
+	if err := model.ValidateVATRate(*product.VATRate); err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	return nil
+}
+
+// normalizeUnits устанавливает единицу измерения по умолчанию и проверяет параметры упаковки и вес.
+func normalizeUnits(product *model.Product) error {
+	product.UnitCode = strings.TrimSpace(product.UnitCode)
+	if product.UnitCode == "" {
+		product.UnitCode = model.DefaultUnitCode
+	}
+	if product.Quantity < 0 {
+		return errors.NewValidationError("остаток товара не может быть отрицательным", nil)
+	}
+	if (product.PackUnitCode == nil) != (product.PackSize == nil) {
+		return errors.NewValidationError("для упаковки необходимо указать единицу измерения и размер", nil)
+	}
+	if product.PackSize != nil && *product.PackSize <= 0 {
+		return errors.NewValidationError("размер упаковки должен быть положительным", nil)
+	}
+	if product.Weight != nil && *product.Weight < 0 {
+		return errors.NewValidationError("вес товара не может быть отрицательным", nil)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+)
+
+func TestNormalizeBundle(t *testing.T) {
+	fixed := model.BundlePricingFixed
+	components := model.BundlePricingComponents
+	unknown := model.BundlePricing("average")
+	percent := model.DiscountPercent
+	ten, tooMuch := 10, 150
+	set := []model.BundleComponent{{ProductID: 2, Quantity: model.NewQuantity(2)}}
+
+	tests := []struct {
+		name    string
+		product model.Product
+		want    model.Product
+		wantErr bool
+	}{
+		{
+			name: "regular product drops bundle settings",
+			product: model.Product{
+				BundlePricing:      &components,
+				BundleDiscountType: &percent,
+				Components:         set,
+			},
+			want: model.Product{},
+		},
+		{
+			name:    "fixed price by default",
+			product: model.Product{IsBundle: true, Components: set},
+			want:    model.Product{IsBundle: true, BundlePricing: &fixed, Components: set},
+		},
+		{
+			name: "discount on components price",
+			product: model.Product{
+				IsBundle:            true,
+				BundlePricing:       &components,
+				BundleDiscountType:  &percent,
+				BundleDiscountValue: &ten,
+				Components:          set,
+			},
+			want: model.Product{
+				IsBundle:            true,
+				BundlePricing:       &components,
+				BundleDiscountType:  &percent,
+				BundleDiscountValue: &ten,
+				Components:          set,
+			},
+		},
+		{
+			name:    "bundle without components",
+			product: model.Product{IsBundle: true},
+			wantErr: true,
+		},
+		{
+			name: "component without quantity",
+			product: model.Product{
+				IsBundle:   true,
+				Components: []model.BundleComponent{{ProductID: 2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "discount on fixed price",
+			product: model.Product{
+				IsBundle:            true,
+				BundlePricing:       &fixed,
+				BundleDiscountType:  &percent,
+				BundleDiscountValue: &ten,
+				Components:          set,
+			},
+			wantErr: true,
+		},
+		{
+			name: "discount over 100 percent",
+			product: model.Product{
+				IsBundle:            true,
+				BundlePricing:       &components,
+				BundleDiscountType:  &percent,
+				BundleDiscountValue: &tooMuch,
+				Components:          set,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown pricing",
+			product: model.Product{IsBundle: true, BundlePricing: &unknown, Components: set},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			err := normalizeBundle(&product)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeBundle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(product, tt.want) {
+				t.Errorf("normalizeBundle() = %+v, want %+v", product, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logPromoCodesTableName = "logPromoCode"
+)
+
+type PromoCodesService struct {
+	repo repository.PromoCode
+	ctx  context.Context
+}
+
+func NewPromoCodesService(ctx context.Context, repo repository.PromoCode) *PromoCodesService {
+	return &PromoCodesService{repo: repo, ctx: ctx}
+}
+
+func (s *PromoCodesService) Create(promoCodeReq model.PromoCodeRequestBody) (*model.PromoCode, error) {
+	if err := normalizePromoCodeRequest(&promoCodeReq); err != nil {
+		return nil, err
+	}
+	createdPromoCode, err := s.repo.Create(s.ctx, promoCodeReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create promo code in repository",
+			zap.Error(err),
+			zap.String("promo_code", promoCodeReq.Code),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("promo code created successfully",
+			zap.Int("promo_code_id", createdPromoCode.ID),
+			zap.String("promo_code", createdPromoCode.Code),
+		)
+		result = createdPromoCode
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logPromoCodesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for promo code creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdPromoCode, err
+}
+
+func (s *PromoCodesService) GetAll() ([]model.PromoCode, error) {
+	promoCodes, err := s.repo.GetAll(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get promo codes from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка промокодов", err)
+	}
+	return promoCodes, nil
+}
+
+func (s *PromoCodesService) GetByID(id int) (*model.PromoCode, error) {
+	promoCode, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get promo code by ID from repository",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+		)
+		if strings.Contains(err.Error(), "промокод не найден") {
+			return nil, errors.NewNotFoundError("промокод", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения промокода", err)
+	}
+	return promoCode, nil
+}
+
+func (s *PromoCodesService) Update(id int, promoCodeReq model.PromoCodeRequestBody) (*model.PromoCode, error) {
+	if err := normalizePromoCodeRequest(&promoCodeReq); err != nil {
+		return nil, err
+	}
+	updatedPromoCode, err := s.repo.Update(s.ctx, id, promoCodeReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to update promo code in repository",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("promo code updated successfully",
+			zap.Int("promo_code_id", id),
+		)
+		result = updatedPromoCode
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Update", status, logPromoCodesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for promo code update",
+			zap.Error(logErr),
+		)
+	}
+	return updatedPromoCode, err
+}
+
+func (s *PromoCodesService) Delete(id int) error {
+	deletedPromoCode, err := s.repo.Delete(s.ctx, id)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to delete promo code from repository",
+			zap.Error(err),
+			zap.Int("promo_code_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("promo code deleted successfully",
+			zap.Int("promo_code_id", id),
+		)
+		result = deletedPromoCode
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Delete", status, logPromoCodesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for promo code deletion",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
+func normalizePromoCodeRequest(promoCodeReq *model.PromoCodeRequestBody) error {
+	promoCodeReq.Code = strings.ToUpper(strings.TrimSpace(promoCodeReq.Code))
+	if promoCodeReq.Code == "" {
+		return errors.NewValidationError("код промокода не может быть пустым", nil)
+	}
+	if err := model.ValidateDiscount(&promoCodeReq.DiscountType, &promoCodeReq.DiscountValue); err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	currency, err := model.NormalizeCurrency(promoCodeReq.Currency)
+	if err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	promoCodeReq.Currency = currency
+	if promoCodeReq.ValidFrom != nil && promoCodeReq.ValidTo != nil &&
+		!promoCodeReq.ValidTo.After(*promoCodeReq.ValidFrom) {
+		return errors.NewValidationError("дата окончания действия должна быть позже даты начала", nil)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logRecurringOrdersTableName = "logRecurringOrder"
+)
+
+type RecurringOrdersService struct {
+	repo          repository.RecurringOrder
+	orders        Order
+	users         repository.User
+	notifications repository.Notification
+	ctx           context.Context
+}
+
+// NewRecurringOrdersService создает сервис регулярных заказов. Заказы по расписанию создаются
+// через сервис заказов с теми же проверками, что и заказы, оформленные вручную.
+func NewRecurringOrdersService(
+	ctx context.Context,
+	repo repository.RecurringOrder,
+	orders Order,
+	users repository.User,
+	notifications repository.Notification,
+) *RecurringOrdersService {
+	return &RecurringOrdersService{
+		repo:          repo,
+		orders:        orders,
+		users:         users,
+		notifications: notifications,
+		ctx:           ctx,
+	}
+}
+
+func (s *RecurringOrdersService) Create(
+	recurringReq model.RecurringOrderRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.RecurringOrder, error) {
+	ownerID := userID
+	if recurringReq.UserID != nil {
+		if err := checkOrderClient(s.ctx, s.users, *recurringReq.UserID, role); err != nil {
+			return nil, err
+		}
+		ownerID = *recurringReq.UserID
+	}
+	if err := normalizeRecurringOrderRequest(&recurringReq); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	nextRun := recurringReq.Frequency.Next(now, recurringReq.IntervalCount, now.Day())
+	if recurringReq.StartDate != nil {
+		if recurringReq.StartDate.Before(now) {
+			return nil, errors.NewValidationError("дата первого заказа не может быть в прошлом", nil)
+		}
+		nextRun = *recurringReq.StartDate
+	}
+
+	createdRecurring, err := s.repo.Create(s.ctx, recurringReq, ownerID, nextRun)
+	s.writeLog(createdRecurring, err, "Create", ownerID)
+	return createdRecurring, err
+}
+
+func (s *RecurringOrdersService) GetAll(userID int, role model.UserRole) ([]model.RecurringOrder, error) {
+	recurringOrders, err := s.repo.GetAll(s.ctx, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get recurring orders from repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка регулярных заказов", err)
+	}
+	return recurringOrders, nil
+}
+
+func (s *RecurringOrdersService) GetByID(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	recurring, err := s.repo.GetByID(s.ctx, id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get recurring order by ID from repository",
+			zap.Error(err),
+			zap.Int("recurring_order_id", id),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "регулярный заказ не найден") {
+			return nil, errors.NewNotFoundError("регулярный заказ", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения регулярного заказа", err)
+	}
+	return recurring, nil
+}
+
+// Pause приостанавливает создание заказов по шаблону.
+func (s *RecurringOrdersService) Pause(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	recurring, err := s.GetByID(id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	paused, err := s.repo.SetSchedule(s.ctx, id, model.RecurringPaused, recurring.NextRunDate)
+	s.writeLog(paused, err, "Pause", userID)
+	return paused, err
+}
+
+// Resume возобновляет шаблон. Запуски, пропущенные за время паузы, не выполняются:
+// следующий заказ будет создан в ближайшую дату по расписанию.
+func (s *RecurringOrdersService) Resume(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	recurring, err := s.GetByID(id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	resumed, err := s.repo.SetSchedule(s.ctx, id, model.RecurringActive, recurring.NextAfter(time.Now()))
+	s.writeLog(resumed, err, "Resume", userID)
+	return resumed, err
+}
+
+// Skip пропускает ближайший запуск, не меняя состояние шаблона.
+func (s *RecurringOrdersService) Skip(id, userID int, role model.UserRole) (*model.RecurringOrder, error) {
+	recurring, err := s.GetByID(id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.AddRun(s.ctx, id, model.RecurringOrderRun{
+		ScheduledDate: recurring.NextRunDate,
+		Status:        model.RecurringRunSkipped,
+	})
+	var skipped *model.RecurringOrder
+	if err == nil {
+		skipped, err = s.repo.SetSchedule(s.ctx, id, recurring.Status, recurring.Next(recurring.NextRunDate))
+	}
+	s.writeLog(skipped, err, "Skip", userID)
+	return skipped, err
+}
+
+func (s *RecurringOrdersService) Delete(id, userID int, role model.UserRole) error {
+	deleted, err := s.repo.Delete(s.ctx, id, userID, role)
+	s.writeLog(deleted, err, "Delete", userID)
+	return err
+}
+
+// ProcessDue создает заказы по шаблонам, дата запуска которых наступила, и возвращает
+// число созданных заказов. Если заказ создать не удалось, например из-за нехватки товара,
+// запуск записывается как неуспешный, а владелец шаблона получает уведомление.
+func (s *RecurringOrdersService) ProcessDue() (int, error) {
+	due, err := s.repo.GetDue(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get due recurring orders",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	created := 0
+	now := time.Now()
+	for _, recurring := range due {
+		claimed, err := s.repo.Claim(s.ctx, recurring.ID, recurring.NextRunDate, recurring.NextAfter(now))
+		if err != nil {
+			logger.GetLogger().Error("failed to claim recurring order",
+				zap.Error(err),
+				zap.Int("recurring_order_id", recurring.ID),
+			)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if s.materialize(recurring) {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// materialize создает заказ по шаблону и записывает результат запуска.
+func (s *RecurringOrdersService) materialize(recurring model.RecurringOrder) bool {
+	run := model.RecurringOrderRun{ScheduledDate: recurring.NextRunDate}
+	// Заказ оформляется от имени владельца шаблона, поэтому роль не дает дополнительных прав.
+	order, err := s.orders.Create(recurring.OrderRequest(), recurring.UserID, model.RoleClient)
+	if err != nil {
+		logger.GetLogger().Warn("failed to create order from recurring order",
+			zap.Error(err),
+			zap.Int("recurring_order_id", recurring.ID),
+			zap.Int("user_id", recurring.UserID),
+		)
+		message := err.Error()
+		if appErr, ok := errors.IsAppError(err); ok {
+			message = appErr.Message
+		}
+		run.Status = model.RecurringRunFailed
+		run.Error = &message
+		s.notifyFailure(recurring, message)
+	} else {
+		logger.GetLogger().Info("order created from recurring order",
+			zap.Int("recurring_order_id", recurring.ID),
+			zap.Int("order_id", order.ID),
+			zap.Int("user_id", recurring.UserID),
+		)
+		run.Status = model.RecurringRunCreated
+		run.OrderID = &order.ID
+	}
+
+	if err := s.repo.AddRun(s.ctx, recurring.ID, run); err != nil {
+		logger.GetLogger().Error("failed to record recurring order run",
+			zap.Error(err),
+			zap.Int("recurring_order_id", recurring.ID),
+		)
+	}
+	status := logSuccessStatus
+	if run.Status == model.RecurringRunFailed {
+		status = logErrorStatus
+	}
+	_, logErr := s.repo.WriteLog(run, "Run", status, logRecurringOrdersTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for recurring order run",
+			zap.Error(logErr),
+		)
+	}
+	return run.Status == model.RecurringRunCreated
+}
+
+func (s *RecurringOrdersService) notifyFailure(recurring model.RecurringOrder, reason string) {
+	message := fmt.Sprintf("Не удалось оформить регулярный заказ №%d на %s: %s",
+		recurring.ID, recurring.NextRunDate.Format("02.01.2006"), reason)
+	_, err := s.notifications.Create(s.ctx, recurring.UserID, model.NotificationRecurringOrderFailed, message)
+	if err != nil {
+		logger.GetLogger().Error("failed to notify about recurring order failure",
+			zap.Error(err),
+			zap.Int("recurring_order_id", recurring.ID),
+			zap.Int("user_id", recurring.UserID),
+		)
+	}
+}
+
+func (s *RecurringOrdersService) writeLog(recurring *model.RecurringOrder, err error, operation string, userID int) {
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to change recurring order",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("recurring order changed successfully",
+			zap.String("operation", operation),
+			zap.Int("recurring_order_id", recurring.ID),
+			zap.Int("user_id", userID),
+		)
+		result = recurring
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, operation, status, logRecurringOrdersTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for recurring order",
+			zap.Error(logErr),
+			zap.String("operation", operation),
+		)
+	}
+}
+
+func normalizeRecurringOrderRequest(recurringReq *model.RecurringOrderRequestBody) error {
+	if !recurringReq.Frequency.Valid() {
+		return errors.NewValidationError("неизвестная периодичность: "+string(recurringReq.Frequency), nil)
+	}
+	if recurringReq.IntervalCount == 0 {
+		recurringReq.IntervalCount = 1
+	}
+	if recurringReq.IntervalCount < 0 {
+		return errors.NewValidationError("интервал повторения должен быть положительным", nil)
+	}
+	if len(recurringReq.Products) == 0 {
+		return errors.NewValidationError("список товаров не может быть пустым", nil)
+	}
+	for _, product := range recurringReq.Products {
+		if product.Quantity <= 0 {
+			return errors.NewValidationError("количество товара должно быть положительным", nil)
+		}
+	}
+	if recurringReq.TaxMode != nil && !recurringReq.TaxMode.Valid() {
+		return errors.NewValidationError("неизвестный режим НДС: "+string(*recurringReq.TaxMode), nil)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logReturnsTableName = "logReturn"
+)
+
+type ReturnsService struct {
+	repo repository.Return
+	ctx  context.Context
+}
+
+func NewReturnsService(ctx context.Context, repo repository.Return) *ReturnsService {
+	return &ReturnsService{repo: repo, ctx: ctx}
+}
+
+func (s *ReturnsService) Create(
+	returnReq model.ReturnRequestBody,
+	userID int,
+	role model.UserRole,
+) (*model.Return, error) {
+	if err := normalizeReturnRequest(&returnReq); err != nil {
+		return nil, err
+	}
+	createdReturn, err := s.repo.Create(s.ctx, returnReq, userID, role)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create return in repository",
+			zap.Error(err),
+			zap.Int("order_id", returnReq.OrderID),
+			zap.Int("user_id", userID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("return created successfully",
+			zap.Int("return_id", createdReturn.ID),
+			zap.Int("order_id", createdReturn.OrderID),
+			zap.Int64("refund_amount", createdReturn.RefundAmount),
+		)
+		result = createdReturn
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logReturnsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for return creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdReturn, err
+}
+
+func (s *ReturnsService) GetAll(userID int, role model.UserRole) ([]model.Return, error) {
+	returns, err := s.repo.GetAll(s.ctx, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get returns from repository",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка возвратов", err)
+	}
+	return returns, nil
+}
+
+func (s *ReturnsService) GetByID(id, userID int, role model.UserRole) (*model.Return, error) {
+	ret, err := s.repo.GetByID(s.ctx, id, userID, role)
+	if err != nil {
+		logger.GetLogger().Error("failed to get return by ID from repository",
+			zap.Error(err),
+			zap.Int("return_id", id),
+			zap.Int("user_id", userID),
+		)
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Decide одобряет или отклоняет заявку на возврат.
+func (s *ReturnsService) Decide(id int, decision model.ReturnDecisionBody, employeeID int) (*model.Return, error) {
+	if decision.Status != model.ReturnApproved && decision.Status != model.ReturnRejected {
+		return nil, errors.NewValidationError("решение по возврату должно быть approved или rejected", nil)
+	}
+	if decision.Status == model.ReturnRejected && len(decision.DamagedProductIDs) > 0 {
+		return nil, errors.NewValidationError("списание возможно только при одобрении возврата", nil)
+	}
+
+	decidedReturn, err := s.repo.Decide(s.ctx, id, decision, employeeID)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to decide return in repository",
+			zap.Error(err),
+			zap.Int("return_id", id),
+			zap.Int("employee_id", employeeID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("return decided successfully",
+			zap.Int("return_id", id),
+			zap.String("status", string(decidedReturn.Status)),
+			zap.Int("employee_id", employeeID),
+		)
+		result = decidedReturn
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Decide", status, logReturnsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for return decision",
+			zap.Error(logErr),
+		)
+	}
+	return decidedReturn, err
+}
+
+func normalizeReturnRequest(returnReq *model.ReturnRequestBody) error {
+	if len(returnReq.Lines) == 0 {
+		return errors.NewValidationError("заявка на возврат должна содержать товары", nil)
+	}
+	for i := range returnReq.Lines {
+		line := &returnReq.Lines[i]
+		line.Reason = strings.TrimSpace(line.Reason)
+		if line.Quantity <= 0 {
+			return errors.NewValidationError("количество возвращаемого товара должно быть положительным", nil)
+		}
+		if line.Reason == "" {
+			return errors.NewValidationError("необходимо указать причину возврата", nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestReturnsService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockReturn(ctrl)
+
+	tests := []struct {
+		name     string
+		lines    []model.ReturnLineRequest
+		mock     func()
+		wantType apperrors.ErrorType
+	}{
+		{
+			name:  "reason is trimmed",
+			lines: []model.ReturnLineRequest{{ProductID: 2, Quantity: model.NewQuantity(1), Reason: " Брак "}},
+			mock: func() {
+				repoMock.EXPECT().Create(gomock.Any(), model.ReturnRequestBody{
+					OrderID: 7,
+					Lines:   []model.ReturnLineRequest{{ProductID: 2, Quantity: model.NewQuantity(1), Reason: "Брак"}},
+				}, 1, model.RoleClient).Return(&model.Return{ID: 4, OrderID: 7}, nil)
+				repoMock.EXPECT().WriteLog(gomock.Any(), "Create", logSuccessStatus, logReturnsTableName)
+			},
+		},
+		{
+			name:     "no lines",
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "zero quantity",
+			lines:    []model.ReturnLineRequest{{ProductID: 2, Reason: "Брак"}},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "blank reason",
+			lines:    []model.ReturnLineRequest{{ProductID: 2, Quantity: model.NewQuantity(1), Reason: "  "}},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewReturnsService(context.Background(), repoMock)
+
+			tt.mock()
+
+			_, err := s.Create(model.ReturnRequestBody{OrderID: 7, Lines: tt.lines}, 1, model.RoleClient)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка создания возврата error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка создания возврата error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReturnsService_Decide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockReturn(ctrl)
+
+	tests := []struct {
+		name     string
+		decision model.ReturnDecisionBody
+		mock     func()
+		wantType apperrors.ErrorType
+	}{
+		{
+			name:     "approved with write-off",
+			decision: model.ReturnDecisionBody{Status: model.ReturnApproved, DamagedProductIDs: []int{3}},
+			mock: func() {
+				repoMock.EXPECT().
+					Decide(gomock.Any(), 4, model.ReturnDecisionBody{
+						Status:            model.ReturnApproved,
+						DamagedProductIDs: []int{3},
+					}, 9).
+					Return(&model.Return{ID: 4, Status: model.ReturnApproved}, nil)
+				repoMock.EXPECT().WriteLog(gomock.Any(), "Decide", logSuccessStatus, logReturnsTableName)
+			},
+		},
+		{
+			name:     "back to pending",
+			decision: model.ReturnDecisionBody{Status: model.ReturnPending},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "write-off on rejection",
+			decision: model.ReturnDecisionBody{Status: model.ReturnRejected, DamagedProductIDs: []int{3}},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewReturnsService(context.Background(), repoMock)
+
+			tt.mock()
+
+			_, err := s.Decide(4, tt.decision, 9)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка решения по возврату error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка решения по возврату error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logRolesTableName = "logRole"
+
+	// rolePermissionsTTL срок, в течение которого изменения ролей, сделанные
+	// на другом экземпляре приложения, могут быть не видны этому экземпляру.
+	rolePermissionsTTL = time.Minute
+)
+
+// RolesService ведет роли и отвечает на вопрос, какие разрешения есть у роли.
+// Разрешения проверяются на каждом запросе, поэтому они кешируются в памяти.
+type RolesService struct {
+	repo repository.Role
+	ctx  context.Context
+
+	mu          sync.RWMutex
+	permissions map[model.UserRole]model.PermissionSet
+	loadedAt    time.Time
+}
+
+func NewRolesService(ctx context.Context, repo repository.Role) *RolesService {
+	return &RolesService{repo: repo, ctx: ctx}
+}
+
+// Permissions возвращает разрешения роли. У неизвестной роли разрешений нет.
+func (s *RolesService) Permissions(role model.UserRole) (model.PermissionSet, error) {
+	s.mu.RLock()
+	permissions, loadedAt := s.permissions, s.loadedAt
+	s.mu.RUnlock()
+
+	if permissions == nil || time.Since(loadedAt) > rolePermissionsTTL {
+		roles, err := s.repo.GetAll(s.ctx)
+		if err != nil {
+			logger.GetLogger().Error("failed to load role permissions",
+				zap.Error(err),
+			)
+			return nil, errors.NewDatabaseError("ошибка получения разрешений ролей", err)
+		}
+		permissions = make(map[model.UserRole]model.PermissionSet, len(roles))
+		for _, r := range roles {
+			permissions[r.Key] = model.NewPermissionSet(r.Permissions)
+		}
+		s.mu.Lock()
+		s.permissions, s.loadedAt = permissions, time.Now()
+		s.mu.Unlock()
+	}
+
+	if set, ok := permissions[role]; ok {
+		return set, nil
+	}
+	return model.PermissionSet{}, nil
+}
+
+func (s *RolesService) Create(roleReq model.RoleRequestBody) (*model.Role, error) {
+	if !roleReq.Key.Valid() {
+		return nil, errors.NewValidationError(
+			"ключ роли должен начинаться с латинской буквы и содержать до 20 строчных букв, цифр и _",
+			nil,
+		)
+	}
+	if err := normalizeRoleRequest(&roleReq); err != nil {
+		return nil, err
+	}
+	createdRole, err := s.repo.Create(s.ctx, roleReq)
+	s.writeLog(createdRole, err, "Create", roleReq.Key)
+	return createdRole, err
+}
+
+func (s *RolesService) GetAll() ([]model.Role, error) {
+	roles, err := s.repo.GetAll(s.ctx)
+	if err != nil {
+		logger.GetLogger().Error("failed to get roles from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка ролей", err)
+	}
+	return roles, nil
+}
+
+func (s *RolesService) GetByKey(key model.UserRole) (*model.Role, error) {
+	role, err := s.repo.GetByKey(s.ctx, key)
+	if err != nil {
+		logger.GetLogger().Error("failed to get role from repository",
+			zap.Error(err),
+			zap.String("role", string(key)),
+		)
+		if strings.Contains(err.Error(), "роль не найдена") {
+			return nil, errors.NewNotFoundError("роль", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения роли", err)
+	}
+	return role, nil
+}
+
+// Update изменяет роль. Разрешения администратора не изменяются, чтобы в системе
+// всегда оставалась роль, которой доступно управление ролями.
+func (s *RolesService) Update(key model.UserRole, roleReq model.RoleRequestBody) (*model.Role, error) {
+	if key == model.RoleAdmin {
+		return nil, errors.NewForbiddenError("разрешения роли администратора не изменяются", nil)
+	}
+	if err := normalizeRoleRequest(&roleReq); err != nil {
+		return nil, err
+	}
+	updatedRole, err := s.repo.Update(s.ctx, key, roleReq)
+	s.writeLog(updatedRole, err, "Update", key)
+	return updatedRole, err
+}
+
+func (s *RolesService) Delete(key model.UserRole) error {
+	deletedRole, err := s.repo.Delete(s.ctx, key)
+	s.writeLog(deletedRole, err, "Delete", key)
+	return err
+}
+
+// writeLog пишет результат изменения роли в журнал и сбрасывает кеш разрешений.
+func (s *RolesService) writeLog(role *model.Role, err error, operation string, key model.UserRole) {
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to change role in repository",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("role", string(key)),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("role changed successfully",
+			zap.String("operation", operation),
+			zap.String("role", string(key)),
+		)
+		result = role
+		status = logSuccessStatus
+		s.mu.Lock()
+		s.permissions = nil
+		s.mu.Unlock()
+	}
+
+	_, logErr := s.repo.WriteLog(result, operation, status, logRolesTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for role change",
+			zap.Error(logErr),
+		)
+	}
+}
+
+func normalizeRoleRequest(roleReq *model.RoleRequestBody) error {
+	roleReq.Name = strings.TrimSpace(roleReq.Name)
+	if roleReq.Name == "" {
+		return errors.NewValidationError("название роли не может быть пустым", nil)
+	}
+	seen := make(model.PermissionSet, len(roleReq.Permissions))
+	permissions := make([]model.Permission, 0, len(roleReq.Permissions))
+	for _, permission := range roleReq.Permissions {
+		if !permission.Valid() {
+			return errors.NewValidationError("неизвестное разрешение: "+string(permission), nil)
+		}
+		if !seen.Has(permission) {
+			seen[permission] = struct{}{}
+			permissions = append(permissions, permission)
+		}
+	}
+	roleReq.Permissions = permissions
+	return nil
+}
@@ -4,28 +4,41 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/mikhailshtv/stockLkBack/config"
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/mailer"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
 
 type Order interface {
-	Create(order model.OrderRequestBody, userID int) (*model.Order, error)
+	Create(order model.OrderRequestBody, userID int, role model.UserRole) (*model.Order, error)
 	GetAll(userID int, role model.UserRole) ([]model.Order, error)
 	GetByID(id, userID int, role model.UserRole) (*model.Order, error)
-	Delete(id, userID int) error
-	Update(id int, order model.OrderRequestBody, userID int) (*model.Order, error)
-	UpdateStatus(id int, orderStatusRequest model.OrderStatusRequest, userID int) (*model.Order, error)
+	Delete(id, userID int, role model.UserRole) error
+	Update(id int, order model.OrderRequestBody, userID int, role model.UserRole) (*model.Order, error)
+	UpdateStatus(
+		id int,
+		orderStatusRequest model.OrderStatusRequest,
+		userID int,
+		role model.UserRole,
+	) (*model.Order, error)
+	Ship(id int, shipment model.ShipmentRequestBody, userID int) (*model.Order, error)
+	Reorder(id, userID int, role model.UserRole) (*model.ReorderResult, error)
 }
 
 type Product interface {
-	Create(product model.Product) (*model.Product, error)
-	GetAll(params model.ProductQueryParams) ([]model.Product, error)
-	GetByID(id int) (*model.Product, error)
+	Create(product model.Product, userID int) (*model.Product, error)
+	GetAll(params model.ProductQueryParams, userID int) ([]model.Product, error)
+	GetByID(id, userID int) (*model.Product, error)
 	Delete(id int) error
-	Update(id int, product model.Product) (*model.Product, error)
+	Update(id int, product model.Product, userID int) (*model.Product, error)
 	GetTotalCount(params model.ProductQueryParams) (int, error)
+	GetPriceHistory(id int) ([]model.PriceChange, error)
+	CreatePriceChange(id int, priceReq model.PriceChangeRequestBody, userID int) (*model.PriceChange, error)
+	ApplyScheduledPriceChanges() (int, error)
+	GetUnits() ([]model.Unit, error)
 }
 
 type User interface {
@@ -34,22 +47,183 @@ type User interface {
 	GetByID(id int) (*model.User, error)
 	Delete(id int) error
 	Update(id int, user model.UserEditBody) (*model.User, error)
-	Login(user model.LoginRequest) (*model.TokenSuccess, error)
+	Login(user model.LoginRequest, ip string) (*model.TokenSuccess, error)
+	Refresh(refreshReq model.RefreshTokenRequest) (*model.TokenSuccess, error)
+	Logout(claims *model.Claims, logoutReq model.LogoutRequest) error
+	LogoutAll(userID int) error
+	IsTokenRevoked(claims *model.Claims) (bool, error)
 	ChangeUserRole(id int, userRoleReq model.UserRoleBody) (*model.User, error)
+	Unlock(id int) error
+	LoginTwoFactor(loginReq model.TwoFactorLoginRequest) (*model.TokenSuccess, error)
+	SetupTwoFactorChallenge(challengeReq model.TwoFactorChallengeRequest) (*model.TwoFactorEnrollment, error)
+	EnrollTwoFactor(userID int) (*model.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) (*model.RecoveryCodes, error)
+	DisableTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) error
+	ResetTwoFactor(id int) error
 	ChangePassword(id int, changePassworReq model.UserChangePasswordBody) (*model.Success, error)
+	ForgotPassword(forgotReq model.ForgotPasswordRequest) error
+	ResetPassword(resetReq model.ResetPasswordRequest) (*model.Success, error)
+	ResendEmailVerification(userID int) error
+	VerifyEmail(verifyReq model.VerifyEmailRequest) (*model.Success, error)
+	ChangeCustomerGroup(id int, groupReq model.UserCustomerGroupBody) (*model.User, error)
+}
+
+type PriceList interface {
+	Create(priceList model.PriceListRequestBody) (*model.PriceList, error)
+	GetAll() ([]model.PriceList, error)
+	GetByID(id int) (*model.PriceList, error)
+	Update(id int, priceList model.PriceListRequestBody) (*model.PriceList, error)
+	Delete(id int) error
+	AddAssignment(priceListID int, assignment model.PriceListAssignmentBody) (*model.PriceListAssignment, error)
+	DeleteAssignment(priceListID, assignmentID int) error
+	CreateCustomerGroup(group model.CustomerGroupRequestBody) (*model.CustomerGroup, error)
+	GetCustomerGroups() ([]model.CustomerGroup, error)
+	DeleteCustomerGroup(id int) error
+}
+
+type PromoCode interface {
+	Create(promoCode model.PromoCodeRequestBody) (*model.PromoCode, error)
+	GetAll() ([]model.PromoCode, error)
+	GetByID(id int) (*model.PromoCode, error)
+	Update(id int, promoCode model.PromoCodeRequestBody) (*model.PromoCode, error)
+	Delete(id int) error
+}
+
+type Return interface {
+	Create(returnReq model.ReturnRequestBody, userID int, role model.UserRole) (*model.Return, error)
+	GetAll(userID int, role model.UserRole) ([]model.Return, error)
+	GetByID(id, userID int, role model.UserRole) (*model.Return, error)
+	Decide(id int, decision model.ReturnDecisionBody, employeeID int) (*model.Return, error)
+}
+
+type Cart interface {
+	Get(userID int) (*model.Cart, error)
+	AddItem(userID int, item model.CartItemRequest) (*model.Cart, error)
+	UpdateItem(userID int, item model.CartItemRequest) (*model.Cart, error)
+	RemoveItem(userID, productID int) (*model.Cart, error)
+	Clear(userID int) error
+	Checkout(userID int, checkout model.CartCheckoutBody) (*model.Order, error)
+}
+
+type Document interface {
+	Invoice(orderID, userID int, role model.UserRole) ([]byte, error)
+	DeliveryNote(orderID, userID int, role model.UserRole) ([]byte, error)
+}
+
+type Address interface {
+	Create(userID int, address model.AddressRequestBody) (*model.Address, error)
+	GetAll(userID int) ([]model.Address, error)
+	GetByID(id, userID int) (*model.Address, error)
+	Update(id, userID int, address model.AddressRequestBody) (*model.Address, error)
+	Delete(id, userID int) error
+}
+
+type ShippingMethod interface {
+	Create(method model.ShippingMethodRequestBody) (*model.ShippingMethod, error)
+	GetAll(role model.UserRole) ([]model.ShippingMethod, error)
+	GetByID(id int) (*model.ShippingMethod, error)
+	Update(id int, method model.ShippingMethodRequestBody) (*model.ShippingMethod, error)
+	Delete(id int) error
+}
+
+type Comment interface {
+	Create(
+		orderID int,
+		comment model.OrderCommentRequestBody,
+		userID int,
+		role model.UserRole,
+	) (*model.OrderComment, error)
+	GetAll(orderID, userID int, role model.UserRole) ([]model.OrderComment, error)
+	Delete(id, orderID, userID int, role model.UserRole) error
+}
+
+type RecurringOrder interface {
+	Create(
+		recurringReq model.RecurringOrderRequestBody,
+		userID int,
+		role model.UserRole,
+	) (*model.RecurringOrder, error)
+	GetAll(userID int, role model.UserRole) ([]model.RecurringOrder, error)
+	GetByID(id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	Pause(id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	Resume(id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	Skip(id, userID int, role model.UserRole) (*model.RecurringOrder, error)
+	Delete(id, userID int, role model.UserRole) error
+	ProcessDue() (int, error)
+}
+
+type Notification interface {
+	GetAll(userID int, unreadOnly bool) ([]model.Notification, error)
+	MarkRead(id, userID int) (*model.Notification, error)
+}
+
+type Role interface {
+	Permissions(role model.UserRole) (model.PermissionSet, error)
+	Create(role model.RoleRequestBody) (*model.Role, error)
+	GetAll() ([]model.Role, error)
+	GetByKey(key model.UserRole) (*model.Role, error)
+	Update(key model.UserRole, role model.RoleRequestBody) (*model.Role, error)
+	Delete(key model.UserRole) error
+}
+
+type APIKey interface {
+	Create(createdBy int, keyReq model.APIKeyRequestBody) (*model.APIKeyCreated, error)
+	GetAll() ([]model.APIKey, error)
+	GetByID(id int) (*model.APIKey, error)
+	Delete(id int) error
+	Authenticate(key string) (*model.APIKey, error)
 }
 
 type Service struct {
 	Order
 	Product
 	User
+	PriceList
+	PromoCode
+	Return
+	Cart
+	Document
+	Address
+	ShippingMethod
+	Comment
+	RecurringOrder
+	Notification
+	Role
+	APIKey
 }
 
-func NewService(ctx context.Context, repo *repository.Repository) *Service {
+func NewService(ctx context.Context, repo *repository.Repository, cfg *config.Config, mail mailer.Mailer) *Service {
+	orders := NewOrdersService(ctx, repo.Order, repo.User)
+	roles := NewRolesService(ctx, repo.Role)
+	users := NewUsersService(
+		ctx,
+		repo.User,
+		repo.RefreshToken,
+		repo.TokenRevocation,
+		repo.PasswordReset,
+		repo.EmailVerification,
+		repo.LoginAttempt,
+		repo.TwoFactor,
+		repo.TwoFactorChallenge,
+		mail,
+		cfg.Auth,
+	)
 	return &Service{
-		Order:   NewOrdersService(ctx, repo.Order),
-		Product: NewProductsService(ctx, repo.Product),
-		User:    NewUsersService(ctx, repo.User),
+		Order:          orders,
+		Product:        NewProductsService(ctx, repo.Product),
+		User:           users,
+		PriceList:      NewPriceListsService(ctx, repo.PriceList),
+		PromoCode:      NewPromoCodesService(ctx, repo.PromoCode),
+		Return:         NewReturnsService(ctx, repo.Return),
+		Cart:           NewCartsService(ctx, repo.Cart, orders),
+		Document:       NewDocumentsService(ctx, repo.Order, repo.User, repo.Product, cfg.Seller),
+		Address:        NewAddressesService(ctx, repo.Address),
+		ShippingMethod: NewShippingMethodsService(ctx, repo.ShippingMethod),
+		Comment:        NewCommentsService(ctx, repo.Comment),
+		RecurringOrder: NewRecurringOrdersService(ctx, repo.RecurringOrder, orders, repo.User, repo.Notification),
+		Notification:   NewNotificationsService(ctx, repo.Notification),
+		Role:           roles,
+		APIKey:         NewAPIKeysService(ctx, repo.APIKey, repo.User, roles),
 	}
 }
 
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	logShippingMethodsTableName = "logShippingMethod"
+)
+
+type ShippingMethodsService struct {
+	repo repository.ShippingMethod
+	ctx  context.Context
+}
+
+func NewShippingMethodsService(ctx context.Context, repo repository.ShippingMethod) *ShippingMethodsService {
+	return &ShippingMethodsService{repo: repo, ctx: ctx}
+}
+
+func (s *ShippingMethodsService) Create(methodReq model.ShippingMethodRequestBody) (*model.ShippingMethod, error) {
+	if err := normalizeShippingMethodRequest(&methodReq); err != nil {
+		return nil, err
+	}
+	createdMethod, err := s.repo.Create(s.ctx, methodReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to create shipping method in repository",
+			zap.Error(err),
+			zap.String("name", methodReq.Name),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("shipping method created successfully",
+			zap.Int("shipping_method_id", createdMethod.ID),
+		)
+		result = createdMethod
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Create", status, logShippingMethodsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for shipping method creation",
+			zap.Error(logErr),
+		)
+	}
+	return createdMethod, err
+}
+
+// GetAll возвращает способы доставки. Неактивные способы видит только сотрудник.
+func (s *ShippingMethodsService) GetAll(role model.UserRole) ([]model.ShippingMethod, error) {
+	methods, err := s.repo.GetAll(s.ctx, role.IsClient())
+	if err != nil {
+		logger.GetLogger().Error("failed to get shipping methods from repository",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения списка способов доставки", err)
+	}
+	return methods, nil
+}
+
+func (s *ShippingMethodsService) GetByID(id int) (*model.ShippingMethod, error) {
+	method, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get shipping method by ID from repository",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+		)
+		if strings.Contains(err.Error(), "способ доставки не найден") {
+			return nil, errors.NewNotFoundError("способ доставки", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения способа доставки", err)
+	}
+	return method, nil
+}
+
+func (s *ShippingMethodsService) Update(id int, methodReq model.ShippingMethodRequestBody) (*model.ShippingMethod, error) {
+	if err := normalizeShippingMethodRequest(&methodReq); err != nil {
+		return nil, err
+	}
+	updatedMethod, err := s.repo.Update(s.ctx, id, methodReq)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to update shipping method in repository",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("shipping method updated successfully",
+			zap.Int("shipping_method_id", id),
+		)
+		result = updatedMethod
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Update", status, logShippingMethodsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for shipping method update",
+			zap.Error(logErr),
+		)
+	}
+	return updatedMethod, err
+}
+
+func (s *ShippingMethodsService) Delete(id int) error {
+	deletedMethod, err := s.repo.Delete(s.ctx, id)
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to delete shipping method from repository",
+			zap.Error(err),
+			zap.Int("shipping_method_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("shipping method deleted successfully",
+			zap.Int("shipping_method_id", id),
+		)
+		result = deletedMethod
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Delete", status, logShippingMethodsTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for shipping method deletion",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
+func normalizeShippingMethodRequest(methodReq *model.ShippingMethodRequestBody) error {
+	methodReq.Name = strings.TrimSpace(methodReq.Name)
+	if methodReq.Name == "" {
+		return errors.NewValidationError("название способа доставки не может быть пустым", nil)
+	}
+	if !methodReq.Rule.Valid() {
+		return errors.NewValidationError("неизвестное правило расчета доставки", nil)
+	}
+	if methodReq.BaseCost < 0 ||
+		(methodReq.CostPerKg != nil && *methodReq.CostPerKg < 0) ||
+		(methodReq.FreeThreshold != nil && *methodReq.FreeThreshold < 0) {
+		return errors.NewValidationError("стоимость доставки не может быть отрицательной", nil)
+	}
+	if methodReq.Rule == model.ShippingByWeight && methodReq.CostPerKg == nil {
+		return errors.NewValidationError("для расчета по весу нужно указать стоимость килограмма", nil)
+	}
+	if methodReq.Rule == model.ShippingFreeOver && methodReq.FreeThreshold == nil {
+		return errors.NewValidationError("для бесплатной доставки нужно указать порог суммы заказа", nil)
+	}
+	currency, err := model.NormalizeCurrency(methodReq.Currency)
+	if err != nil {
+		return errors.NewValidationError(err.Error(), err)
+	}
+	methodReq.Currency = currency
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestShippingMethodsService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockShippingMethod(ctrl)
+
+	perKg, threshold, negative := int64(5000), int64(300000), int64(-1)
+
+	tests := []struct {
+		name     string
+		args     model.ShippingMethodRequestBody
+		mock     func()
+		wantType apperrors.ErrorType
+	}{
+		{
+			name: "by weight",
+			args: model.ShippingMethodRequestBody{
+				Name: " Курьер ", Rule: model.ShippingByWeight, BaseCost: 30000, CostPerKg: &perKg,
+			},
+			mock: func() {
+				repoMock.EXPECT().Create(gomock.Any(), model.ShippingMethodRequestBody{
+					Name: "Курьер", Rule: model.ShippingByWeight, BaseCost: 30000, CostPerKg: &perKg,
+					Currency: model.DefaultCurrency,
+				}).Return(&model.ShippingMethod{ID: 1, Name: "Курьер"}, nil)
+				repoMock.EXPECT().WriteLog(gomock.Any(), "Create", logSuccessStatus, logShippingMethodsTableName)
+			},
+		},
+		{
+			name:     "blank name",
+			args:     model.ShippingMethodRequestBody{Name: " ", Rule: model.ShippingFlat},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "unknown rule",
+			args:     model.ShippingMethodRequestBody{Name: "Почта", Rule: "distance"},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name: "negative cost",
+			args: model.ShippingMethodRequestBody{
+				Name: "Почта", Rule: model.ShippingByWeight, CostPerKg: &negative,
+			},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "by weight without cost per kilogram",
+			args:     model.ShippingMethodRequestBody{Name: "Почта", Rule: model.ShippingByWeight},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name:     "free over without threshold",
+			args:     model.ShippingMethodRequestBody{Name: "Почта", Rule: model.ShippingFreeOver},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+		{
+			name: "invalid currency",
+			args: model.ShippingMethodRequestBody{
+				Name: "Почта", Rule: model.ShippingFreeOver, FreeThreshold: &threshold, Currency: "RUBL",
+			},
+			mock:     func() {},
+			wantType: apperrors.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewShippingMethodsService(context.Background(), repoMock)
+
+			tt.mock()
+
+			_, err := s.Create(tt.args)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка создания способа доставки error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка создания способа доставки error = %v", err)
+			}
+		})
+	}
+}
+
+func TestShippingMethodsService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	repoMock := repoMocks.NewMockShippingMethod(ctrl)
+
+	tests := []struct {
+		name       string
+		role       model.UserRole
+		onlyActive bool
+	}{
+		{name: "client sees only active methods", role: model.RoleClient, onlyActive: true},
+		{name: "manager sees all methods", role: model.RoleEmployee, onlyActive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewShippingMethodsService(context.Background(), repoMock)
+
+			repoMock.EXPECT().GetAll(gomock.Any(), tt.onlyActive).Return([]model.ShippingMethod{}, nil)
+
+			if _, err := s.GetAll(tt.role); err != nil {
+				t.Errorf("Ошибка получения способов доставки error = %v", err)
+			}
+		})
+	}
+}
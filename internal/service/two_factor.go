@@ -0,0 +1,397 @@
+package service
+
+import (
+	"bytes"
+	"image/png"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	"github.com/mikhailshtv/stockLkBack/pkg/errors"
+	"github.com/mikhailshtv/stockLkBack/pkg/logger"
+
+	"github.com/pquerna/otp/totp"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTwoFactorIssuer = "stock-lk-back"
+
+	twoFactorChallengeTTL   = 5 * time.Minute
+	twoFactorMaxAttempts    = 5
+	twoFactorRecoveryCodes  = 10
+	twoFactorQRCodeSize     = 200
+	twoFactorNotConfigured  = "двухфакторная аутентификация не настроена"
+	twoFactorAlreadyEnabled = "двухфакторная аутентификация уже включена"
+)
+
+// twoFactorRequired возвращает true, если роль пользователя не может входить без второго фактора.
+func (s *UsersService) twoFactorRequired(role model.UserRole) bool {
+	return slices.Contains(s.auth.TwoFactorRequiredRoles, string(role))
+}
+
+// getTwoFactor возвращает настройку двухфакторной аутентификации или nil, если ее нет.
+func (s *UsersService) getTwoFactor(userID int) (*model.TwoFactor, error) {
+	twoFactor, err := s.twoFactor.Get(s.ctx, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), twoFactorNotConfigured) {
+			return nil, nil
+		}
+		logger.GetLogger().Error("failed to get two-factor settings",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewDatabaseError("ошибка получения настройки двухфакторной аутентификации", err)
+	}
+	return twoFactor, nil
+}
+
+// startTwoFactorChallenge начинает второй шаг входа, если пользователь включил двухфакторную
+// аутентификацию или она обязательна для его роли. Возвращает nil, если второй шаг не нужен.
+func (s *UsersService) startTwoFactorChallenge(user *model.User) (*model.TokenSuccess, error) {
+	twoFactor, err := s.getTwoFactor(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := twoFactor != nil && twoFactor.Enabled()
+	if !enabled && !s.twoFactorRequired(user.Role) {
+		return nil, nil
+	}
+
+	token, hash, err := jwtgen.NewOpaqueToken()
+	if err == nil {
+		err = s.challenges.Create(s.ctx, hash, user.ID, twoFactorChallengeTTL)
+	}
+	if err != nil {
+		logger.GetLogger().Error("failed to start two-factor challenge",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return nil, errors.NewInternalError("Ошибка аутентификации", err)
+	}
+	message := "введите код из приложения-аутентификатора"
+	if !enabled {
+		message = "для входа необходимо настроить двухфакторную аутентификацию"
+	}
+	return &model.TokenSuccess{
+		Message:                message,
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: !enabled,
+		ChallengeToken:         token,
+	}, nil
+}
+
+// LoginTwoFactor завершает вход кодом из приложения-аутентификатора или кодом восстановления.
+// Если двухфакторная аутентификация настраивается при входе, первый верный код включает ее,
+// а в ответе возвращаются коды восстановления.
+func (s *UsersService) LoginTwoFactor(loginReq model.TwoFactorLoginRequest) (*model.TokenSuccess, error) {
+	challengeHash := jwtgen.HashToken(loginReq.ChallengeToken)
+	userID, attempts, err := s.challenges.Attempt(s.ctx, challengeHash)
+	if err != nil {
+		return nil, s.challengeError(err)
+	}
+	if attempts > twoFactorMaxAttempts {
+		s.deleteChallenge(challengeHash, userID)
+		return nil, errors.NewUnauthorizedError("Слишком много неверных кодов, войдите заново", nil)
+	}
+
+	user, err := s.repo.GetByID(s.ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for two-factor login",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return nil, errors.NewUnauthorizedError("пользователь не найден", err)
+	}
+	subjects := []loginSubject{{key: loginKey(user.Login), limit: s.auth.LoginMaxAttempts}}
+	lockedFor, err := s.attempts.LockedFor(s.ctx, subjects[0].key)
+	if err != nil {
+		return nil, errors.NewInternalError("Ошибка аутентификации", err)
+	}
+	if lockedFor > 0 {
+		return nil, errors.NewTooManyRequestsError(
+			"Слишком много неудачных попыток входа, повторите позже",
+			nil,
+		).WithRetryAfter(lockedFor)
+	}
+
+	twoFactor, err := s.getTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, errors.NewValidationError("Сначала получите секрет для приложения-аутентификатора", nil)
+	}
+	var valid bool
+	if twoFactor.Enabled() {
+		valid, err = s.checkSecondFactor(userID, twoFactor.Secret, loginReq.Code)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		valid = totp.Validate(strings.TrimSpace(loginReq.Code), twoFactor.Secret)
+	}
+	if !valid {
+		// Неверные коды учитываются вместе с неверными паролями, иначе код можно
+		// было бы подбирать, каждый раз заново входя по известному паролю.
+		if lockErr := s.registerLoginFailure(subjects); lockErr != nil {
+			s.deleteChallenge(challengeHash, userID)
+			return nil, lockErr
+		}
+		return nil, errors.NewUnauthorizedError("Неверный код подтверждения", nil)
+	}
+
+	var recoveryCodes []string
+	if !twoFactor.Enabled() {
+		recoveryCodes, err = s.enableTwoFactor(user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.deleteChallenge(challengeHash, userID)
+	token, err := s.startSession(user)
+	if err != nil {
+		return nil, err
+	}
+	token.RecoveryCodes = recoveryCodes
+	logger.GetLogger().Info("user logged in with second factor",
+		zap.Int("user_id", userID),
+	)
+	return token, nil
+}
+
+// SetupTwoFactorChallenge выдает секрет для приложения-аутентификатора пользователю, которому
+// двухфакторная аутентификация обязательна, но еще не настроена. Доступно только на втором шаге входа.
+func (s *UsersService) SetupTwoFactorChallenge(
+	challengeReq model.TwoFactorChallengeRequest,
+) (*model.TwoFactorEnrollment, error) {
+	userID, err := s.challenges.Get(s.ctx, jwtgen.HashToken(challengeReq.ChallengeToken))
+	if err != nil {
+		return nil, s.challengeError(err)
+	}
+	return s.EnrollTwoFactor(userID)
+}
+
+// EnrollTwoFactor создает новый секрет TOTP. Двухфакторная аутентификация включается только
+// после подтверждения кодом, до этого секрет можно получить заново.
+func (s *UsersService) EnrollTwoFactor(userID int) (*model.TwoFactorEnrollment, error) {
+	user, err := s.repo.GetByID(s.ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for two-factor enrollment",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return nil, errors.NewNotFoundError("пользователь", err)
+		}
+		return nil, errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.auth.Issuer,
+		AccountName: user.Login,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("ошибка генерации секрета", err)
+	}
+	if err := s.twoFactor.SaveSecret(s.ctx, userID, key.Secret()); err != nil {
+		logger.GetLogger().Error("failed to save two-factor secret",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), twoFactorAlreadyEnabled) {
+			return nil, errors.NewValidationError(err.Error(), err)
+		}
+		return nil, errors.NewDatabaseError("ошибка сохранения секрета", err)
+	}
+
+	image, err := key.Image(twoFactorQRCodeSize, twoFactorQRCodeSize)
+	if err != nil {
+		return nil, errors.NewInternalError("ошибка генерации QR-кода", err)
+	}
+	var qrCode bytes.Buffer
+	if err := png.Encode(&qrCode, image); err != nil {
+		return nil, errors.NewInternalError("ошибка генерации QR-кода", err)
+	}
+	logger.GetLogger().Info("two-factor enrollment started",
+		zap.Int("user_id", userID),
+	)
+	return &model.TwoFactorEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: qrCode.Bytes(),
+	}, nil
+}
+
+// ConfirmTwoFactor включает двухфакторную аутентификацию, если код из приложения совпал,
+// и возвращает коды восстановления.
+func (s *UsersService) ConfirmTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) (*model.RecoveryCodes, error) {
+	twoFactor, err := s.getTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, errors.NewValidationError("Сначала получите секрет для приложения-аутентификатора", nil)
+	}
+	if twoFactor.Enabled() {
+		return nil, errors.NewValidationError(twoFactorAlreadyEnabled, nil)
+	}
+	if !totp.Validate(strings.TrimSpace(codeReq.Code), twoFactor.Secret) {
+		return nil, errors.NewValidationError("Неверный код подтверждения", nil)
+	}
+	user, err := s.repo.GetByID(s.ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	codes, err := s.enableTwoFactor(user)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RecoveryCodes{Codes: codes}, nil
+}
+
+// DisableTwoFactor отключает двухфакторную аутентификацию после проверки кода. Пользователям,
+// которым она обязательна, отключить ее нельзя.
+func (s *UsersService) DisableTwoFactor(userID int, codeReq model.TwoFactorCodeRequest) error {
+	user, err := s.repo.GetByID(s.ctx, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return errors.NewNotFoundError("пользователь", err)
+		}
+		return errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	if s.twoFactorRequired(user.Role) {
+		return errors.NewForbiddenError("Для вашей роли двухфакторная аутентификация обязательна", nil)
+	}
+	twoFactor, err := s.getTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+	if twoFactor == nil || !twoFactor.Enabled() {
+		return errors.NewValidationError("двухфакторная аутентификация не включена", nil)
+	}
+	valid, err := s.checkSecondFactor(userID, twoFactor.Secret, codeReq.Code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.NewValidationError("Неверный код подтверждения", nil)
+	}
+	return s.disableTwoFactor(user, "DisableTwoFactor")
+}
+
+// ResetTwoFactor отключает двухфакторную аутентификацию пользователя, потерявшего устройство
+// и коды восстановления. Если она обязательна для роли, при следующем входе ее придется настроить заново.
+func (s *UsersService) ResetTwoFactor(id int) error {
+	user, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for two-factor reset",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		return err
+	}
+	return s.disableTwoFactor(user, "ResetTwoFactor")
+}
+
+// checkSecondFactor проверяет код из приложения-аутентификатора, а если он не подошел,
+// пробует погасить им код восстановления.
+func (s *UsersService) checkSecondFactor(userID int, secret, code string) (bool, error) {
+	if totp.Validate(strings.TrimSpace(code), secret) {
+		return true, nil
+	}
+	used, err := s.twoFactor.UseRecoveryCode(s.ctx, userID, jwtgen.HashRecoveryCode(code))
+	if err != nil {
+		logger.GetLogger().Error("failed to use recovery code",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return false, errors.NewDatabaseError("ошибка проверки кода восстановления", err)
+	}
+	if used {
+		logger.GetLogger().Warn("recovery code used",
+			zap.Int("user_id", userID),
+		)
+	}
+	return used, nil
+}
+
+// enableTwoFactor включает подтвержденную настройку и выпускает новые коды восстановления.
+func (s *UsersService) enableTwoFactor(user *model.User) ([]string, error) {
+	codes := make([]string, 0, twoFactorRecoveryCodes)
+	hashes := make([]string, 0, twoFactorRecoveryCodes)
+	for range twoFactorRecoveryCodes {
+		code, hash, err := jwtgen.NewRecoveryCode()
+		if err != nil {
+			return nil, errors.NewInternalError("ошибка генерации кодов восстановления", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hash)
+	}
+	err := s.twoFactor.Enable(s.ctx, user.ID, hashes)
+	s.writeTwoFactorLog(user, "EnableTwoFactor", err)
+	if err != nil {
+		if strings.Contains(err.Error(), twoFactorAlreadyEnabled) {
+			return nil, errors.NewValidationError(err.Error(), err)
+		}
+		return nil, errors.NewDatabaseError("ошибка включения двухфакторной аутентификации", err)
+	}
+	return codes, nil
+}
+
+func (s *UsersService) disableTwoFactor(user *model.User, operation string) error {
+	err := s.twoFactor.Disable(s.ctx, user.ID)
+	s.writeTwoFactorLog(user, operation, err)
+	if err != nil {
+		return errors.NewDatabaseError("ошибка отключения двухфакторной аутентификации", err)
+	}
+	return nil
+}
+
+func (s *UsersService) writeTwoFactorLog(user *model.User, operation string, err error) {
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to change two-factor settings",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.Int("user_id", user.ID),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("two-factor settings changed",
+			zap.String("operation", operation),
+			zap.Int("user_id", user.ID),
+		)
+		result = user
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, operation, status, logUsersTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for two-factor settings",
+			zap.Error(logErr),
+		)
+	}
+}
+
+func (s *UsersService) challengeError(err error) error {
+	if strings.Contains(err.Error(), "сессия входа истекла") {
+		return errors.NewUnauthorizedError("Сессия входа истекла, войдите заново", err)
+	}
+	logger.GetLogger().Error("failed to get two-factor challenge",
+		zap.Error(err),
+	)
+	return errors.NewInternalError("Ошибка аутентификации", err)
+}
+
+func (s *UsersService) deleteChallenge(challengeHash string, userID int) {
+	if err := s.challenges.Delete(s.ctx, challengeHash); err != nil {
+		logger.GetLogger().Error("failed to delete two-factor challenge",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+	}
+}
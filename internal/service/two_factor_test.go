@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+)
+
+func TestUsersService_checkSecondFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	twoFactorMock := repoMocks.NewMockTwoFactor(ctrl)
+
+	const secret = "JBSWY3DPEHPK3PXP"
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("Ошибка генерации кода: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		mock     func()
+		want     bool
+		wantType apperrors.ErrorType
+	}{
+		{
+			name: "authenticator code",
+			code: code,
+			mock: func() {},
+			want: true,
+		},
+		{
+			name: "authenticator code with spaces",
+			code: " " + code + " ",
+			mock: func() {},
+			want: true,
+		},
+		{
+			name: "recovery code is used up",
+			code: "ABCDE-12345",
+			mock: func() {
+				twoFactorMock.EXPECT().
+					UseRecoveryCode(gomock.Any(), 1, jwtgen.HashRecoveryCode("abcde12345")).
+					Return(true, nil)
+			},
+			want: true,
+		},
+		{
+			name: "wrong code",
+			code: "00000",
+			mock: func() {
+				twoFactorMock.EXPECT().
+					UseRecoveryCode(gomock.Any(), 1, jwtgen.HashRecoveryCode("00000")).
+					Return(false, nil)
+			},
+			want: false,
+		},
+		{
+			name: "recovery codes unavailable",
+			code: "abcde-12345",
+			mock: func() {
+				twoFactorMock.EXPECT().
+					UseRecoveryCode(gomock.Any(), 1, gomock.Any()).
+					Return(false, errors.New("connection refused"))
+			},
+			wantType: apperrors.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUsersService(
+				context.Background(), nil, nil, nil, nil, nil, nil, twoFactorMock, nil, nil, config.Auth{},
+			)
+
+			tt.mock()
+
+			got, err := s.checkSecondFactor(1, secret, tt.code)
+			if tt.wantType != "" {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Type != tt.wantType {
+					t.Errorf("Ошибка проверки кода error = %v, want type %s", err, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Ошибка проверки кода error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkSecondFactor(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
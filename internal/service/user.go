@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/mikhailshtv/stockLkBack/config"
 	"github.com/mikhailshtv/stockLkBack/internal/model"
 	"github.com/mikhailshtv/stockLkBack/internal/repository"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/mailer"
 	"github.com/mikhailshtv/stockLkBack/pkg/errors"
 	"github.com/mikhailshtv/stockLkBack/pkg/logger"
 
@@ -13,15 +20,98 @@ import (
 
 const (
 	logUsersTableName = "logUser"
+
+	defaultAccessTokenTTL   = time.Hour
+	defaultRefreshTokenTTL  = 30 * 24 * time.Hour
+	defaultPasswordResetTTL = time.Hour
+
+	defaultEmailVerificationTTL       = 24 * time.Hour
+	defaultVerificationResendInterval = time.Minute
+	defaultVerificationHourlyLimit    = 5
+
+	defaultLoginMaxAttempts      = 5
+	defaultLoginMaxAttemptsPerIP = 50
+	defaultLoginAttemptsWindow   = time.Hour
+	defaultLoginLockout          = time.Minute
+	defaultLoginLockoutMax       = time.Hour
 )
 
 type UsersService struct {
-	repo repository.User
-	ctx  context.Context
+	repo          repository.User
+	tokens        repository.RefreshToken
+	revocations   repository.TokenRevocation
+	resets        repository.PasswordReset
+	verifications repository.EmailVerification
+	attempts      repository.LoginAttempt
+	twoFactor     repository.TwoFactor
+	challenges    repository.TwoFactorChallenge
+	mail          mailer.Mailer
+	auth          config.Auth
+	ctx           context.Context
 }
 
-func NewUsersService(ctx context.Context, repo repository.User) *UsersService {
-	return &UsersService{repo: repo, ctx: ctx}
+func NewUsersService(
+	ctx context.Context,
+	repo repository.User,
+	tokens repository.RefreshToken,
+	revocations repository.TokenRevocation,
+	resets repository.PasswordReset,
+	verifications repository.EmailVerification,
+	attempts repository.LoginAttempt,
+	twoFactor repository.TwoFactor,
+	challenges repository.TwoFactorChallenge,
+	mail mailer.Mailer,
+	auth config.Auth,
+) *UsersService {
+	if auth.AccessTokenTTL <= 0 {
+		auth.AccessTokenTTL = defaultAccessTokenTTL
+	}
+	if auth.RefreshTokenTTL <= 0 {
+		auth.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
+	if auth.PasswordResetTTL <= 0 {
+		auth.PasswordResetTTL = defaultPasswordResetTTL
+	}
+	if auth.EmailVerificationTTL <= 0 {
+		auth.EmailVerificationTTL = defaultEmailVerificationTTL
+	}
+	if auth.VerificationResendInterval <= 0 {
+		auth.VerificationResendInterval = defaultVerificationResendInterval
+	}
+	if auth.VerificationHourlyLimit <= 0 {
+		auth.VerificationHourlyLimit = defaultVerificationHourlyLimit
+	}
+	if auth.LoginMaxAttempts <= 0 {
+		auth.LoginMaxAttempts = defaultLoginMaxAttempts
+	}
+	if auth.LoginMaxAttemptsPerIP <= 0 {
+		auth.LoginMaxAttemptsPerIP = defaultLoginMaxAttemptsPerIP
+	}
+	if auth.LoginAttemptsWindow <= 0 {
+		auth.LoginAttemptsWindow = defaultLoginAttemptsWindow
+	}
+	if auth.LoginLockout <= 0 {
+		auth.LoginLockout = defaultLoginLockout
+	}
+	if auth.LoginLockoutMax < auth.LoginLockout {
+		auth.LoginLockoutMax = max(defaultLoginLockoutMax, auth.LoginLockout)
+	}
+	if auth.Issuer == "" {
+		auth.Issuer = defaultTwoFactorIssuer
+	}
+	return &UsersService{
+		repo:          repo,
+		tokens:        tokens,
+		revocations:   revocations,
+		resets:        resets,
+		verifications: verifications,
+		attempts:      attempts,
+		twoFactor:     twoFactor,
+		challenges:    challenges,
+		mail:          mail,
+		auth:          auth,
+		ctx:           ctx,
+	}
 }
 
 func (s *UsersService) Create(userRequest model.UserCreateBody) (*model.User, error) {
@@ -67,6 +157,10 @@ func (s *UsersService) Create(userRequest model.UserCreateBody) (*model.User, er
 			zap.Error(logErr),
 		)
 	}
+	if err == nil {
+		// Регистрация не отменяется, если письмо не ушло: его можно запросить повторно.
+		_ = s.sendEmailVerification(createdUser)
+	}
 	return createdUser, err
 }
 
@@ -151,16 +245,57 @@ func (s *UsersService) Update(id int, user model.UserEditBody) (*model.User, err
 			zap.Error(logErr),
 		)
 	}
+	if err == nil && user.Email != "" && !updatedUser.EmailVerified() {
+		// Новый адрес нужно подтвердить; ошибка отправки уже записана в лог.
+		_ = s.sendEmailVerification(updatedUser)
+	}
 	return updatedUser, err
 }
 
-func (s *UsersService) Login(user model.LoginRequest) (*model.TokenSuccess, error) {
-	token, err := s.repo.Login(s.ctx, user)
+// Login проверяет учетные данные и выдает пару токенов, открывающую новое семейство refresh-токенов.
+// Неудачные попытки считаются по логину и по IP-адресу; при подборе пароля вход временно блокируется.
+// Если нужен второй фактор, вместо токенов возвращается токен второго шага входа.
+func (s *UsersService) Login(user model.LoginRequest, ip string) (*model.TokenSuccess, error) {
+	subjects := s.loginSubjects(user.Login, ip)
+	keys := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		keys = append(keys, subject.key)
+	}
+	lockedFor, err := s.attempts.LockedFor(s.ctx, keys...)
+	if err != nil {
+		logger.GetLogger().Error("failed to check login lockout",
+			zap.Error(err),
+			zap.String("login", user.Login),
+		)
+		return nil, errors.NewInternalError("Ошибка аутентификации", err)
+	}
+	if lockedFor > 0 {
+		return nil, errors.NewTooManyRequestsError(
+			"Слишком много неудачных попыток входа, повторите позже",
+			nil,
+		).WithRetryAfter(lockedFor)
+	}
+
+	loggedUser, err := s.repo.Login(s.ctx, user)
 	if err != nil {
 		logger.GetLogger().Error("failed to login user",
 			zap.Error(err),
 			zap.String("login", user.Login),
+			zap.String("ip", ip),
 		)
+		if strings.Contains(err.Error(), "логин или пароль пользователя недействителен") {
+			if lockErr := s.registerLoginFailure(subjects); lockErr != nil {
+				return nil, lockErr
+			}
+		}
+		return nil, err
+	}
+	challenge, err := s.startTwoFactorChallenge(loggedUser)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+	token, err := s.startSession(loggedUser)
+	if err != nil {
 		return nil, err
 	}
 	logger.GetLogger().Info("user logged in successfully",
@@ -169,6 +304,206 @@ func (s *UsersService) Login(user model.LoginRequest) (*model.TokenSuccess, erro
 	return token, nil
 }
 
+// startSession завершает успешный вход: сбрасывает счетчик неудачных попыток по логину
+// и выдает пару токенов, открывающую новое семейство refresh-токенов.
+func (s *UsersService) startSession(user *model.User) (*model.TokenSuccess, error) {
+	// Счетчик IP-адреса не сбрасывается: иначе, зная свой пароль, можно подбирать чужие.
+	if err := s.attempts.Reset(s.ctx, loginKey(user.Login), loginKey(user.Email)); err != nil {
+		logger.GetLogger().Error("failed to reset login failures",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+	}
+
+	refreshToken, refreshHash, err := jwtgen.NewOpaqueToken()
+	if err == nil {
+		_, err = s.tokens.Create(s.ctx, user.ID, refreshHash, time.Now().Add(s.auth.RefreshTokenTTL))
+	}
+	if err != nil {
+		logger.GetLogger().Error("failed to issue refresh token",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return nil, fmt.Errorf("ошибка генерации токена")
+	}
+	return s.issueTokens(user, refreshToken)
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов. Предъявленный токен
+// погашается; повторное его использование отзывает все семейство.
+func (s *UsersService) Refresh(refreshReq model.RefreshTokenRequest) (*model.TokenSuccess, error) {
+	refreshToken, refreshHash, err := jwtgen.NewOpaqueToken()
+	if err != nil {
+		return nil, errors.NewInternalError("ошибка генерации токена", err)
+	}
+	rotated, err := s.tokens.Rotate(
+		s.ctx,
+		jwtgen.HashToken(refreshReq.RefreshToken),
+		refreshHash,
+		time.Now().Add(s.auth.RefreshTokenTTL),
+	)
+	if err != nil {
+		switch msg := err.Error(); {
+		case strings.Contains(msg, "использован повторно"):
+			logger.GetLogger().Warn("refresh token reuse detected, token family revoked",
+				zap.Error(err),
+			)
+			return nil, errors.NewUnauthorizedError(msg, err)
+		case strings.Contains(msg, "refresh-токен недействителен"),
+			strings.Contains(msg, "refresh-токен отозван"),
+			strings.Contains(msg, "срок действия refresh-токена истек"):
+			return nil, errors.NewUnauthorizedError(msg, err)
+		}
+		logger.GetLogger().Error("failed to rotate refresh token",
+			zap.Error(err),
+		)
+		return nil, errors.NewDatabaseError("ошибка обновления токена", err)
+	}
+
+	user, err := s.repo.GetByID(s.ctx, rotated.UserID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for token refresh",
+			zap.Error(err),
+			zap.Int("user_id", rotated.UserID),
+		)
+		return nil, errors.NewUnauthorizedError("пользователь не найден", err)
+	}
+	token, err := s.issueTokens(user, refreshToken)
+	if err != nil {
+		return nil, errors.NewInternalError("ошибка генерации токена", err)
+	}
+	return token, nil
+}
+
+// issueTokens выпускает access-токен и возвращает его вместе с уже сохраненным refresh-токеном.
+func (s *UsersService) issueTokens(user *model.User, refreshToken string) (*model.TokenSuccess, error) {
+	version, err := s.revocations.GetTokenVersion(s.ctx, user.ID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user token version",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return nil, fmt.Errorf("ошибка генерации токена")
+	}
+	accessToken, err := jwtgen.GenerateToken(user.ID, user.Login, user.Role, version, s.auth.AccessTokenTTL)
+	if err != nil {
+		logger.GetLogger().Error("failed to generate access token",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return nil, fmt.Errorf("ошибка генерации токена")
+	}
+	return &model.TokenSuccess{
+		Message:      "аутентификация успешна",
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.auth.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// Logout отзывает текущий access-токен и, если передан, refresh-токен вместе с его семейством.
+func (s *UsersService) Logout(claims *model.Claims, logoutReq model.LogoutRequest) error {
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if err := s.revocations.RevokeToken(s.ctx, claims.ID, expiresAt); err != nil {
+		logger.GetLogger().Error("failed to revoke access token",
+			zap.Error(err),
+			zap.Int("user_id", claims.UserID),
+		)
+		return errors.NewInternalError("ошибка выхода из системы", err)
+	}
+	if logoutReq.RefreshToken != "" {
+		err := s.tokens.RevokeFamily(s.ctx, jwtgen.HashToken(logoutReq.RefreshToken), claims.UserID)
+		if err != nil {
+			logger.GetLogger().Error("failed to revoke refresh token",
+				zap.Error(err),
+				zap.Int("user_id", claims.UserID),
+			)
+			return errors.NewDatabaseError("ошибка выхода из системы", err)
+		}
+	}
+	logger.GetLogger().Info("user logged out",
+		zap.Int("user_id", claims.UserID),
+	)
+	return nil
+}
+
+// LogoutAll завершает все сессии пользователя на всех устройствах.
+func (s *UsersService) LogoutAll(userID int) error {
+	if err := s.revokeSessions(userID); err != nil {
+		return errors.NewInternalError("ошибка завершения сессий пользователя", err)
+	}
+	logger.GetLogger().Info("all user sessions revoked",
+		zap.Int("user_id", userID),
+	)
+	return nil
+}
+
+// IsTokenRevoked проверяет, не отозван ли access-токен.
+func (s *UsersService) IsTokenRevoked(claims *model.Claims) (bool, error) {
+	return s.revocations.IsRevoked(s.ctx, claims.ID, claims.UserID, claims.TokenVersion)
+}
+
+// revokeSessions отзывает все access- и refresh-токены пользователя.
+func (s *UsersService) revokeSessions(userID int) error {
+	if err := s.revocations.RevokeUserTokens(s.ctx, userID); err != nil {
+		logger.GetLogger().Error("failed to revoke user access tokens",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return err
+	}
+	if err := s.tokens.RevokeAll(s.ctx, userID); err != nil {
+		logger.GetLogger().Error("failed to revoke user refresh tokens",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		return err
+	}
+	return nil
+}
+
+// Unlock снимает блокировку входа с учетной записи и обнуляет счетчик неудачных попыток.
+// Блокировки по IP-адресу не снимаются.
+func (s *UsersService) Unlock(id int) error {
+	user, err := s.repo.GetByID(s.ctx, id)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for unlock",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		return err
+	}
+	// Войти можно и по логину, и по email, поэтому снимаются обе блокировки.
+	err = s.attempts.Reset(s.ctx, loginKey(user.Login), loginKey(user.Email))
+	var result any
+	var status string
+	if err != nil {
+		logger.GetLogger().Error("failed to unlock user",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		result = model.Error{Error: err.Error()}
+		status = logErrorStatus
+	} else {
+		logger.GetLogger().Info("user unlocked successfully",
+			zap.Int("user_id", id),
+		)
+		result = user
+		status = logSuccessStatus
+	}
+
+	_, logErr := s.repo.WriteLog(result, "Unlock", status, logUsersTableName)
+	if logErr != nil {
+		logger.GetLogger().Error("failed to write log for user unlock",
+			zap.Error(logErr),
+		)
+	}
+	return err
+}
+
 func (s *UsersService) ChangeUserRole(id int, userRoleReq model.UserRoleBody) (*model.User, error) {
 	updatedUser, err := s.repo.ChangeUserRole(s.ctx, id, userRoleReq)
 	if err != nil {
@@ -182,6 +517,24 @@ func (s *UsersService) ChangeUserRole(id int, userRoleReq model.UserRoleBody) (*
 		zap.Int("user_id", id),
 		zap.String("new_role", string(userRoleReq.Role)),
 	)
+	// Роль записана в токенах, поэтому после ее смены пользователь должен войти заново.
+	// Ошибка отзыва уже записана в лог и не отменяет смену роли.
+	_ = s.revokeSessions(id)
+	return updatedUser, nil
+}
+
+func (s *UsersService) ChangeCustomerGroup(id int, groupReq model.UserCustomerGroupBody) (*model.User, error) {
+	updatedUser, err := s.repo.ChangeCustomerGroup(s.ctx, id, groupReq)
+	if err != nil {
+		logger.GetLogger().Error("failed to change user customer group",
+			zap.Error(err),
+			zap.Int("user_id", id),
+		)
+		return nil, err
+	}
+	logger.GetLogger().Info("user customer group changed successfully",
+		zap.Int("user_id", id),
+	)
 	return updatedUser, nil
 }
 
@@ -197,5 +550,291 @@ func (s *UsersService) ChangePassword(id int, changePasswordReq model.UserChange
 	logger.GetLogger().Info("user password changed successfully",
 		zap.Int("user_id", id),
 	)
+	// Ошибка отзыва уже записана в лог и не отменяет смену пароля.
+	_ = s.revokeSessions(id)
 	return result, nil
 }
+
+// ForgotPassword отправляет на email учетной записи ссылку для сброса пароля.
+// Если учетной записи с таким email нет, ошибка не возвращается, чтобы по ответу
+// нельзя было узнать, зарегистрирован ли адрес.
+func (s *UsersService) ForgotPassword(forgotReq model.ForgotPasswordRequest) error {
+	user, err := s.repo.GetByEmail(s.ctx, strings.TrimSpace(forgotReq.Email))
+	if err != nil {
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			logger.GetLogger().Info("password reset requested for unknown email")
+			return nil
+		}
+		logger.GetLogger().Error("failed to get user by email",
+			zap.Error(err),
+		)
+		return errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+
+	token, hash, err := jwtgen.NewOpaqueToken()
+	if err != nil {
+		return errors.NewInternalError("ошибка генерации токена сброса пароля", err)
+	}
+	if err := s.resets.Create(s.ctx, user.ID, hash, time.Now().Add(s.auth.PasswordResetTTL)); err != nil {
+		logger.GetLogger().Error("failed to save password reset token",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return errors.NewDatabaseError("ошибка создания ссылки для сброса пароля", err)
+	}
+
+	link, err := tokenLink(s.auth.PasswordResetURL, token)
+	if err != nil {
+		return errors.NewInternalError("некорректный адрес страницы сброса пароля", err)
+	}
+
+	err = s.mail.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Сброс пароля",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nДля вашей учетной записи запрошен сброс пароля. "+
+				"Чтобы задать новый пароль, перейдите по ссылке:\n%s\n\n"+
+				"Ссылка действует до %s и может быть использована один раз. "+
+				"Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.",
+			user.FirstName, link, time.Now().Add(s.auth.PasswordResetTTL).Format("02.01.2006 15:04"),
+		),
+	})
+	if err != nil {
+		logger.GetLogger().Error("failed to send password reset email",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return errors.NewInternalError("ошибка отправки письма", err)
+	}
+	logger.GetLogger().Info("password reset email sent",
+		zap.Int("user_id", user.ID),
+	)
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по токену из письма и завершает все сессии пользователя.
+func (s *UsersService) ResetPassword(resetReq model.ResetPasswordRequest) (*model.Success, error) {
+	if resetReq.Password != resetReq.PasswordConfirm {
+		return nil, errors.NewValidationError("Ошибка подтверждения пароля", nil)
+	}
+	var user model.User
+	if err := user.HashPassword(resetReq.Password); err != nil {
+		return nil, errors.NewInternalError("ошибка при хешировании пароля", err)
+	}
+
+	userID, err := s.resets.Reset(s.ctx, jwtgen.HashToken(resetReq.Token), user.PasswordHash)
+	if err != nil {
+		logger.GetLogger().Error("failed to reset password",
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "ссылк") {
+			return nil, errors.NewValidationError(err.Error(), err)
+		}
+		return nil, errors.NewDatabaseError("ошибка при изменении пароля", err)
+	}
+	logger.GetLogger().Info("user password reset successfully",
+		zap.Int("user_id", userID),
+	)
+	// Ошибка отзыва уже записана в лог и не отменяет смену пароля.
+	_ = s.revokeSessions(userID)
+	return &model.Success{
+		Status:  "Success",
+		Message: "Пароль успешно изменен",
+	}, nil
+}
+
+// ResendEmailVerification повторно отправляет письмо для подтверждения email.
+func (s *UsersService) ResendEmailVerification(userID int) error {
+	user, err := s.repo.GetByID(s.ctx, userID)
+	if err != nil {
+		logger.GetLogger().Error("failed to get user for email verification",
+			zap.Error(err),
+			zap.Int("user_id", userID),
+		)
+		if strings.Contains(err.Error(), "пользователь не найден") {
+			return errors.NewNotFoundError("пользователь", err)
+		}
+		return errors.NewDatabaseError("ошибка получения пользователя", err)
+	}
+	if user.EmailVerified() {
+		return errors.NewValidationError("email уже подтвержден", nil)
+	}
+	return s.sendEmailVerification(user)
+}
+
+// VerifyEmail подтверждает email по токену из письма.
+func (s *UsersService) VerifyEmail(verifyReq model.VerifyEmailRequest) (*model.Success, error) {
+	userID, err := s.verifications.Verify(s.ctx, jwtgen.HashToken(verifyReq.Token))
+	if err != nil {
+		logger.GetLogger().Error("failed to verify email",
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "ссылк") {
+			return nil, errors.NewValidationError(err.Error(), err)
+		}
+		return nil, errors.NewDatabaseError("ошибка подтверждения email", err)
+	}
+	logger.GetLogger().Info("user email verified",
+		zap.Int("user_id", userID),
+	)
+	return &model.Success{
+		Status:  "Success",
+		Message: "Email успешно подтвержден",
+	}, nil
+}
+
+// sendEmailVerification отправляет письмо со ссылкой для подтверждения email. Частота
+// отправки ограничена, чтобы через регистрацию и смену адреса нельзя было рассылать спам.
+func (s *UsersService) sendEmailVerification(user *model.User) error {
+	now := time.Now()
+	recent, err := s.verifications.CountSince(s.ctx, user.ID, now.Add(-s.auth.VerificationResendInterval))
+	if err == nil && recent > 0 {
+		return errors.NewTooManyRequestsError("Письмо уже отправлено, повторите запрос позже", nil).
+			WithRetryAfter(s.auth.VerificationResendInterval)
+	}
+	var hourly int
+	if err == nil {
+		hourly, err = s.verifications.CountSince(s.ctx, user.ID, now.Add(-time.Hour))
+	}
+	if err != nil {
+		logger.GetLogger().Error("failed to count email verifications",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return errors.NewDatabaseError("ошибка проверки отправленных писем", err)
+	}
+	if hourly >= s.auth.VerificationHourlyLimit {
+		return errors.NewTooManyRequestsError("Превышено число писем для подтверждения email, повторите через час", nil).
+			WithRetryAfter(time.Hour)
+	}
+
+	token, hash, err := jwtgen.NewOpaqueToken()
+	if err != nil {
+		return errors.NewInternalError("ошибка генерации токена подтверждения email", err)
+	}
+	expiresAt := now.Add(s.auth.EmailVerificationTTL)
+	if err := s.verifications.Create(s.ctx, user.ID, user.Email, hash, expiresAt); err != nil {
+		logger.GetLogger().Error("failed to save email verification token",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return errors.NewDatabaseError("ошибка создания ссылки для подтверждения email", err)
+	}
+	link, err := tokenLink(s.auth.EmailVerificationURL, token)
+	if err != nil {
+		return errors.NewInternalError("некорректный адрес страницы подтверждения email", err)
+	}
+
+	err = s.mail.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Подтверждение email",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы подтвердить адрес электронной почты, перейдите по ссылке:\n%s\n\n"+
+				"Ссылка действует до %s. Пока адрес не подтвержден, оформление заказов недоступно.",
+			user.FirstName, link, expiresAt.Format("02.01.2006 15:04"),
+		),
+	})
+	if err != nil {
+		logger.GetLogger().Error("failed to send email verification",
+			zap.Error(err),
+			zap.Int("user_id", user.ID),
+		)
+		return errors.NewInternalError("ошибка отправки письма", err)
+	}
+	logger.GetLogger().Info("email verification sent",
+		zap.Int("user_id", user.ID),
+	)
+	return nil
+}
+
+// tokenLink добавляет токен параметром token к адресу страницы фронтенда.
+func tokenLink(pageURL, token string) (string, error) {
+	link, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// loginSubject то, по чему считаются неудачные попытки входа, и их допустимое число.
+type loginSubject struct {
+	key   string
+	limit int
+}
+
+// loginSubjects возвращает субъекты попытки входа: первым всегда идет логин,
+// за ним IP-адрес, если он известен.
+func (s *UsersService) loginSubjects(login, ip string) []loginSubject {
+	subjects := []loginSubject{{key: loginKey(login), limit: s.auth.LoginMaxAttempts}}
+	if ip != "" {
+		subjects = append(subjects, loginSubject{key: "ip:" + ip, limit: s.auth.LoginMaxAttemptsPerIP})
+	}
+	return subjects
+}
+
+func loginKey(login string) string {
+	return "login:" + strings.ToLower(strings.TrimSpace(login))
+}
+
+// registerLoginFailure учитывает неудачную попытку входа и блокирует субъекты, превысившие
+// порог. Возвращает ошибку с временем блокировки, если попытка привела к блокировке.
+func (s *UsersService) registerLoginFailure(subjects []loginSubject) error {
+	var lockedFor time.Duration
+	for _, subject := range subjects {
+		failures, err := s.attempts.RegisterFailure(s.ctx, subject.key, s.auth.LoginAttemptsWindow)
+		if err != nil {
+			logger.GetLogger().Error("failed to register login failure",
+				zap.Error(err),
+				zap.String("subject", subject.key),
+			)
+			continue
+		}
+		if failures < subject.limit {
+			continue
+		}
+		duration := s.lockoutDuration(failures - subject.limit)
+		if err := s.attempts.Lock(s.ctx, subject.key, duration); err != nil {
+			logger.GetLogger().Error("failed to lock login",
+				zap.Error(err),
+				zap.String("subject", subject.key),
+			)
+			continue
+		}
+		logger.GetLogger().Warn("login locked after failed attempts",
+			zap.String("subject", subject.key),
+			zap.Int("failures", failures),
+			zap.Duration("duration", duration),
+		)
+		lockout := model.LoginLockout{
+			Subject:     subject.key,
+			Failures:    failures,
+			LockedUntil: time.Now().Add(duration),
+		}
+		if _, logErr := s.repo.WriteLog(lockout, "Lockout", logErrorStatus, logUsersTableName); logErr != nil {
+			logger.GetLogger().Error("failed to write log for login lockout",
+				zap.Error(logErr),
+			)
+		}
+		lockedFor = max(lockedFor, duration)
+	}
+	if lockedFor > 0 {
+		return errors.NewTooManyRequestsError(
+			"Слишком много неудачных попыток входа, повторите позже",
+			nil,
+		).WithRetryAfter(lockedFor)
+	}
+	return nil
+}
+
+// lockoutDuration возвращает длительность блокировки: базовая длительность удваивается
+// с каждой неудачной попыткой сверх порога, но не превышает максимальную.
+func (s *UsersService) lockoutDuration(overLimit int) time.Duration {
+	duration := s.auth.LoginLockout
+	for i := 0; i < overLimit && duration < s.auth.LoginLockoutMax; i++ {
+		duration *= 2
+	}
+	return min(duration, s.auth.LoginLockoutMax)
+}
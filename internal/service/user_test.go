@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+	repoMocks "github.com/mikhailshtv/stockLkBack/internal/repository/mocks"
+	"github.com/mikhailshtv/stockLkBack/internal/utils/jwtgen"
+	apperrors "github.com/mikhailshtv/stockLkBack/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUsersService_lockoutDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		auth      config.Auth
+		overLimit int
+		want      time.Duration
+	}{
+		{
+			name:      "first lockout",
+			auth:      config.Auth{LoginLockout: time.Minute, LoginLockoutMax: time.Hour},
+			overLimit: 0,
+			want:      time.Minute,
+		},
+		{
+			name:      "doubles with each failure over the limit",
+			auth:      config.Auth{LoginLockout: time.Minute, LoginLockoutMax: time.Hour},
+			overLimit: 3,
+			want:      8 * time.Minute,
+		},
+		{
+			name:      "capped by the maximum",
+			auth:      config.Auth{LoginLockout: time.Minute, LoginLockoutMax: time.Hour},
+			overLimit: 10,
+			want:      time.Hour,
+		},
+		{
+			name:      "maximum is not a power of two of the base",
+			auth:      config.Auth{LoginLockout: time.Minute, LoginLockoutMax: 5 * time.Minute},
+			overLimit: 3,
+			want:      5 * time.Minute,
+		},
+		{
+			name:      "defaults",
+			auth:      config.Auth{},
+			overLimit: 1,
+			want:      2 * defaultLoginLockout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUsersService(context.Background(), nil, nil, nil, nil, nil, nil, nil, nil, nil, tt.auth)
+			if got := s.lockoutDuration(tt.overLimit); got != tt.want {
+				t.Errorf("lockoutDuration(%d) = %v, want %v", tt.overLimit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsersService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	tokensMock := repoMocks.NewMockRefreshToken(ctrl)
+
+	const refreshToken = "presented-refresh-token"
+	hash := jwtgen.HashToken(refreshToken)
+
+	tests := []struct {
+		name     string
+		rotate   error
+		wantType apperrors.ErrorType
+	}{
+		{
+			name:     "reused token revokes the session",
+			rotate:   errors.New("refresh-токен использован повторно, сессия отозвана"),
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name:     "unknown token",
+			rotate:   errors.New("refresh-токен недействителен"),
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name:     "revoked token",
+			rotate:   errors.New("refresh-токен отозван"),
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name:     "expired token",
+			rotate:   errors.New("срок действия refresh-токена истек"),
+			wantType: apperrors.ErrorTypeUnauthorized,
+		},
+		{
+			name:     "database failure",
+			rotate:   errors.New("ошибка получения refresh-токена: connection refused"),
+			wantType: apperrors.ErrorTypeDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUsersService(
+				context.Background(), nil, tokensMock, nil, nil, nil, nil, nil, nil, nil, config.Auth{},
+			)
+
+			// Предъявленный токен ищется по хешу, новый токен каждый раз другой.
+			tokensMock.EXPECT().Rotate(gomock.Any(), hash, gomock.Not(hash), gomock.Any()).Return(nil, tt.rotate)
+
+			_, err := s.Refresh(model.RefreshTokenRequest{RefreshToken: refreshToken})
+			appErr, ok := apperrors.IsAppError(err)
+			if !ok || appErr.Type != tt.wantType {
+				t.Errorf("Ошибка обновления токена error = %v, want type %s", err, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+)
+
+// DeliveryNote формирует товарную накладную по образцу формы ТОРГ-12.
+func DeliveryNote(data Data) ([]byte, error) {
+	pdf := newPDF("L")
+	seller := data.Seller
+	order := data.Order
+	sellerDetails := fmt.Sprintf("%s, ИНН %s, КПП %s, %s, р/с %s в %s, БИК %s, к/с %s",
+		seller.Name, seller.INN, seller.KPP, seller.Address,
+		seller.Account, seller.BankName, seller.BIC, seller.CorrAccount)
+	clientDetails := fmt.Sprintf("%s, %s", clientName(data.Client), data.Client.Email)
+
+	pdf.SetFont(fontFamily, "", 7)
+	pdf.CellFormat(0, 4, "Унифицированная форма № ТОРГ-12", "", 1, "R", false, 0, "")
+	pdf.Ln(2)
+
+	party := func(title, details string) {
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.CellFormat(40, 5, title, "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, 5, details, "B", "L", false)
+		pdf.Ln(1)
+	}
+	party("Грузоотправитель", sellerDetails)
+	consigneeDetails := clientDetails
+	if order.ShippingAddress != nil {
+		consigneeDetails += ", " + *order.ShippingAddress
+	}
+	party("Грузополучатель", consigneeDetails)
+	party("Поставщик", sellerDetails)
+	party("Плательщик", clientDetails)
+	party("Основание", fmt.Sprintf("Заказ № %d от %s", order.Number, order.CreatedDate.Format("02.01.2006")))
+	if order.Carrier != nil && order.TrackingNumber != nil {
+		party("Перевозчик", fmt.Sprintf("%s, трек-номер %s", *order.Carrier, *order.TrackingNumber))
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "B", 12)
+	pdf.CellFormat(0, 8, fmt.Sprintf("ТОВАРНАЯ НАКЛАДНАЯ № %d от %s",
+		order.Number, order.LastModifiedDate.Format("02.01.2006")), "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	widths := []float64{10, 80, 20, 15, 22, 25, 28, 17, 25, 35}
+	aligns := []string{"C", "L", "C", "C", "R", "R", "R", "C", "R", "R"}
+	pdf.SetFont(fontFamily, "B", 8)
+	row(pdf, widths, []string{"C", "C", "C", "C", "C", "C", "C", "C", "C", "C"}, []string{
+		"№", "Товар", "Код", "Ед. изм.", "Количество", "Цена без НДС",
+		"Сумма без НДС", "Ставка НДС", "Сумма НДС", "Сумма с НДС",
+	})
+	pdf.SetFont(fontFamily, "", 8)
+
+	var totalNoVAT, totalVAT, totalTax int64
+	for i, l := range lines(data) {
+		price := int64(0)
+		if l.product.Quantity > 0 {
+			price = l.netNoVAT * int64(model.NewQuantity(1)) / int64(l.product.Quantity)
+		}
+		row(pdf, widths, aligns, []string{
+			strconv.Itoa(i + 1),
+			l.product.Name,
+			strconv.Itoa(int(l.product.Code)),
+			l.unit,
+			formatQuantity(l.product.Quantity),
+			formatAmount(price, order.Currency),
+			formatAmount(l.netNoVAT, order.Currency),
+			l.vatRate,
+			formatAmount(l.taxAmount, order.Currency),
+			formatAmount(l.netVAT, order.Currency),
+		})
+		totalNoVAT += l.netNoVAT
+		totalVAT += l.netVAT
+		totalTax += l.taxAmount
+	}
+
+	pdf.SetFont(fontFamily, "B", 8)
+	row(pdf, widths, aligns, []string{
+		"", "Итого", "", "", "", "",
+		formatAmount(totalNoVAT, order.Currency),
+		"",
+		formatAmount(totalTax, order.Currency),
+		formatAmount(totalVAT, order.Currency),
+	})
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "", 9)
+	total := fmt.Sprintf("Всего отпущено на сумму %s %s", formatAmount(totalVAT, order.Currency), order.Currency)
+	if order.Currency == "RUB" {
+		total += " (" + rublesInWords(totalVAT) + ")"
+	}
+	pdf.MultiCell(0, 5, total, "", "L", false)
+	pdf.Ln(8)
+
+	signature(pdf, "Отпуск груза разрешил", seller.Director)
+	signature(pdf, "Главный бухгалтер", seller.Accountant)
+	signature(pdf, "Груз принял", clientName(data.Client))
+
+	return output(pdf)
+}
@@ -0,0 +1,172 @@
+// Package document формирует печатные формы заказа (счет на оплату, товарная накладная) в PDF.
+package document
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Шрифты DejaVu встраиваются в бинарник: стандартные шрифты PDF не содержат кириллицы.
+var (
+	//go:embed fonts/DejaVuSans.ttf
+	fontRegular []byte
+	//go:embed fonts/DejaVuSans-Bold.ttf
+	fontBold []byte
+)
+
+const fontFamily = "DejaVu"
+
+// Data данные для печатной формы заказа.
+type Data struct {
+	Seller config.Seller
+	Order  model.Order
+	Client model.User
+	// Units наименования единиц измерения по коду (pcs -> шт).
+	Units map[string]string
+}
+
+// line строка документа с суммами, рассчитанными по строке заказа.
+type line struct {
+	product   model.Product
+	unit      string
+	gross     int64 // Стоимость по цене без скидок
+	net       int64 // Стоимость с учетом всех скидок
+	netNoVAT  int64 // Стоимость без НДС
+	netVAT    int64 // Стоимость с НДС
+	vatRate   string
+	taxAmount int64
+}
+
+func newPDF(orientation string) *gofpdf.Fpdf {
+	pdf := gofpdf.New(orientation, "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", fontRegular)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", fontBold)
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.AddPage()
+	return pdf
+}
+
+func output(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("ошибка формирования PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// lines рассчитывает суммы строк документа. Сумма строки с учетом всех скидок
+// хранится в заказе как база НДС.
+func lines(data Data) []line {
+	result := make([]line, 0, len(data.Order.Products))
+	for _, p := range data.Order.Products {
+		l := line{
+			product:   p,
+			unit:      unitName(data.Units, p.UnitCode),
+			gross:     lineCost(p.Quantity, p.SellPrice),
+			net:       p.TaxBase,
+			taxAmount: p.TaxAmount,
+			vatRate:   "без НДС",
+		}
+		if p.VATRate != nil && *p.VATRate > 0 {
+			l.vatRate = fmt.Sprintf("%d%%", *p.VATRate)
+		}
+		if data.Order.TaxMode == model.TaxExclusive {
+			l.netNoVAT = l.net
+			l.netVAT = l.net + l.taxAmount
+		} else {
+			l.netNoVAT = l.net - l.taxAmount
+			l.netVAT = l.net
+		}
+		result = append(result, l)
+	}
+	return result
+}
+
+// lineCost стоимость количества товара, округленная до минимальной единицы валюты.
+func lineCost(quantity model.Quantity, price int64) int64 {
+	one := int64(model.NewQuantity(1))
+	return (int64(quantity)*price + one/2) / one
+}
+
+func unitName(units map[string]string, code string) string {
+	if name, ok := units[code]; ok {
+		return name
+	}
+	return code
+}
+
+// formatAmount форматирует сумму для печатной формы: 123456 -> "1 234,56".
+func formatAmount(amount int64, currency string) string {
+	s := model.NewMoney(amount, currency).FormatAmount()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteString("," + fracPart)
+	}
+	return sign + b.String()
+}
+
+func formatQuantity(q model.Quantity) string {
+	return strings.Replace(q.String(), ".", ",", 1)
+}
+
+func clientName(user model.User) string {
+	return strings.TrimSpace(user.LastName + " " + user.FirstName)
+}
+
+// row выводит строку таблицы; высота строки подбирается по самой длинной ячейке.
+func row(pdf *gofpdf.Fpdf, widths []float64, aligns []string, values []string) {
+	const lineHeight = 5.0
+	maxLines := 1
+	for i, v := range values {
+		if n := len(pdf.SplitText(v, widths[i]-2)); n > maxLines {
+			maxLines = n
+		}
+	}
+	height := float64(maxLines) * lineHeight
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+height > pageHeight-bottom {
+		pdf.AddPage()
+	}
+
+	x, y := pdf.GetXY()
+	for i, v := range values {
+		pdf.Rect(x, y, widths[i], height, "D")
+		pdf.SetXY(x, y)
+		pdf.MultiCell(widths[i], lineHeight, v, "", aligns[i], false)
+		x += widths[i]
+	}
+	pdf.SetXY(pdf.GetX(), y+height)
+	left, _, _, _ := pdf.GetMargins()
+	pdf.SetX(left)
+}
+
+// signature выводит строку подписи: должность, линия и расшифровка.
+func signature(pdf *gofpdf.Fpdf, title, name string) {
+	pdf.CellFormat(45, 8, title, "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 8, "", "B", 0, "L", false, 0, "")
+	pdf.CellFormat(5, 8, "", "", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 8, name, "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
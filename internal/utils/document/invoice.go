@@ -0,0 +1,101 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mikhailshtv/stockLkBack/internal/model"
+)
+
+// Invoice формирует счет на оплату заказа.
+func Invoice(data Data) ([]byte, error) {
+	pdf := newPDF("P")
+	seller := data.Seller
+	order := data.Order
+
+	// Банковские реквизиты получателя
+	pdf.CellFormat(110, 6, seller.BankName, "LTR", 0, "L", false, 0, "")
+	pdf.CellFormat(20, 6, "БИК", "LTR", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, seller.BIC, "LTR", 1, "L", false, 0, "")
+	pdf.CellFormat(110, 6, "Банк получателя", "LBR", 0, "L", false, 0, "")
+	pdf.CellFormat(20, 6, "Сч. №", "LBR", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, seller.CorrAccount, "LBR", 1, "L", false, 0, "")
+	pdf.CellFormat(55, 6, "ИНН "+seller.INN, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(55, 6, "КПП "+seller.KPP, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(20, 6, "Сч. №", "LTR", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, seller.Account, "LTR", 1, "L", false, 0, "")
+	pdf.CellFormat(110, 6, seller.Name, "LR", 0, "L", false, 0, "")
+	pdf.CellFormat(20, 6, "", "LR", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, "", "LR", 1, "L", false, 0, "")
+	pdf.CellFormat(110, 6, "Получатель", "LBR", 0, "L", false, 0, "")
+	pdf.CellFormat(20, 6, "", "LBR", 0, "L", false, 0, "")
+	pdf.CellFormat(50, 6, "", "LBR", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetFont(fontFamily, "B", 14)
+	pdf.CellFormat(0, 8, fmt.Sprintf("Счет на оплату № %d от %s",
+		order.Number, order.CreatedDate.Format("02.01.2006")), "B", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.CellFormat(30, 5, "Поставщик:", "", 0, "L", false, 0, "")
+	pdf.MultiCell(0, 5, fmt.Sprintf("%s, ИНН %s, КПП %s, %s",
+		seller.Name, seller.INN, seller.KPP, seller.Address), "", "L", false)
+	pdf.Ln(1)
+	pdf.CellFormat(30, 5, "Покупатель:", "", 0, "L", false, 0, "")
+	pdf.MultiCell(0, 5, fmt.Sprintf("%s, %s", clientName(data.Client), data.Client.Email), "", "L", false)
+	pdf.Ln(4)
+
+	widths := []float64{8, 64, 18, 12, 26, 22, 30}
+	aligns := []string{"C", "L", "R", "C", "R", "R", "R"}
+	pdf.SetFont(fontFamily, "B", 9)
+	row(pdf, widths, []string{"C", "C", "C", "C", "C", "C", "C"},
+		[]string{"№", "Товар", "Кол-во", "Ед.", "Цена", "Скидка", "Сумма"})
+	pdf.SetFont(fontFamily, "", 9)
+
+	docLines := lines(data)
+	for i, l := range docLines {
+		row(pdf, widths, aligns, []string{
+			strconv.Itoa(i + 1),
+			l.product.Name,
+			formatQuantity(l.product.Quantity),
+			l.unit,
+			formatAmount(l.product.SellPrice, order.Currency),
+			formatAmount(l.gross-l.net, order.Currency),
+			formatAmount(l.net, order.Currency),
+		})
+	}
+	pdf.Ln(2)
+
+	total := func(title string, amount int64) {
+		pdf.CellFormat(150, 6, title, "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 6, formatAmount(amount, order.Currency), "", 1, "R", false, 0, "")
+	}
+	total("Итого:", order.Subtotal-order.DiscountTotal)
+	if order.TaxMode == model.TaxExclusive {
+		total("НДС:", order.TaxTotal)
+	} else {
+		total("В том числе НДС:", order.TaxTotal)
+	}
+	if order.ShippingCost > 0 {
+		total("Доставка:", order.ShippingCost)
+	}
+	pdf.SetFont(fontFamily, "B", 9)
+	total("Всего к оплате:", order.TotalCost)
+	pdf.Ln(2)
+
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.MultiCell(0, 5, fmt.Sprintf("Всего наименований %d, на сумму %s %s",
+		len(docLines), formatAmount(order.TotalCost, order.Currency), order.Currency), "", "L", false)
+	if order.Currency == "RUB" {
+		pdf.SetFont(fontFamily, "B", 9)
+		pdf.MultiCell(0, 5, rublesInWords(order.TotalCost), "", "L", false)
+	}
+	pdf.Ln(10)
+
+	pdf.SetFont(fontFamily, "", 9)
+	signature(pdf, "Руководитель", seller.Director)
+	signature(pdf, "Бухгалтер", seller.Accountant)
+
+	return output(pdf)
+}
@@ -0,0 +1,122 @@
+package document
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	unitsMale   = []string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	unitsFemale = []string{"", "одна", "две", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	teens       = []string{
+		"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать",
+		"пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать",
+	}
+	tens = []string{
+		"", "", "двадцать", "тридцать", "сорок", "пятьдесят",
+		"шестьдесят", "семьдесят", "восемьдесят", "девяносто",
+	}
+	hundreds = []string{
+		"", "сто", "двести", "триста", "четыреста", "пятьсот",
+		"шестьсот", "семьсот", "восемьсот", "девятьсот",
+	}
+)
+
+// scale разряд числа: формы для 1, 2-4 и 5+ и род.
+type scale struct {
+	forms  [3]string
+	female bool
+}
+
+var scales = []scale{
+	{forms: [3]string{"", "", ""}},
+	{forms: [3]string{"тысяча", "тысячи", "тысяч"}, female: true},
+	{forms: [3]string{"миллион", "миллиона", "миллионов"}},
+	{forms: [3]string{"миллиард", "миллиарда", "миллиардов"}},
+	{forms: [3]string{"триллион", "триллиона", "триллионов"}},
+	{forms: [3]string{"квадриллион", "квадриллиона", "квадриллионов"}},
+}
+
+// plural выбирает форму слова для числа: 1 рубль, 2 рубля, 5 рублей.
+func plural(n int64, forms [3]string) string {
+	n %= 100
+	if n >= 11 && n <= 19 {
+		return forms[2]
+	}
+	switch n % 10 {
+	case 1:
+		return forms[0]
+	case 2, 3, 4:
+		return forms[1]
+	default:
+		return forms[2]
+	}
+}
+
+func triadInWords(n int64, female bool) []string {
+	words := []string{}
+	if h := n / 100; h > 0 {
+		words = append(words, hundreds[h])
+	}
+	rest := n % 100
+	switch {
+	case rest >= 10 && rest <= 19:
+		words = append(words, teens[rest-10])
+	default:
+		if t := rest / 10; t > 0 {
+			words = append(words, tens[t])
+		}
+		if u := rest % 10; u > 0 {
+			if female {
+				words = append(words, unitsFemale[u])
+			} else {
+				words = append(words, unitsMale[u])
+			}
+		}
+	}
+	return words
+}
+
+// numberInWords записывает целое неотрицательное число прописью.
+func numberInWords(n int64, female bool) string {
+	if n == 0 {
+		return "ноль"
+	}
+	triads := []int64{}
+	for n > 0 {
+		triads = append(triads, n%1000)
+		n /= 1000
+	}
+
+	words := []string{}
+	for i := len(triads) - 1; i >= 0; i-- {
+		if triads[i] == 0 {
+			continue
+		}
+		isFemale := scales[i].female || (i == 0 && female)
+		words = append(words, triadInWords(triads[i], isFemale)...)
+		if i > 0 {
+			words = append(words, plural(triads[i], scales[i].forms))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// rublesInWords записывает сумму в копейках прописью: "Сто двадцать три рубля 45 копеек".
+func rublesInWords(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "минус "
+		amount = -amount
+	}
+	rubles, kopecks := amount/100, amount%100
+	text := fmt.Sprintf("%s%s %s %02d %s",
+		sign,
+		numberInWords(rubles, false),
+		plural(rubles, [3]string{"рубль", "рубля", "рублей"}),
+		kopecks,
+		plural(kopecks, [3]string{"копейка", "копейки", "копеек"}),
+	)
+	runes := []rune(text)
+	return strings.ToUpper(string(runes[0])) + string(runes[1:])
+}
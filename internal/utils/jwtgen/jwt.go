@@ -1,7 +1,12 @@
 package jwtgen
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/mikhailshtv/stockLkBack/internal/model"
@@ -11,43 +16,74 @@ import (
 
 // Структуры для пользователя и токенов.
 var (
-	secretKey               = []byte("2k0935h84j39k2ks9df8h4fj3dk2s02kj9f8h4g5")
+	ErrNotConfigured        = errors.New("jwt keys are not configured")
 	ErrInvalidSigningMethod = errors.New("invalid signing method")
 	ErrInvalidToken         = errors.New("invalid token")
+	ErrRevokedToken         = errors.New("token revoked")
 )
 
-// GenerateToken Функция для создания JWT токена.
-func GenerateToken(userID int, login string, role model.UserRole) (string, error) {
-	// Устанавливаем срок действия токена (1 час)
-	expirationTime := time.Now().Add(1 * time.Hour)
+// GenerateToken Функция для создания JWT токена со сроком действия ttl.
+// version текущая версия токенов пользователя, см. model.Claims.
+func GenerateToken(userID int, login string, role model.UserRole, version int, ttl time.Duration) (string, error) {
+	if keys == nil {
+		return "", ErrNotConfigured
+	}
+
+	// Устанавливаем срок действия токена
+	now := time.Now()
+	expirationTime := now.Add(ttl)
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
 
 	// Создаем JWT токен
 	claims := &model.Claims{
-		Login:  login,
-		Role:   role,
-		UserID: userID,
+		Login:        login,
+		Role:         role,
+		UserID:       userID,
+		TokenVersion: version,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			IssuedAt:  &jwt.NumericDate{Time: now},
 			ExpiresAt: &jwt.NumericDate{Time: expirationTime},
-			Issuer:    "go-gin-jwt-example",
+			Issuer:    keys.issuer,
 		},
 	}
 
-	// Создаем JWT токен и шифруем его методом HS256
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// Создаем JWT токен и подписываем его текущим ключом, kid указывает ключ для проверки
+	token := jwt.NewWithClaims(keys.signing.method, claims)
+	token.Header["kid"] = keys.signing.id
 
-	// Подписываем токен секретным ключом
-	return token.SignedString(secretKey)
+	return token.SignedString(keys.signing.signKey)
 }
 
 func ParseToken(tokenString string) (*model.Claims, error) {
+	if keys == nil {
+		return nil, ErrNotConfigured
+	}
+
+	options := []jwt.ParserOption{}
+	if keys.issuer != "" {
+		options = append(options, jwt.WithIssuer(keys.issuer))
+	}
+
 	// Проверяем и парсим токен
 	token, err := jwt.ParseWithClaims(tokenString, &model.Claims{}, func(token *jwt.Token) (interface{}, error) {
+		// Токены без kid выпущены до появления ротации и проверяются текущим ключом
+		key := keys.signing
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok = keys.keys[kid]; !ok {
+				return nil, ErrInvalidToken
+			}
+		}
 		// Проверка алгоритма подписи
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, ErrInvalidSigningMethod
 		}
-		return secretKey, nil
-	})
+		return key.verifyKey, nil
+	}, options...)
 
 	// Если у нас произошла ошибка или токен не валиден, то вернем ошибку
 	if err != nil || !token.Valid {
@@ -60,3 +96,66 @@ func ParseToken(tokenString string) (*model.Claims, error) {
 
 	return nil, ErrInvalidToken
 }
+
+// NewOpaqueToken создает случайный непрозрачный токен и возвращает его вместе с хешем для хранения.
+// Так устроены refresh-токены, токены сброса пароля, подтверждения email и второго шага входа:
+// в базе хранится только SHA-256, сам токен знает лишь его получатель.
+func NewOpaqueToken() (token, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(bytes)
+	return token, HashToken(token), nil
+}
+
+// HashToken возвращает хеш токена или ключа API, по которому он ищется в базе.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRecoveryCode возвращает код восстановления двухфакторной аутентификации вида
+// xxxxx-xxxxx и его хеш. Код вводится вручную, поэтому он короче токенов.
+func NewRecoveryCode() (code, hash string, err error) {
+	bytes := make([]byte, 5)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	raw := hex.EncodeToString(bytes)
+	code = raw[:5] + "-" + raw[5:]
+	return code, HashRecoveryCode(code), nil
+}
+
+// HashRecoveryCode возвращает хеш кода восстановления. Регистр, дефисы и пробелы не учитываются.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashToken(normalized)
+}
+
+// APIKeyPrefix начало всех ключей API, по нему ключ легко узнать в конфигурации и логах.
+const APIKeyPrefix = "slk_"
+
+// NewAPIKey возвращает ключ API вида slk_<8 символов>_<секрет>, его префикс slk_<8 символов>,
+// который хранится открыто для опознания ключа, и хеш ключа для хранения в базе.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", err
+	}
+	secret, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+	prefix = APIKeyPrefix + hex.EncodeToString(id)
+	key = prefix + "_" + secret
+	return key, prefix, HashToken(key), nil
+}
+
+func newTokenID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,90 @@
+package jwtgen
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewRecoveryCode(t *testing.T) {
+	codePattern := regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`)
+	seen := make(map[string]struct{})
+	for range 20 {
+		code, hash, err := NewRecoveryCode()
+		if err != nil {
+			t.Fatalf("Ошибка генерации кода восстановления: %v", err)
+		}
+		if !codePattern.MatchString(code) {
+			t.Errorf("Код восстановления %q не соответствует формату xxxxx-xxxxx", code)
+		}
+		if hash != HashRecoveryCode(code) {
+			t.Errorf("Хеш кода %q не совпадает с HashRecoveryCode", code)
+		}
+		if _, ok := seen[code]; ok {
+			t.Errorf("Код восстановления %q выдан повторно", code)
+		}
+		seen[code] = struct{}{}
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-12345")
+
+	tests := []struct {
+		name  string
+		code  string
+		match bool
+	}{
+		{name: "as issued", code: "abcde-12345", match: true},
+		{name: "upper case", code: "ABCDE-12345", match: true},
+		{name: "without dash", code: "abcde12345", match: true},
+		{name: "with spaces", code: "abcde 12345 ", match: true},
+		{name: "another code", code: "abcde-12346", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashRecoveryCode(tt.code) == want; got != tt.match {
+				t.Errorf("HashRecoveryCode(%q) совпадает = %v, want %v", tt.code, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestNewOpaqueToken(t *testing.T) {
+	token, hash, err := NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("Ошибка генерации токена: %v", err)
+	}
+	if hash != HashToken(token) {
+		t.Errorf("Хеш токена не совпадает с HashToken")
+	}
+	other, _, err := NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("Ошибка генерации токена: %v", err)
+	}
+	if token == other {
+		t.Errorf("Два токена совпали")
+	}
+}
+
+func TestValidateSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "random secret", secret: "0f3c9a7e5b1d2468ace0f3c9a7e5b1d2", wantErr: false},
+		{name: "empty", secret: "", wantErr: true},
+		{name: "placeholder", secret: "change-me", wantErr: true},
+		{name: "long placeholder", secret: "CHANGE-ME-before-deploying-to-production", wantErr: true},
+		{name: "too short", secret: "0f3c9a7e5b1d2468ace0f3c9a7e5b1d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSecret(tt.secret); (err != nil) != tt.wantErr {
+				t.Errorf("validateSecret(%q) error = %v, wantErr %v", tt.secret, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package jwtgen
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minSecretLength минимальная длина секрета HS256 в байтах: секрет короче размера
+// хеша SHA-256 ослабляет подпись.
+const minSecretLength = 32
+
+// placeholderSecret заглушка из примеров конфигурации, с которой сервис не запускается.
+const placeholderSecret = "change-me"
+
+// signingKey ключ из конфигурации. Для HS256 signKey и verifyKey совпадают,
+// у ключа, предназначенного только для проверки подписи, signKey пуст.
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+// keySet ключи, с которыми выпускаются и проверяются токены.
+type keySet struct {
+	issuer  string
+	signing *signingKey
+	keys    map[string]*signingKey
+}
+
+var keys *keySet
+
+// Configure загружает ключи подписи из конфигурации. Вызывается один раз при старте приложения.
+func Configure(cfg config.Auth) error {
+	set := &keySet{
+		issuer: cfg.Issuer,
+		keys:   make(map[string]*signingKey, len(cfg.Keys)),
+	}
+	for _, keyCfg := range cfg.Keys {
+		if keyCfg.ID == "" {
+			return fmt.Errorf("у ключа JWT не задан id")
+		}
+		if _, ok := set.keys[keyCfg.ID]; ok {
+			return fmt.Errorf("ключ JWT %s указан дважды", keyCfg.ID)
+		}
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return fmt.Errorf("ключ JWT %s: %w", keyCfg.ID, err)
+		}
+		set.keys[key.id] = key
+	}
+
+	signing, ok := set.keys[cfg.SigningKeyID]
+	if !ok {
+		return fmt.Errorf("ключ подписи JWT %q не найден среди ключей", cfg.SigningKeyID)
+	}
+	if signing.signKey == nil {
+		return fmt.Errorf("для ключа подписи JWT %s не задан закрытый ключ", signing.id)
+	}
+	set.signing = signing
+	keys = set
+	return nil
+}
+
+// validateSecret отклоняет пустой, короткий и оставленный из примера секрет.
+func validateSecret(secret string) error {
+	switch {
+	case secret == "":
+		return fmt.Errorf("секрет не задан")
+	case strings.Contains(strings.ToLower(secret), placeholderSecret):
+		return fmt.Errorf("секрет не заменен после примера конфигурации")
+	case len(secret) < minSecretLength:
+		return fmt.Errorf("секрет короче %d байт", minSecretLength)
+	}
+	return nil
+}
+
+func loadKey(keyCfg config.JWTKey) (*signingKey, error) {
+	key := &signingKey{id: keyCfg.ID}
+	switch keyCfg.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		secret := os.Getenv(keyCfg.SecretEnvKey)
+		if err := validateSecret(secret); err != nil {
+			return nil, fmt.Errorf("переменная окружения %q: %w", keyCfg.SecretEnvKey, err)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(secret)
+		key.verifyKey = key.signKey
+	case jwt.SigningMethodRS256.Alg():
+		key.method = jwt.SigningMethodRS256
+		if keyCfg.PrivateKeyFile != "" {
+			privateKey, err := readPEM(keyCfg.PrivateKeyFile, func(data []byte) (any, error) {
+				return jwt.ParseRSAPrivateKeyFromPEM(data)
+			})
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.(*rsa.PrivateKey).PublicKey
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		key.method = jwt.SigningMethodEdDSA
+		if keyCfg.PrivateKeyFile != "" {
+			privateKey, err := readPEM(keyCfg.PrivateKeyFile, func(data []byte) (any, error) {
+				return jwt.ParseEdPrivateKeyFromPEM(data)
+			})
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+	default:
+		return nil, fmt.Errorf("неподдерживаемый алгоритм %q, допустимы HS256, RS256 и EdDSA", keyCfg.Algorithm)
+	}
+
+	if key.verifyKey == nil {
+		if keyCfg.PublicKeyFile == "" {
+			return nil, fmt.Errorf("не задан ни закрытый, ни открытый ключ")
+		}
+		publicKey, err := readPEM(keyCfg.PublicKeyFile, func(data []byte) (any, error) {
+			if key.method == jwt.SigningMethodRS256 {
+				return jwt.ParseRSAPublicKeyFromPEM(data)
+			}
+			return jwt.ParseEdPublicKeyFromPEM(data)
+		})
+		if err != nil {
+			return nil, err
+		}
+		key.verifyKey = publicKey
+	}
+	return key, nil
+}
+
+func readPEM(path string, parse func(data []byte) (any, error)) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла ключа: %w", err)
+	}
+	key, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора файла ключа %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// JWK открытый ключ в формате RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet набор открытых ключей для /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys возвращает открытые ключи, которыми другие сервисы могут проверять токены.
+// Симметричные ключи HS256 не публикуются.
+func PublicKeys() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	if keys == nil {
+		return set
+	}
+	for _, key := range keys.keys {
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -0,0 +1,151 @@
+// Package mailer отправляет письма пользователям: через SMTP-сервер или, при разработке
+// и в тестах, в каталог с файлами либо в консоль.
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mikhailshtv/stockLkBack/config"
+)
+
+const (
+	KindSMTP    = "smtp"
+	KindFile    = "file"
+	KindConsole = "console"
+)
+
+// Message текстовое письмо одному получателю.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New создает отправителя писем по конфигурации. По умолчанию письма выводятся в консоль.
+func New(cfg config.Mail) (Mailer, error) {
+	switch cfg.Kind {
+	case KindSMTP:
+		if cfg.Host == "" || cfg.Port == "" {
+			return nil, fmt.Errorf("не указан адрес SMTP-сервера")
+		}
+		return NewSMTPMailer(
+			net.JoinHostPort(cfg.Host, cfg.Port),
+			os.Getenv(cfg.UserEnvKey),
+			os.Getenv(cfg.PassEnvKey),
+			cfg.From,
+		), nil
+	case KindFile:
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("не указан каталог для писем")
+		}
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			return nil, fmt.Errorf("ошибка создания каталога для писем: %w", err)
+		}
+		return NewFileMailer(cfg.Dir, cfg.From), nil
+	case KindConsole, "":
+		return NewWriterMailer(os.Stdout, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("неизвестный способ отправки писем: %s", cfg.Kind)
+	}
+}
+
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer создает отправителя через SMTP-сервер addr. Если user пуст, сервер
+// используется без аутентификации.
+func NewSMTPMailer(addr, user, password, from string) *SMTPMailer {
+	m := &SMTPMailer{addr: addr, from: from}
+	if user != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		m.auth = smtp.PlainAuth("", user, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, format(m.from, msg)); err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	return nil
+}
+
+// FileMailer сохраняет каждое письмо в отдельный файл .eml в каталоге dir.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	file, err := os.CreateTemp(m.dir, time.Now().Format("20060102-150405")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла письма: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(format(m.from, msg)); err != nil {
+		return fmt.Errorf("ошибка записи письма: %w", err)
+	}
+	return nil
+}
+
+// WriterMailer выводит письма в w как есть, без кодирования, чтобы их было удобно читать.
+type WriterMailer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewWriterMailer(w io.Writer, from string) *WriterMailer {
+	return &WriterMailer{w: w, from: from}
+}
+
+func (m *WriterMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := fmt.Fprintf(m.w, "From: %s\nTo: %s\nSubject: %s\n\n%s\n\n", m.from, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("ошибка вывода письма: %w", err)
+	}
+	return nil
+}
+
+// format собирает письмо в формате RFC 5322. Тема и текст кодируются, так как содержат кириллицу.
+func format(from string, msg Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	const lineLength = 76
+	for len(body) > lineLength {
+		buf.WriteString(body[:lineLength] + "\r\n")
+		body = body[lineLength:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes()
+}
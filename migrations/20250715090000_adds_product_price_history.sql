@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS products.price_history (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE CASCADE,
+    purchase_price INTEGER NOT NULL CHECK (purchase_price >= 0),
+    sell_price INTEGER NOT NULL CHECK (sell_price >= 0),
+    effective_from TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by INTEGER REFERENCES users.users(id) ON DELETE SET NULL,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_product ON products.price_history(product_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_price_history_pending ON products.price_history(effective_from) WHERE NOT applied;
+
+-- Текущие цены считаем первой записью истории.
+INSERT INTO products.price_history (product_id, purchase_price, sell_price, applied)
+SELECT id, purchase_price, sell_price, TRUE FROM products.products;
+
+COMMENT ON TABLE products.price_history IS 'История изменения цен товаров, в том числе запланированные изменения';
+COMMENT ON COLUMN products.price_history.effective_from IS 'Момент, с которого действуют цены';
+COMMENT ON COLUMN products.price_history.created_by IS 'Пользователь, внесший изменение';
+COMMENT ON COLUMN products.price_history.applied IS 'Признак применения цен к товару';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS products.price_history;
+-- +goose StatementEnd
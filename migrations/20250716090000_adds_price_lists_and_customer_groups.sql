@@ -0,0 +1,89 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS users.customer_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE
+);
+
+ALTER TABLE users.users
+ADD COLUMN customer_group_id INTEGER REFERENCES users.customer_groups(id) ON DELETE SET NULL;
+
+CREATE SCHEMA IF NOT EXISTS pricing;
+
+CREATE TABLE IF NOT EXISTS pricing.price_lists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    valid_from TIMESTAMPTZ,
+    valid_to TIMESTAMPTZ,
+    priority INTEGER NOT NULL DEFAULT 0,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (valid_from IS NULL OR valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS pricing.price_list_items (
+    price_list_id INTEGER NOT NULL REFERENCES pricing.price_lists(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE CASCADE,
+    price INTEGER NOT NULL CHECK (price >= 0),
+
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS pricing.price_list_assignments (
+    id SERIAL PRIMARY KEY,
+    price_list_id INTEGER NOT NULL REFERENCES pricing.price_lists(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users.users(id) ON DELETE CASCADE,
+    customer_group_id INTEGER REFERENCES users.customer_groups(id) ON DELETE CASCADE,
+    CHECK ((user_id IS NULL) <> (customer_group_id IS NULL)),
+    UNIQUE (price_list_id, user_id),
+    UNIQUE (price_list_id, customer_group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_list_items_product ON pricing.price_list_items(product_id);
+CREATE INDEX IF NOT EXISTS idx_price_list_assignments_user ON pricing.price_list_assignments(user_id);
+CREATE INDEX IF NOT EXISTS idx_price_list_assignments_group ON pricing.price_list_assignments(customer_group_id);
+
+-- Эффективная цена товара для пользователя: прайс-лист, назначенный пользователю напрямую,
+-- важнее прайс-листа его группы, далее по приоритету. Если подходящего прайс-листа нет — базовая цена товара.
+CREATE OR REPLACE FUNCTION pricing.effective_price(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(
+        (
+            SELECT pli.price
+            FROM pricing.price_list_items pli
+            JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+            JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+            LEFT JOIN users.users u ON u.id = p_user_id
+            WHERE pli.product_id = p_product_id
+            AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+            AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+            AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+            ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+            LIMIT 1
+        ),
+        (SELECT sell_price FROM products.products WHERE id = p_product_id)
+    );
+$$ LANGUAGE sql STABLE;
+
+COMMENT ON SCHEMA pricing IS 'Схема для хранения прайс-листов';
+COMMENT ON TABLE users.customer_groups IS 'Группы клиентов (например, опт и розница)';
+COMMENT ON TABLE pricing.price_lists IS 'Прайс-листы с периодом действия';
+COMMENT ON TABLE pricing.price_list_items IS 'Цены товаров в прайс-листе';
+COMMENT ON TABLE pricing.price_list_assignments IS 'Назначение прайс-листа пользователю или группе клиентов';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP FUNCTION IF EXISTS pricing.effective_price;
+DROP TABLE IF EXISTS pricing.price_list_assignments;
+DROP TABLE IF EXISTS pricing.price_list_items;
+DROP TABLE IF EXISTS pricing.price_lists;
+DROP SCHEMA IF EXISTS pricing;
+
+ALTER TABLE users.users DROP COLUMN customer_group_id;
+DROP TABLE IF EXISTS users.customer_groups;
+-- +goose StatementEnd
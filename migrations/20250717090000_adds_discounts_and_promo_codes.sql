@@ -0,0 +1,137 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS orders.promo_codes (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value INTEGER NOT NULL CHECK (discount_value > 0),
+    max_uses INTEGER CHECK (max_uses > 0),
+    max_uses_per_user INTEGER CHECK (max_uses_per_user > 0),
+    used_count INTEGER NOT NULL DEFAULT 0,
+    valid_from TIMESTAMPTZ,
+    valid_to TIMESTAMPTZ,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (discount_type <> 'percent' OR discount_value <= 100),
+    CHECK (valid_from IS NULL OR valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS orders.promo_code_usages (
+    id SERIAL PRIMARY KEY,
+    promo_code_id INTEGER NOT NULL REFERENCES orders.promo_codes(id) ON DELETE CASCADE,
+    order_id INTEGER NOT NULL UNIQUE REFERENCES orders.orders(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    used_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_code_usages_code_user ON orders.promo_code_usages(promo_code_id, user_id);
+
+ALTER TABLE orders.orders
+ADD COLUMN subtotal INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN discount_total INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN discount_type VARCHAR(10) CHECK (discount_type IN ('percent', 'fixed')),
+ADD COLUMN discount_value INTEGER CHECK (discount_value > 0),
+ADD COLUMN promo_code_id INTEGER REFERENCES orders.promo_codes(id) ON DELETE SET NULL;
+
+ALTER TABLE orders.order_products
+ADD COLUMN discount_type VARCHAR(10) CHECK (discount_type IN ('percent', 'fixed')),
+ADD COLUMN discount_value INTEGER CHECK (discount_value > 0),
+ADD COLUMN discount_amount INTEGER NOT NULL DEFAULT 0;
+
+UPDATE orders.orders SET subtotal = total_cost;
+
+CREATE OR REPLACE FUNCTION orders.discount_amount(p_type VARCHAR, p_value INTEGER, p_base INTEGER)
+RETURNS INTEGER AS $$
+    SELECT CASE p_type
+        WHEN 'percent' THEN p_base * p_value / 100
+        WHEN 'fixed' THEN LEAST(p_value, p_base)
+        ELSE 0
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Пересчет сумм заказа: сначала применяются скидки по строкам, затем скидка на заказ.
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(discount_type, discount_value, quantity * sell_price)
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(quantity * sell_price), 0) AS subtotal,
+            COALESCE(SUM(discount_amount), 0) AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION update_order_total()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        RETURN NULL;
+    END IF;
+    PERFORM orders.recalculate_order_total(COALESCE(NEW.order_id, OLD.order_id));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE orders.promo_codes IS 'Промокоды со скидкой на заказ';
+COMMENT ON TABLE orders.promo_code_usages IS 'Использования промокодов в заказах';
+COMMENT ON COLUMN orders.orders.subtotal IS 'Сумма заказа без скидок';
+COMMENT ON COLUMN orders.orders.discount_total IS 'Сумма всех скидок заказа';
+COMMENT ON COLUMN orders.orders.total_cost IS 'Итоговая сумма заказа с учетом скидок';
+COMMENT ON COLUMN orders.order_products.discount_amount IS 'Скидка по строке заказа';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+CREATE OR REPLACE FUNCTION update_order_total()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE orders.orders
+    SET total_cost = (
+        SELECT SUM(op.quantity * op.sell_price)
+        FROM orders.order_products op
+        WHERE op.order_id = NEW.order_id
+    )
+    WHERE id = NEW.order_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS orders.recalculate_order_total;
+DROP FUNCTION IF EXISTS orders.discount_amount;
+
+ALTER TABLE orders.order_products
+DROP COLUMN discount_type,
+DROP COLUMN discount_value,
+DROP COLUMN discount_amount;
+
+ALTER TABLE orders.orders
+DROP COLUMN subtotal,
+DROP COLUMN discount_total,
+DROP COLUMN discount_type,
+DROP COLUMN discount_value,
+DROP COLUMN promo_code_id;
+
+DROP TABLE IF EXISTS orders.promo_code_usages;
+DROP TABLE IF EXISTS orders.promo_codes;
+-- +goose StatementEnd
@@ -0,0 +1,154 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE products.products
+ADD COLUMN is_bundle BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN bundle_pricing VARCHAR(10) CHECK (bundle_pricing IN ('fixed', 'components')),
+ADD COLUMN bundle_discount_type VARCHAR(10) CHECK (bundle_discount_type IN ('percent', 'fixed')),
+ADD COLUMN bundle_discount_value INTEGER CHECK (bundle_discount_value > 0);
+
+CREATE TABLE IF NOT EXISTS products.bundle_components (
+    bundle_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_components_component ON products.bundle_components(component_id);
+
+-- Сколько комплектов можно собрать из текущих остатков компонентов.
+CREATE OR REPLACE FUNCTION products.bundle_available(p_bundle_id INTEGER)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(MIN(p.quantity / bc.quantity), 0)
+    FROM products.bundle_components bc
+    JOIN products.products p ON p.id = bc.component_id
+    WHERE bc.bundle_id = p_bundle_id;
+$$ LANGUAGE sql STABLE;
+
+-- Остаток комплекта не хранится самостоятельно, а пересчитывается при изменении остатков компонентов.
+CREATE OR REPLACE FUNCTION products.refresh_bundles_by_component()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE products.products b
+    SET quantity = products.bundle_available(b.id)
+    WHERE b.is_bundle
+    AND b.id IN (
+        SELECT bundle_id FROM products.bundle_components WHERE component_id = NEW.id
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER refresh_bundles_on_component_quantity
+AFTER UPDATE OF quantity ON products.products
+FOR EACH ROW
+WHEN (NOT NEW.is_bundle AND OLD.quantity IS DISTINCT FROM NEW.quantity)
+EXECUTE FUNCTION products.refresh_bundles_by_component();
+
+CREATE OR REPLACE FUNCTION products.refresh_bundle_by_composition()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_bundle_id INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_bundle_id := OLD.bundle_id;
+    ELSE
+        v_bundle_id := NEW.bundle_id;
+    END IF;
+
+    UPDATE products.products
+    SET quantity = products.bundle_available(v_bundle_id)
+    WHERE id = v_bundle_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER refresh_bundle_on_composition_change
+AFTER INSERT OR UPDATE OR DELETE ON products.bundle_components
+FOR EACH ROW EXECUTE FUNCTION products.refresh_bundle_by_composition();
+
+-- Цена комплекта: из прайс-листа, фиксированная (sell_price)
+-- или сумма цен компонентов за вычетом скидки комплекта.
+CREATE OR REPLACE FUNCTION pricing.effective_price(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    v_product products.products%ROWTYPE;
+    v_price INTEGER;
+BEGIN
+    SELECT pli.price INTO v_price
+    FROM pricing.price_list_items pli
+    JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+    JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+    LEFT JOIN users.users u ON u.id = p_user_id
+    WHERE pli.product_id = p_product_id
+    AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+    AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+    AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+    ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+    LIMIT 1;
+
+    IF v_price IS NOT NULL THEN
+        RETURN v_price;
+    END IF;
+
+    SELECT * INTO v_product FROM products.products WHERE id = p_product_id;
+
+    IF v_product.is_bundle AND v_product.bundle_pricing = 'components' THEN
+        SELECT COALESCE(SUM(pricing.effective_price(bc.component_id, p_user_id) * bc.quantity), 0)
+        INTO v_price
+        FROM products.bundle_components bc
+        WHERE bc.bundle_id = p_product_id;
+
+        RETURN v_price - orders.discount_amount(
+            v_product.bundle_discount_type,
+            v_product.bundle_discount_value,
+            v_price
+        );
+    END IF;
+
+    RETURN v_product.sell_price;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+COMMENT ON TABLE products.bundle_components IS 'Состав комплектов (подарочных наборов)';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+CREATE OR REPLACE FUNCTION pricing.effective_price(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(
+        (
+            SELECT pli.price
+            FROM pricing.price_list_items pli
+            JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+            JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+            LEFT JOIN users.users u ON u.id = p_user_id
+            WHERE pli.product_id = p_product_id
+            AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+            AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+            AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+            ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+            LIMIT 1
+        ),
+        (SELECT sell_price FROM products.products WHERE id = p_product_id)
+    );
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER IF EXISTS refresh_bundle_on_composition_change ON products.bundle_components;
+DROP TRIGGER IF EXISTS refresh_bundles_on_component_quantity ON products.products;
+DROP FUNCTION IF EXISTS products.refresh_bundle_by_composition;
+DROP FUNCTION IF EXISTS products.refresh_bundles_by_component;
+DROP FUNCTION IF EXISTS products.bundle_available;
+DROP TABLE IF EXISTS products.bundle_components;
+
+ALTER TABLE products.products
+DROP COLUMN bundle_discount_value,
+DROP COLUMN bundle_discount_type,
+DROP COLUMN bundle_pricing,
+DROP COLUMN is_bundle;
+-- +goose StatementEnd
@@ -0,0 +1,160 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS products.units (
+    code VARCHAR(10) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    precision SMALLINT NOT NULL DEFAULT 0 CHECK (precision BETWEEN 0 AND 3)
+);
+
+INSERT INTO products.units (code, name, precision) VALUES
+    ('pcs', 'шт', 0),
+    ('pack', 'уп', 0),
+    ('box', 'кор', 0),
+    ('kg', 'кг', 3),
+    ('g', 'г', 0),
+    ('m', 'м', 3),
+    ('l', 'л', 3)
+ON CONFLICT (code) DO NOTHING;
+
+-- Триггер пересчета комплектов зависит от типа колонки quantity, пересоздаем его после смены типа.
+DROP TRIGGER IF EXISTS refresh_bundles_on_component_quantity ON products.products;
+
+ALTER TABLE products.products
+ALTER COLUMN quantity TYPE NUMERIC(14, 3),
+ADD COLUMN unit_code VARCHAR(10) NOT NULL DEFAULT 'pcs' REFERENCES products.units(code),
+ADD COLUMN pack_unit_code VARCHAR(10) REFERENCES products.units(code),
+ADD COLUMN pack_size NUMERIC(14, 3) CHECK (pack_size > 0),
+ADD CONSTRAINT chk_products_pack CHECK ((pack_unit_code IS NULL) = (pack_size IS NULL));
+
+CREATE TRIGGER refresh_bundles_on_component_quantity
+AFTER UPDATE OF quantity ON products.products
+FOR EACH ROW
+WHEN (NOT NEW.is_bundle AND OLD.quantity IS DISTINCT FROM NEW.quantity)
+EXECUTE FUNCTION products.refresh_bundles_by_component();
+
+ALTER TABLE orders.order_products
+ALTER COLUMN quantity TYPE NUMERIC(14, 3);
+
+ALTER TABLE products.bundle_components
+ALTER COLUMN quantity TYPE NUMERIC(14, 3);
+
+-- Комплект собирается только целиком.
+CREATE OR REPLACE FUNCTION products.bundle_available(p_bundle_id INTEGER)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(FLOOR(MIN(p.quantity / bc.quantity)), 0)::INTEGER
+    FROM products.bundle_components bc
+    JOIN products.products p ON p.id = bc.component_id
+    WHERE bc.bundle_id = p_bundle_id;
+$$ LANGUAGE sql STABLE;
+
+-- Стоимость строки с дробным количеством округляется до минимальной денежной единицы.
+CREATE OR REPLACE FUNCTION orders.line_cost(p_quantity NUMERIC, p_price INTEGER)
+RETURNS INTEGER AS $$
+    SELECT ROUND(p_quantity * p_price)::INTEGER;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::INTEGER AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::INTEGER AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+COMMENT ON TABLE products.units IS 'Справочник единиц измерения';
+COMMENT ON COLUMN products.units.precision IS 'Допустимое количество знаков после запятой';
+COMMENT ON COLUMN products.products.quantity IS 'Остаток в базовых единицах измерения';
+COMMENT ON COLUMN products.products.pack_size IS 'Количество базовых единиц в упаковке';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE products.bundle_components
+ALTER COLUMN quantity TYPE INTEGER USING CEIL(quantity);
+
+ALTER TABLE orders.order_products
+ALTER COLUMN quantity TYPE INTEGER USING CEIL(quantity);
+
+DROP TRIGGER IF EXISTS refresh_bundles_on_component_quantity ON products.products;
+
+ALTER TABLE products.products
+DROP CONSTRAINT chk_products_pack,
+DROP COLUMN pack_size,
+DROP COLUMN pack_unit_code,
+DROP COLUMN unit_code,
+ALTER COLUMN quantity TYPE INTEGER USING FLOOR(quantity);
+
+CREATE TRIGGER refresh_bundles_on_component_quantity
+AFTER UPDATE OF quantity ON products.products
+FOR EACH ROW
+WHEN (NOT NEW.is_bundle AND OLD.quantity IS DISTINCT FROM NEW.quantity)
+EXECUTE FUNCTION products.refresh_bundles_by_component();
+
+CREATE OR REPLACE FUNCTION products.bundle_available(p_bundle_id INTEGER)
+RETURNS INTEGER AS $$
+    SELECT COALESCE(MIN(p.quantity / bc.quantity), 0)
+    FROM products.bundle_components bc
+    JOIN products.products p ON p.id = bc.component_id
+    WHERE bc.bundle_id = p_bundle_id;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(discount_type, discount_value, quantity * sell_price)
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(quantity * sell_price), 0) AS subtotal,
+            COALESCE(SUM(discount_amount), 0) AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+DROP FUNCTION IF EXISTS orders.line_cost;
+
+DROP TABLE IF EXISTS products.units;
+-- +goose StatementEnd
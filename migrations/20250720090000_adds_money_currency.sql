@@ -0,0 +1,269 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Суммы хранятся в минимальных единицах валюты (копейках, центах).
+ALTER TABLE products.products
+ALTER COLUMN purchase_price TYPE BIGINT,
+ALTER COLUMN sell_price TYPE BIGINT,
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE products.price_history
+ALTER COLUMN purchase_price TYPE BIGINT,
+ALTER COLUMN sell_price TYPE BIGINT;
+
+ALTER TABLE pricing.price_list_items
+ALTER COLUMN price TYPE BIGINT;
+
+ALTER TABLE orders.order_products
+ALTER COLUMN sell_price TYPE BIGINT,
+ALTER COLUMN discount_amount TYPE BIGINT;
+
+ALTER TABLE orders.orders
+ALTER COLUMN total_cost TYPE BIGINT,
+ALTER COLUMN subtotal TYPE BIGINT,
+ALTER COLUMN discount_total TYPE BIGINT,
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE orders.promo_codes
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Смена типа результата требует пересоздания функций.
+DROP FUNCTION IF EXISTS orders.discount_amount(VARCHAR, INTEGER, INTEGER);
+DROP FUNCTION IF EXISTS orders.line_cost(NUMERIC, INTEGER);
+DROP FUNCTION IF EXISTS pricing.effective_price(INTEGER, INTEGER);
+
+CREATE FUNCTION orders.discount_amount(p_type VARCHAR, p_value BIGINT, p_base BIGINT)
+RETURNS BIGINT AS $$
+    SELECT CASE p_type
+        WHEN 'percent' THEN p_base * p_value / 100
+        WHEN 'fixed' THEN LEAST(p_value, p_base)
+        ELSE 0
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE FUNCTION orders.line_cost(p_quantity NUMERIC, p_price BIGINT)
+RETURNS BIGINT AS $$
+    SELECT ROUND(p_quantity * p_price)::BIGINT;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE FUNCTION pricing.effective_price(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS BIGINT AS $$
+DECLARE
+    v_product products.products%ROWTYPE;
+    v_price BIGINT;
+BEGIN
+    SELECT pli.price INTO v_price
+    FROM pricing.price_list_items pli
+    JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+    JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+    LEFT JOIN users.users u ON u.id = p_user_id
+    WHERE pli.product_id = p_product_id
+    AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+    AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+    AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+    ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+    LIMIT 1;
+
+    IF v_price IS NOT NULL THEN
+        RETURN v_price;
+    END IF;
+
+    SELECT * INTO v_product FROM products.products WHERE id = p_product_id;
+
+    IF v_product.is_bundle AND v_product.bundle_pricing = 'components' THEN
+        SELECT COALESCE(SUM(orders.line_cost(bc.quantity, pricing.effective_price(bc.component_id, p_user_id))), 0)
+        INTO v_price
+        FROM products.bundle_components bc
+        WHERE bc.bundle_id = p_product_id;
+
+        RETURN v_price - orders.discount_amount(
+            v_product.bundle_discount_type,
+            v_product.bundle_discount_value,
+            v_price
+        );
+    END IF;
+
+    RETURN v_product.sell_price;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- Валюта цены, которую вернет pricing.effective_price: валюта прайс-листа или товара.
+CREATE OR REPLACE FUNCTION pricing.effective_currency(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS CHAR(3) AS $$
+    SELECT COALESCE(
+        (
+            SELECT pl.currency
+            FROM pricing.price_list_items pli
+            JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+            JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+            LEFT JOIN users.users u ON u.id = p_user_id
+            WHERE pli.product_id = p_product_id
+            AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+            AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+            AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+            ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+            LIMIT 1
+        ),
+        (SELECT currency FROM products.products WHERE id = p_product_id)
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::BIGINT AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::BIGINT AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+COMMENT ON COLUMN products.products.currency IS 'Валюта цен товара (ISO 4217)';
+COMMENT ON COLUMN orders.orders.currency IS 'Валюта всех сумм заказа (ISO 4217)';
+COMMENT ON COLUMN orders.promo_codes.currency IS 'Валюта фиксированной скидки (ISO 4217)';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP FUNCTION IF EXISTS pricing.effective_currency;
+DROP FUNCTION IF EXISTS pricing.effective_price(INTEGER, INTEGER);
+DROP FUNCTION IF EXISTS orders.line_cost(NUMERIC, BIGINT);
+DROP FUNCTION IF EXISTS orders.discount_amount(VARCHAR, BIGINT, BIGINT);
+
+ALTER TABLE orders.promo_codes
+DROP COLUMN currency;
+
+ALTER TABLE orders.orders
+DROP COLUMN currency,
+ALTER COLUMN discount_total TYPE INTEGER,
+ALTER COLUMN subtotal TYPE INTEGER,
+ALTER COLUMN total_cost TYPE INTEGER;
+
+ALTER TABLE orders.order_products
+ALTER COLUMN discount_amount TYPE INTEGER,
+ALTER COLUMN sell_price TYPE INTEGER;
+
+ALTER TABLE pricing.price_list_items
+ALTER COLUMN price TYPE INTEGER;
+
+ALTER TABLE products.price_history
+ALTER COLUMN sell_price TYPE INTEGER,
+ALTER COLUMN purchase_price TYPE INTEGER;
+
+ALTER TABLE products.products
+DROP COLUMN currency,
+ALTER COLUMN sell_price TYPE INTEGER,
+ALTER COLUMN purchase_price TYPE INTEGER;
+
+CREATE FUNCTION orders.discount_amount(p_type VARCHAR, p_value INTEGER, p_base INTEGER)
+RETURNS INTEGER AS $$
+    SELECT CASE p_type
+        WHEN 'percent' THEN p_base * p_value / 100
+        WHEN 'fixed' THEN LEAST(p_value, p_base)
+        ELSE 0
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE FUNCTION orders.line_cost(p_quantity NUMERIC, p_price INTEGER)
+RETURNS INTEGER AS $$
+    SELECT ROUND(p_quantity * p_price)::INTEGER;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE FUNCTION pricing.effective_price(p_product_id INTEGER, p_user_id INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    v_product products.products%ROWTYPE;
+    v_price INTEGER;
+BEGIN
+    SELECT pli.price INTO v_price
+    FROM pricing.price_list_items pli
+    JOIN pricing.price_lists pl ON pl.id = pli.price_list_id
+    JOIN pricing.price_list_assignments a ON a.price_list_id = pl.id
+    LEFT JOIN users.users u ON u.id = p_user_id
+    WHERE pli.product_id = p_product_id
+    AND (a.user_id = p_user_id OR a.customer_group_id = u.customer_group_id)
+    AND (pl.valid_from IS NULL OR pl.valid_from <= NOW())
+    AND (pl.valid_to IS NULL OR pl.valid_to > NOW())
+    ORDER BY (a.user_id IS NOT NULL) DESC, pl.priority DESC, pli.price ASC
+    LIMIT 1;
+
+    IF v_price IS NOT NULL THEN
+        RETURN v_price;
+    END IF;
+
+    SELECT * INTO v_product FROM products.products WHERE id = p_product_id;
+
+    IF v_product.is_bundle AND v_product.bundle_pricing = 'components' THEN
+        SELECT COALESCE(SUM(pricing.effective_price(bc.component_id, p_user_id) * bc.quantity), 0)
+        INTO v_price
+        FROM products.bundle_components bc
+        WHERE bc.bundle_id = p_product_id;
+
+        RETURN v_price - orders.discount_amount(
+            v_product.bundle_discount_type,
+            v_product.bundle_discount_value,
+            v_price
+        );
+    END IF;
+
+    RETURN v_product.sell_price;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::INTEGER AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::INTEGER AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+-- +goose StatementEnd
@@ -0,0 +1,157 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE products.products
+ADD COLUMN vat_rate SMALLINT NOT NULL DEFAULT 20 CHECK (vat_rate IN (0, 10, 20));
+
+ALTER TABLE orders.orders
+ADD COLUMN tax_mode VARCHAR(10) NOT NULL DEFAULT 'inclusive' CHECK (tax_mode IN ('inclusive', 'exclusive')),
+ADD COLUMN tax_total BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders.order_products
+ADD COLUMN vat_rate SMALLINT NOT NULL DEFAULT 20 CHECK (vat_rate IN (0, 10, 20)),
+ADD COLUMN tax_base BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN tax_amount BIGINT NOT NULL DEFAULT 0;
+
+-- НДС, включенный в сумму, выделяется по расчетной ставке rate/(100+rate),
+-- начисляемый сверх суммы — по ставке rate/100.
+CREATE OR REPLACE FUNCTION orders.tax_amount(p_mode VARCHAR, p_rate INTEGER, p_base BIGINT)
+RETURNS BIGINT AS $$
+    SELECT CASE p_mode
+        WHEN 'exclusive' THEN ROUND(p_base * p_rate / 100.0)::BIGINT
+        ELSE ROUND(p_base * p_rate / (100.0 + p_rate))::BIGINT
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Пересчет сумм заказа: скидки по строкам, скидка на заказ, затем НДС по строкам.
+-- Скидка на заказ распределяется по строкам пропорционально их сумме после скидок по строкам.
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::BIGINT AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::BIGINT AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+
+    WITH lines AS (
+        SELECT product_id, orders.line_cost(quantity, sell_price) - discount_amount AS net
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), sums AS (
+        SELECT COALESCE(SUM(net), 0)::BIGINT AS net_total FROM lines
+    ), bases AS (
+        SELECT
+            l.product_id,
+            l.net - CASE
+                WHEN s.net_total = 0 THEN 0
+                ELSE ROUND((o.discount_total - (o.subtotal - s.net_total))::NUMERIC * l.net / s.net_total)::BIGINT
+            END AS base
+        FROM lines l, sums s, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.order_products op
+    SET tax_base = b.base,
+        tax_amount = orders.tax_amount(o.tax_mode, op.vat_rate, b.base)
+    FROM bases b, orders.orders o
+    WHERE op.order_id = p_order_id
+    AND op.product_id = b.product_id
+    AND o.id = p_order_id;
+
+    UPDATE orders.orders o
+    SET tax_total = t.tax_total,
+        total_cost = o.subtotal - o.discount_total
+            + CASE WHEN o.tax_mode = 'exclusive' THEN t.tax_total ELSE 0 END
+    FROM (
+        SELECT COALESCE(SUM(tax_amount), 0)::BIGINT AS tax_total
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ) t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+-- Заказы, созданные до появления НДС, считаются с НДС в цене по ставке по умолчанию.
+SELECT orders.recalculate_order_total(id) FROM orders.orders;
+
+COMMENT ON COLUMN products.products.vat_rate IS 'Ставка НДС, %';
+COMMENT ON COLUMN orders.orders.tax_mode IS 'inclusive — НДС в цене, exclusive — НДС сверх цены';
+COMMENT ON COLUMN orders.orders.tax_total IS 'Сумма НДС по заказу';
+COMMENT ON COLUMN orders.order_products.vat_rate IS 'Ставка НДС товара на момент добавления в заказ';
+COMMENT ON COLUMN orders.order_products.tax_base IS 'Сумма строки с учетом всех скидок, к которой применяется ставка';
+COMMENT ON COLUMN orders.order_products.tax_amount IS 'Сумма НДС по строке';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::BIGINT AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::BIGINT AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total,
+        total_cost = t.subtotal - t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+DROP FUNCTION IF EXISTS orders.tax_amount;
+
+SELECT orders.recalculate_order_total(id) FROM orders.orders WHERE tax_mode = 'exclusive';
+
+ALTER TABLE orders.order_products
+DROP COLUMN tax_amount,
+DROP COLUMN tax_base,
+DROP COLUMN vat_rate;
+
+ALTER TABLE orders.orders
+DROP COLUMN tax_total,
+DROP COLUMN tax_mode;
+
+ALTER TABLE products.products
+DROP COLUMN vat_rate;
+-- +goose StatementEnd
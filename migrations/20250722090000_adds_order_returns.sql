@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS orders.returns (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders.orders(id) ON DELETE RESTRICT,
+    user_id INTEGER NOT NULL REFERENCES users.users(id),
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    refund_amount BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    comment TEXT,
+    decided_by INTEGER REFERENCES users.users(id) ON DELETE SET NULL,
+    decided_date TIMESTAMPTZ,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON orders.returns(order_id);
+CREATE INDEX IF NOT EXISTS idx_returns_user_id ON orders.returns(user_id);
+
+CREATE TABLE IF NOT EXISTS orders.return_lines (
+    return_id INTEGER NOT NULL REFERENCES orders.returns(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE RESTRICT,
+    quantity NUMERIC(14, 3) NOT NULL CHECK (quantity > 0),
+    reason TEXT NOT NULL,
+    refund_amount BIGINT NOT NULL DEFAULT 0,
+    damaged BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (return_id, product_id)
+);
+
+-- Журнал списаний: поврежденные возвращенные товары не попадают обратно на склад.
+CREATE TABLE IF NOT EXISTS products.write_offs (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products.products(id) ON DELETE RESTRICT,
+    quantity NUMERIC(14, 3) NOT NULL CHECK (quantity > 0),
+    reason TEXT NOT NULL,
+    return_id INTEGER REFERENCES orders.returns(id) ON DELETE SET NULL,
+    created_by INTEGER REFERENCES users.users(id) ON DELETE SET NULL,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_write_offs_product_id ON products.write_offs(product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS products.write_offs;
+DROP TABLE IF EXISTS orders.return_lines;
+DROP TABLE IF EXISTS orders.returns;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Товар в корзине не ссылается на products.products внешним ключом: удаление товара из каталога
+-- не должно молча убирать его из корзины, клиент увидит причину при проверке корзины.
+CREATE TABLE IF NOT EXISTS orders.cart_items (
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL,
+    quantity NUMERIC(14, 3) NOT NULL CHECK (quantity > 0),
+    added_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, product_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS orders.cart_items;
+-- +goose StatementEnd
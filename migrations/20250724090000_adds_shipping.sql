@@ -0,0 +1,236 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE products.products
+ADD COLUMN weight INTEGER CHECK (weight >= 0);
+
+CREATE TABLE IF NOT EXISTS users.addresses (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    label VARCHAR(100),
+    recipient VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL,
+    postal_code VARCHAR(20) NOT NULL DEFAULT '',
+    city VARCHAR(255) NOT NULL,
+    street VARCHAR(500) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- У пользователя не больше одного адреса по умолчанию.
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_user_default_idx
+ON users.addresses (user_id) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS orders.shipping_methods (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    rule VARCHAR(20) NOT NULL CHECK (rule IN ('flat', 'weight', 'free_over')),
+    base_cost BIGINT NOT NULL DEFAULT 0 CHECK (base_cost >= 0),
+    cost_per_kg BIGINT CHECK (cost_per_kg >= 0),
+    free_threshold BIGINT CHECK (free_threshold >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE orders.orders
+ADD COLUMN shipping_method_id INTEGER REFERENCES orders.shipping_methods(id) ON DELETE SET NULL,
+ADD COLUMN shipping_cost BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN address_id INTEGER REFERENCES users.addresses(id) ON DELETE SET NULL,
+ADD COLUMN shipping_address TEXT,
+ADD COLUMN carrier VARCHAR(100),
+ADD COLUMN tracking_number VARCHAR(100),
+ADD COLUMN shipped_date TIMESTAMPTZ;
+
+-- Стоимость доставки по правилу способа: flat — base_cost, weight — base_cost плюс cost_per_kg
+-- за каждый начатый килограмм, free_over — бесплатно от free_threshold, иначе base_cost.
+CREATE OR REPLACE FUNCTION orders.shipping_cost(p_method_id INTEGER, p_goods_total BIGINT, p_weight_grams BIGINT)
+RETURNS BIGINT AS $$
+    SELECT CASE m.rule
+        WHEN 'weight' THEN m.base_cost + CEIL(p_weight_grams / 1000.0)::BIGINT * COALESCE(m.cost_per_kg, 0)
+        WHEN 'free_over' THEN CASE
+            WHEN m.free_threshold IS NOT NULL AND p_goods_total >= m.free_threshold THEN 0
+            ELSE m.base_cost
+        END
+        ELSE m.base_cost
+    END
+    FROM orders.shipping_methods m
+    WHERE m.id = p_method_id;
+$$ LANGUAGE sql STABLE;
+
+-- Пересчет сумм заказа: скидки по строкам, скидка на заказ, НДС по строкам, затем доставка.
+-- Скидка на заказ распределяется по строкам пропорционально их сумме после скидок по строкам.
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::BIGINT AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::BIGINT AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+
+    WITH lines AS (
+        SELECT product_id, orders.line_cost(quantity, sell_price) - discount_amount AS net
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), sums AS (
+        SELECT COALESCE(SUM(net), 0)::BIGINT AS net_total FROM lines
+    ), bases AS (
+        SELECT
+            l.product_id,
+            l.net - CASE
+                WHEN s.net_total = 0 THEN 0
+                ELSE ROUND((o.discount_total - (o.subtotal - s.net_total))::NUMERIC * l.net / s.net_total)::BIGINT
+            END AS base
+        FROM lines l, sums s, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.order_products op
+    SET tax_base = b.base,
+        tax_amount = orders.tax_amount(o.tax_mode, op.vat_rate, b.base)
+    FROM bases b, orders.orders o
+    WHERE op.order_id = p_order_id
+    AND op.product_id = b.product_id
+    AND o.id = p_order_id;
+
+    UPDATE orders.orders o
+    SET tax_total = t.tax_total
+    FROM (
+        SELECT COALESCE(SUM(tax_amount), 0)::BIGINT AS tax_total
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ) t
+    WHERE o.id = p_order_id;
+
+    UPDATE orders.orders o
+    SET shipping_cost = COALESCE(orders.shipping_cost(o.shipping_method_id, o.subtotal - o.discount_total, w.weight), 0),
+        total_cost = o.subtotal - o.discount_total
+            + CASE WHEN o.tax_mode = 'exclusive' THEN o.tax_total ELSE 0 END
+            + COALESCE(orders.shipping_cost(o.shipping_method_id, o.subtotal - o.discount_total, w.weight), 0)
+    FROM (
+        SELECT COALESCE(SUM(ROUND(op.quantity * COALESCE(p.weight, 0))), 0)::BIGINT AS weight
+        FROM orders.order_products op
+        LEFT JOIN products.products p ON p.id = op.product_id
+        WHERE op.order_id = p_order_id
+    ) w
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+COMMENT ON COLUMN products.products.weight IS 'Вес базовой единицы товара, г';
+COMMENT ON COLUMN orders.orders.shipping_cost IS 'Стоимость доставки, входит в total_cost';
+COMMENT ON COLUMN orders.orders.shipping_address IS 'Адрес доставки на момент оформления заказа';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+-- Пересчет сумм заказа: скидки по строкам, скидка на заказ, затем НДС по строкам.
+-- Скидка на заказ распределяется по строкам пропорционально их сумме после скидок по строкам.
+CREATE OR REPLACE FUNCTION orders.recalculate_order_total(p_order_id INTEGER)
+RETURNS VOID AS $$
+    UPDATE orders.order_products
+    SET discount_amount = orders.discount_amount(
+        discount_type,
+        discount_value,
+        orders.line_cost(quantity, sell_price)
+    )
+    WHERE order_id = p_order_id;
+
+    WITH lines AS (
+        SELECT
+            COALESCE(SUM(orders.line_cost(quantity, sell_price)), 0)::BIGINT AS subtotal,
+            COALESCE(SUM(discount_amount), 0)::BIGINT AS line_discount
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), totals AS (
+        SELECT
+            l.subtotal,
+            l.line_discount + orders.discount_amount(o.discount_type, o.discount_value, l.subtotal - l.line_discount)
+                AS discount_total
+        FROM lines l, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.orders o
+    SET subtotal = t.subtotal,
+        discount_total = t.discount_total
+    FROM totals t
+    WHERE o.id = p_order_id;
+
+    WITH lines AS (
+        SELECT product_id, orders.line_cost(quantity, sell_price) - discount_amount AS net
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ), sums AS (
+        SELECT COALESCE(SUM(net), 0)::BIGINT AS net_total FROM lines
+    ), bases AS (
+        SELECT
+            l.product_id,
+            l.net - CASE
+                WHEN s.net_total = 0 THEN 0
+                ELSE ROUND((o.discount_total - (o.subtotal - s.net_total))::NUMERIC * l.net / s.net_total)::BIGINT
+            END AS base
+        FROM lines l, sums s, orders.orders o
+        WHERE o.id = p_order_id
+    )
+    UPDATE orders.order_products op
+    SET tax_base = b.base,
+        tax_amount = orders.tax_amount(o.tax_mode, op.vat_rate, b.base)
+    FROM bases b, orders.orders o
+    WHERE op.order_id = p_order_id
+    AND op.product_id = b.product_id
+    AND o.id = p_order_id;
+
+    UPDATE orders.orders o
+    SET tax_total = t.tax_total,
+        total_cost = o.subtotal - o.discount_total
+            + CASE WHEN o.tax_mode = 'exclusive' THEN t.tax_total ELSE 0 END
+    FROM (
+        SELECT COALESCE(SUM(tax_amount), 0)::BIGINT AS tax_total
+        FROM orders.order_products
+        WHERE order_id = p_order_id
+    ) t
+    WHERE o.id = p_order_id;
+$$ LANGUAGE sql;
+
+DROP FUNCTION IF EXISTS orders.shipping_cost;
+
+ALTER TABLE orders.orders
+DROP COLUMN shipped_date,
+DROP COLUMN tracking_number,
+DROP COLUMN carrier,
+DROP COLUMN shipping_address,
+DROP COLUMN address_id,
+DROP COLUMN shipping_cost,
+DROP COLUMN shipping_method_id;
+
+SELECT orders.recalculate_order_total(id) FROM orders.orders;
+
+DROP TABLE IF EXISTS orders.shipping_methods;
+DROP TABLE IF EXISTS users.addresses;
+
+ALTER TABLE products.products
+DROP COLUMN weight;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS orders.order_comments (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders.orders(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES orders.order_comments(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users.users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    internal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS order_comments_order_idx ON orders.order_comments (order_id, created_date);
+
+CREATE TABLE IF NOT EXISTS orders.comment_attachments (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES orders.order_comments(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL DEFAULT 'application/octet-stream',
+    size BIGINT NOT NULL DEFAULT 0 CHECK (size >= 0),
+    url TEXT NOT NULL
+);
+
+COMMENT ON COLUMN orders.order_comments.internal IS 'Внутренняя заметка, клиенту не показывается';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS orders.comment_attachments;
+DROP TABLE IF EXISTS orders.order_comments;
+-- +goose StatementEnd
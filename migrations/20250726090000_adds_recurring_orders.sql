@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS orders.recurring_orders (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    start_date TIMESTAMPTZ NOT NULL,
+    next_run_date TIMESTAMPTZ NOT NULL,
+    last_run_date TIMESTAMPTZ,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused')),
+    address_id INTEGER REFERENCES users.addresses(id) ON DELETE SET NULL,
+    shipping_method_id INTEGER REFERENCES orders.shipping_methods(id) ON DELETE SET NULL,
+    tax_mode VARCHAR(10) CHECK (tax_mode IN ('inclusive', 'exclusive')),
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS recurring_orders_due_idx
+ON orders.recurring_orders (next_run_date) WHERE status = 'active';
+
+-- Как и в корзине, товар не ссылается на каталог внешним ключом: если товар удален,
+-- запуск завершится ошибкой и владелец получит уведомление.
+CREATE TABLE IF NOT EXISTS orders.recurring_order_products (
+    recurring_order_id INTEGER NOT NULL REFERENCES orders.recurring_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL,
+    quantity NUMERIC(14, 3) NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (recurring_order_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS orders.recurring_order_runs (
+    id SERIAL PRIMARY KEY,
+    recurring_order_id INTEGER NOT NULL REFERENCES orders.recurring_orders(id) ON DELETE CASCADE,
+    scheduled_date TIMESTAMPTZ NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('created', 'failed', 'skipped')),
+    order_id INTEGER REFERENCES orders.orders(id) ON DELETE SET NULL,
+    error TEXT,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS users.notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON users.notifications (user_id, created_date DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS users.notifications;
+DROP TABLE IF EXISTS orders.recurring_order_runs;
+DROP TABLE IF EXISTS orders.recurring_order_products;
+DROP TABLE IF EXISTS orders.recurring_orders;
+-- +goose StatementEnd
@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE IF NOT EXISTS users.roles (
+    key VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    system BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS users.role_permissions (
+    role_key VARCHAR(20) NOT NULL REFERENCES users.roles(key) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_key, permission)
+);
+
+INSERT INTO users.roles (key, name, system) VALUES
+    ('client', 'Клиент', TRUE),
+    ('employee', 'Сотрудник', TRUE),
+    ('admin', 'Администратор', TRUE),
+    ('warehouse', 'Кладовщик', TRUE)
+ON CONFLICT (key) DO NOTHING;
+
+-- Сотрудник сохраняет все права, которые у него были до появления ролей,
+-- управление ролями есть только у администратора.
+INSERT INTO users.role_permissions (role_key, permission) VALUES
+    ('client', 'orders:read'),
+    ('client', 'orders:write'),
+    ('employee', 'orders:read'),
+    ('employee', 'orders:read_all'),
+    ('employee', 'orders:write'),
+    ('employee', 'orders:execute'),
+    ('employee', 'orders:discount'),
+    ('employee', 'products:write'),
+    ('employee', 'pricing:manage'),
+    ('employee', 'shipping:manage'),
+    ('employee', 'returns:decide'),
+    ('employee', 'users:manage'),
+    ('admin', 'orders:read'),
+    ('admin', 'orders:read_all'),
+    ('admin', 'orders:write'),
+    ('admin', 'orders:execute'),
+    ('admin', 'orders:discount'),
+    ('admin', 'products:write'),
+    ('admin', 'pricing:manage'),
+    ('admin', 'shipping:manage'),
+    ('admin', 'returns:decide'),
+    ('admin', 'users:manage'),
+    ('admin', 'roles:manage'),
+    ('warehouse', 'orders:read'),
+    ('warehouse', 'orders:read_all'),
+    ('warehouse', 'orders:execute'),
+    ('warehouse', 'products:write')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users.users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users.users
+ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES users.roles(key);
+
+COMMENT ON COLUMN users.users.role IS 'Роль пользователя, ключ из users.roles';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+UPDATE users.users SET role = 'employee' WHERE role NOT IN ('client', 'employee');
+ALTER TABLE users.users DROP CONSTRAINT IF EXISTS users_role_fkey;
+ALTER TABLE users.users
+ADD CONSTRAINT users_role_check CHECK (role IN ('client', 'employee'));
+
+COMMENT ON COLUMN users.users.role IS 'Роль пользователя: client или employee';
+
+DROP TABLE IF EXISTS users.role_permissions;
+DROP TABLE IF EXISTS users.roles;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE users.users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+-- Пользователи, зарегистрированные до появления подтверждения, считаются подтвержденными.
+UPDATE users.users SET email_verified_at = NOW() WHERE email_verified_at IS NULL;
+
+COMMENT ON COLUMN users.users.email_verified_at IS 'Дата подтверждения email, NULL — email не подтвержден';
+
+-- Токен подтверждает конкретный адрес: если email изменился после отправки письма, ссылка недействительна.
+CREATE TABLE IF NOT EXISTS users.email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_date TIMESTAMPTZ,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS email_verification_tokens_user_idx
+    ON users.email_verification_tokens (user_id, created_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS users.email_verification_tokens;
+ALTER TABLE users.users DROP COLUMN IF EXISTS email_verified_at;
+-- +goose StatementEnd
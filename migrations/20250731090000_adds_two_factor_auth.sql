@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Секрет TOTP пользователя. Пока enabled_at пуст, настройка не подтверждена кодом
+-- и при входе не требуется.
+CREATE TABLE IF NOT EXISTS users.two_factor (
+    user_id INTEGER PRIMARY KEY REFERENCES users.users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Одноразовые коды восстановления на случай потери устройства. Хранится только SHA-256.
+CREATE TABLE IF NOT EXISTS users.recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_date TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE IF EXISTS users.recovery_codes;
+DROP TABLE IF EXISTS users.two_factor;
+-- +goose StatementEnd
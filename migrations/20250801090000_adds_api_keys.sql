@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Ключи API для интеграций (1С, синхронизация с маркетплейсами). Ключ действует от имени
+-- пользователя user_id, но только в пределах своих разрешений. Хранится SHA-256 ключа,
+-- префикс хранится открыто, чтобы ключ можно было опознать в списке и в журналах.
+CREATE TABLE IF NOT EXISTS users.api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES users.users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_by INTEGER REFERENCES users.users(id) ON DELETE SET NULL,
+    created_date TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS users.api_key_scopes (
+    api_key_id INTEGER NOT NULL REFERENCES users.api_keys(id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (api_key_id, permission)
+);
+
+INSERT INTO users.role_permissions (role_key, permission) VALUES
+    ('admin', 'api_keys:manage')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DELETE FROM users.role_permissions WHERE permission = 'api_keys:manage';
+DROP TABLE IF EXISTS users.api_key_scopes;
+DROP TABLE IF EXISTS users.api_keys;
+-- +goose StatementEnd
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type ErrorType string
@@ -13,16 +14,18 @@ const (
 	ErrorTypeNotFound     ErrorType = "NOT_FOUND"
 	ErrorTypeUnauthorized ErrorType = "UNAUTHORIZED"
 	ErrorTypeForbidden    ErrorType = "FORBIDDEN"
+	ErrorTypeTooMany      ErrorType = "TOO_MANY_REQUESTS"
 	ErrorTypeInternal     ErrorType = "INTERNAL_ERROR"
 	ErrorTypeDatabase     ErrorType = "DATABASE_ERROR"
 )
 
 type AppError struct {
-	Type       ErrorType `json:"type"`
-	Message    string    `json:"message"`
-	Code       int       `json:"code"`
-	Internal   error     `json:"-"`
-	StackTrace string    `json:"-"`
+	Type       ErrorType     `json:"type"`
+	Message    string        `json:"message"`
+	Code       int           `json:"code"`
+	Internal   error         `json:"-"`
+	StackTrace string        `json:"-"`
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -72,6 +75,22 @@ func NewForbiddenError(message string, internal error) *AppError {
 	}
 }
 
+func NewTooManyRequestsError(message string, internal error) *AppError {
+	return &AppError{
+		Type:     ErrorTypeTooMany,
+		Message:  message,
+		Code:     http.StatusTooManyRequests,
+		Internal: internal,
+	}
+}
+
+// WithRetryAfter указывает, через сколько клиент может повторить запрос.
+// Значение передается клиенту в заголовке Retry-After.
+func (e *AppError) WithRetryAfter(retryAfter time.Duration) *AppError {
+	e.RetryAfter = retryAfter
+	return e
+}
+
 func NewDatabaseError(operation string, internal error) *AppError {
 	return &AppError{
 		Type:     ErrorTypeDatabase,